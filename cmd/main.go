@@ -4,9 +4,11 @@ import (
 	"os"
 
 	"github.com/innovationmech/simple-cli/internal/cmd"
+	"github.com/innovationmech/simple-cli/internal/logging"
 )
 
 func run() int {
+	defer logging.Sync()
 	rootCmd := cmd.NewRootCmd()
 	if err := rootCmd.Execute(); err != nil {
 		return 1
@@ -1,44 +1,167 @@
 package app
 
 import (
+	"github.com/innovationmech/simple-cli/internal/config"
 	"github.com/innovationmech/simple-cli/internal/interfaces"
+	"github.com/innovationmech/simple-cli/internal/pricing"
 	"github.com/innovationmech/simple-cli/internal/repository"
+	addressSrv "github.com/innovationmech/simple-cli/internal/service/address"
+	cartSrv "github.com/innovationmech/simple-cli/internal/service/cart"
+	categorySrv "github.com/innovationmech/simple-cli/internal/service/category"
+	inventorySrv "github.com/innovationmech/simple-cli/internal/service/inventory"
+	mediaSrv "github.com/innovationmech/simple-cli/internal/service/media"
+	orderSrv "github.com/innovationmech/simple-cli/internal/service/order"
+	paymentSrv "github.com/innovationmech/simple-cli/internal/service/payment"
 	productSrv "github.com/innovationmech/simple-cli/internal/service/product"
+	promotionSrv "github.com/innovationmech/simple-cli/internal/service/promotion"
+	returnSrv "github.com/innovationmech/simple-cli/internal/service/returns"
 	userSrv "github.com/innovationmech/simple-cli/internal/service/user"
+	"github.com/innovationmech/simple-cli/internal/storage"
 	"gorm.io/gorm"
 )
 
 // Container 集中管理所有依赖，确保单例
 // 当组件被多处使用时，通过 Container 共享同一实例
 type Container struct {
-	DB *gorm.DB
+	DB        *gorm.DB
+	TxManager repository.TxManager
 
 	// Repositories
-	UserRepo    repository.UserRepository
-	ProductRepo repository.ProductRepository
+	UserRepo      repository.UserRepository
+	AddressRepo   repository.AddressRepository
+	ProductRepo   repository.ProductRepository
+	CategoryRepo  repository.CategoryRepository
+	OrderRepo     repository.OrderRepository
+	PaymentRepo   repository.PaymentRepository
+	ReturnRepo    repository.ReturnRepository
+	CartRepo      repository.CartRepository
+	CouponRepo    repository.CouponRepository
+	ImageRepo     repository.ImageRepository
+	InventoryRepo repository.InventoryRepository
+	// IdempotencyRepo 幂等键，由 Idempotency-Key 中间件使用
+	IdempotencyRepo repository.IdempotencyRepository
+
+	// BlobStore 文件存储，根据 storage 配置选择本地文件系统或 S3 兼容存储
+	BlobStore storage.BlobStore
 
 	// Services
-	UserService    interfaces.UserService
-	ProductService interfaces.ProductService
+	UserService      interfaces.UserService
+	AddressService   interfaces.AddressService
+	ProductService   interfaces.ProductService
+	CategoryService  interfaces.CategoryService
+	PromotionService interfaces.PromotionService
+	OrderService     interfaces.OrderService
+	PaymentService   interfaces.PaymentService
+	ReturnService    interfaces.ReturnService
+	CartService      interfaces.CartService
+	MediaService     interfaces.MediaService
+	InventoryService interfaces.InventoryService
 }
 
 // NewContainer 创建并初始化依赖容器
-// 按照依赖顺序初始化：DB → Repositories → Services
+// 按照依赖顺序初始化：DB → Repositories/Storage → Services
 func NewContainer(db *gorm.DB) (*Container, error) {
-	c := &Container{DB: db}
+	c := &Container{DB: db, TxManager: repository.NewTxManager(db)}
 
 	// 初始化 Repositories
 	c.UserRepo = repository.NewUserRepository(db)
+	c.AddressRepo = repository.NewAddressRepository(db)
 	c.ProductRepo = repository.NewProductRepository(db)
+	c.CategoryRepo = repository.NewCategoryRepository(db)
+	c.OrderRepo = repository.NewOrderRepository(db)
+	c.PaymentRepo = repository.NewPaymentRepository(db)
+	c.ReturnRepo = repository.NewReturnRepository(db)
+	c.CartRepo = repository.NewCartRepository(db)
+	c.CouponRepo = repository.NewCouponRepository(db)
+	c.ImageRepo = repository.NewImageRepository(db)
+	c.InventoryRepo = repository.NewInventoryRepository(db)
+	c.IdempotencyRepo = repository.NewIdempotencyRepository(db)
+
+	storageConfig := config.Storage()
+	blobStore, err := storage.New(storageConfig)
+	if err != nil {
+		return nil, err
+	}
+	c.BlobStore = blobStore
 
 	// 初始化 Services
-	var err error
 	c.UserService, err = userSrv.NewUserService(userSrv.WithUserRepository(c.UserRepo))
 	if err != nil {
 		return nil, err
 	}
 
-	c.ProductService, err = productSrv.NewProductService(productSrv.WithProductRepository(c.ProductRepo))
+	c.AddressService, err = addressSrv.NewAddressService(
+		addressSrv.WithAddressRepository(c.AddressRepo),
+		addressSrv.WithUserRepository(c.UserRepo),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.CategoryService, err = categorySrv.NewCategoryService(categorySrv.WithCategoryRepository(c.CategoryRepo))
+	if err != nil {
+		return nil, err
+	}
+
+	c.ProductService, err = productSrv.NewProductService(
+		productSrv.WithProductRepository(c.ProductRepo),
+		productSrv.WithCategoryRepository(c.CategoryRepo),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.MediaService, err = mediaSrv.NewMediaService(
+		mediaSrv.WithImageRepository(c.ImageRepo),
+		mediaSrv.WithProductRepository(c.ProductRepo),
+		mediaSrv.WithBlobStore(c.BlobStore),
+		mediaSrv.WithPublicURL(storageConfig.PublicURL),
+		mediaSrv.WithMaxBytes(storageConfig.Images.MaxBytes),
+		mediaSrv.WithThumbnailWidth(storageConfig.Images.ThumbnailWidth),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.InventoryService, err = inventorySrv.NewInventoryService(
+		inventorySrv.WithProductRepository(c.ProductRepo),
+		inventorySrv.WithInventoryRepository(c.InventoryRepo),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.PromotionService = promotionSrv.NewPromotionService(c.CouponRepo, c.CategoryRepo, c.TxManager)
+
+	// 购物车结算需要通过订单服务下单
+	c.OrderService = orderSrv.NewOrderService(
+		c.OrderRepo, c.ProductRepo, c.AddressRepo, c.PromotionService,
+		pricing.NewDefaultPipeline(c.ProductRepo, c.PromotionService), c.TxManager,
+	)
+
+	c.CartService, err = cartSrv.NewCartService(
+		cartSrv.WithCartRepository(c.CartRepo),
+		cartSrv.WithProductRepository(c.ProductRepo),
+		cartSrv.WithUserRepository(c.UserRepo),
+		cartSrv.WithOrderService(c.OrderService),
+		cartSrv.WithTxManager(c.TxManager),
+		cartSrv.WithIdleTimeout(config.CartIdleTimeout()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// 退货流程需要通过支付服务发起退款
+	c.PaymentService = paymentSrv.NewPaymentService(c.PaymentRepo, c.OrderRepo, c.TxManager)
+
+	c.ReturnService, err = returnSrv.NewReturnService(
+		returnSrv.WithReturnRepository(c.ReturnRepo),
+		returnSrv.WithOrderRepository(c.OrderRepo),
+		returnSrv.WithProductRepository(c.ProductRepo),
+		returnSrv.WithPaymentRepository(c.PaymentRepo),
+		returnSrv.WithPaymentService(c.PaymentService),
+		returnSrv.WithTxManager(c.TxManager),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -1,10 +1,14 @@
 package cmd
 
 import (
-	"log"
+	"fmt"
 
+	"github.com/innovationmech/simple-cli/internal/cmd/openapi"
+	"github.com/innovationmech/simple-cli/internal/cmd/purge"
 	"github.com/innovationmech/simple-cli/internal/cmd/serve"
 	"github.com/innovationmech/simple-cli/internal/cmd/version"
+	"github.com/innovationmech/simple-cli/internal/config"
+	"github.com/innovationmech/simple-cli/internal/logging"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -17,6 +21,10 @@ func NewRootCmd() *cobra.Command {
 		Use:   "simple-cli",
 		Short: "Simple CLI",
 		Long:  "Simple CLI",
+		// 子命令执行前读取配置并初始化日志，出错时由 cobra 输出错误并以非零状态退出
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return initConfig()
+		},
 	}
 
 	rootCmd.PersistentFlags().Int("port", 8080, "Port to listen on")
@@ -30,11 +38,14 @@ func NewRootCmd() *cobra.Command {
 
 	rootCmd.AddCommand(version.NewVersionCmd())
 	rootCmd.AddCommand(serve.NewServeCmd())
+	rootCmd.AddCommand(purge.NewPurgeCmd())
+	rootCmd.AddCommand(openapi.NewOpenAPICmd())
 
 	return rootCmd
 }
 
-func initConfig() {
+// initConfig 读取配置文件，并按 log 配置初始化结构化日志
+func initConfig() error {
 	if cfgFile != "" {
 		viper.SetConfigFile(cfgFile)
 	} else {
@@ -43,10 +54,7 @@ func initConfig() {
 		viper.AddConfigPath(".")
 	}
 	if err := viper.ReadInConfig(); err != nil {
-		log.Fatalf("Error reading config file: %s", err)
+		return fmt.Errorf("reading config file: %w", err)
 	}
-}
-
-func init() {
-	cobra.OnInitialize(initConfig)
+	return logging.Setup(config.Logging().Logger())
 }
@@ -0,0 +1,83 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/innovationmech/simple-cli/internal/server"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+// NewOpenAPICmd OpenAPI 文档相关命令
+func NewOpenAPICmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "openapi",
+		Short: "OpenAPI document tools",
+		Long:  "OpenAPI document tools",
+	}
+	cmd.AddCommand(newExportCmd())
+	return cmd
+}
+
+// newExportCmd 将 OpenAPI 文档输出到标准输出，供 CI 比对接口变更
+func newExportCmd() *cobra.Command {
+	var format string
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the OpenAPI document",
+		Long:  "Write the OpenAPI document generated from the registered routes to stdout",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := json.MarshalIndent(server.ExportDocument(), "", "  ")
+			if err != nil {
+				return err
+			}
+			switch format {
+			case "json":
+			case "yaml":
+				if data, err = toYAML(data); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("unsupported format %q, expected yaml or json", format)
+			}
+			_, err = cmd.OutOrStdout().Write(append(data, '\n'))
+			return err
+		},
+	}
+	cmd.Flags().StringVar(&format, "format", "yaml", "output format: yaml or json")
+	return cmd
+}
+
+// toYAML 将 JSON 转换为块格式的 YAML，保留字段顺序
+func toYAML(data []byte) ([]byte, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	blockStyle(&node)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+// blockStyle 清除解析 JSON 时得到的流式风格，字符串仍按需加引号
+func blockStyle(node *yaml.Node) {
+	if node.Kind != yaml.ScalarNode {
+		node.Style = 0
+	} else if node.Style == yaml.DoubleQuotedStyle {
+		node.Style = 0
+	}
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
@@ -0,0 +1,66 @@
+package purge
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/innovationmech/simple-cli/internal/app"
+	"github.com/innovationmech/simple-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// defaultRetentionDays 软删除数据的默认保留天数
+const defaultRetentionDays = 30
+
+// NewPurgeCmd 彻底删除软删除超过指定天数的商品与用户，并清理已过期的幂等键
+// 商品的 SKU、分类关联、价格与库存记录以及图片文件一并删除；用户的地址簿与购物车一并删除
+func NewPurgeCmd() *cobra.Command {
+	var days int
+	cmd := &cobra.Command{
+		Use:   "purge",
+		Short: "Hard-delete soft-deleted records",
+		Long:  "Permanently delete products and users that were soft-deleted more than --days days ago, and expired idempotency keys",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if days < 0 {
+				return fmt.Errorf("--days must not be negative")
+			}
+			container, err := app.NewContainer(config.GetDB())
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			before := time.Now().AddDate(0, 0, -days)
+			ids, err := container.ProductService.ListDeletedProductIDs(ctx, before)
+			if err != nil {
+				return err
+			}
+			// 逐个商品先删除图片再删除数据，中途失败时未清理的商品仍保持软删除，下次执行可重试
+			products := 0
+			for _, id := range ids {
+				if err := container.MediaService.DeleteProductImages(ctx, id); err != nil {
+					return err
+				}
+				if err := container.ProductService.PurgeProduct(ctx, id); err != nil {
+					return err
+				}
+				products++
+			}
+			users, err := container.UserService.PurgeDeletedUsers(ctx, before)
+			if err != nil {
+				return err
+			}
+
+			keys, err := container.IdempotencyRepo.PurgeExpired(ctx, time.Now())
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "purged %d products and %d users deleted before %s, and %d expired idempotency keys\n",
+				products, users, before.Format(time.RFC3339), keys)
+			return nil
+		},
+	}
+	cmd.Flags().IntVar(&days, "days", defaultRetentionDays, "purge records soft-deleted more than this many days ago")
+	return cmd
+}
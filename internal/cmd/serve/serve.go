@@ -1,11 +1,15 @@
 package serve
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 
+	"github.com/innovationmech/simple-cli/internal/logging"
 	"github.com/innovationmech/simple-cli/internal/server"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"go.uber.org/zap"
 )
 
 func NewServeCmd() *cobra.Command {
@@ -15,7 +19,22 @@ func NewServeCmd() *cobra.Command {
 		Long:  "Serve the application",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			server := server.NewServer()
+			serveAdmin()
 			return server.Run(fmt.Sprintf(":%d", viper.GetInt("port")))
 		},
 	}
 }
+
+// serveAdmin 配置了管理端口时在后台暴露指标，启动失败只记录日志，不影响业务端口
+func serveAdmin() {
+	admin := server.NewAdminServer()
+	if admin == nil {
+		return
+	}
+	go func() {
+		logging.L().Info("admin server listening", zap.String("addr", admin.Addr))
+		if err := admin.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logging.L().Error("admin server stopped", zap.Error(err))
+		}
+	}()
+}
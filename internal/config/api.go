@@ -0,0 +1,28 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// LegacyRoutesEnabled 是否在根路径保留未带版本前缀的旧接口地址
+// 对应配置项 api.legacy_routes.enabled，未配置时保留
+func LegacyRoutesEnabled() bool {
+	if !viper.IsSet("api.legacy_routes.enabled") {
+		return true
+	}
+	return viper.GetBool("api.legacy_routes.enabled")
+}
+
+// LegacyRoutesDeprecatedAt 旧接口地址的弃用时间，通过 Deprecation 响应头告知调用方
+// 对应配置项 api.legacy_routes.deprecated_at（如 "2026-11-01"），未配置时只声明已弃用
+func LegacyRoutesDeprecatedAt() time.Time {
+	return viper.GetTime("api.legacy_routes.deprecated_at")
+}
+
+// LegacyRoutesSunset 旧接口地址的计划下线时间，通过 Sunset 响应头告知调用方
+// 对应配置项 api.legacy_routes.sunset，未配置时不发送 Sunset
+func LegacyRoutesSunset() time.Time {
+	return viper.GetTime("api.legacy_routes.sunset")
+}
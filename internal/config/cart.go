@@ -0,0 +1,19 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// defaultCartIdleTimeout 购物车默认闲置过期时间
+const defaultCartIdleTimeout = 72 * time.Hour
+
+// CartIdleTimeout 购物车闲置过期时间
+// 对应配置项 cart.idle_timeout（如 "72h"），未配置时默认 72 小时
+func CartIdleTimeout() time.Duration {
+	if d := viper.GetDuration("cart.idle_timeout"); d > 0 {
+		return d
+	}
+	return defaultCartIdleTimeout
+}
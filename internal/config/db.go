@@ -1,9 +1,12 @@
 package config
 
 import (
+	"fmt"
 	"sync"
 
+	"github.com/innovationmech/simple-cli/internal/logging"
 	"github.com/spf13/viper"
+	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
@@ -15,11 +18,31 @@ var (
 
 func GetDB() *gorm.DB {
 	once.Do(func() {
-		var err error
-		db, err = gorm.Open(sqlite.Open(viper.GetString("db.url")), &gorm.Config{})
+		dialector, err := openDialector(viper.GetString("db.driver"), viper.GetString("db.url"))
 		if err != nil {
 			panic(err)
 		}
+		// SQL 日志写入结构化日志，通过 context 携带请求 ID
+		db, err = gorm.Open(dialector, &gorm.Config{Logger: logging.NewGormLogger(Logging().SlowQuery)})
+		if err != nil {
+			panic(err)
+		}
+		if err = AutoMigrate(db); err != nil {
+			panic(err)
+		}
 	})
 	return db
 }
+
+// openDialector 根据 db.driver 选择数据库驱动，默认使用 sqlite
+// sqlite 时 url 为数据库文件路径，postgres 时为连接串（DSN）
+func openDialector(driver, url string) (gorm.Dialector, error) {
+	switch driver {
+	case "", "sqlite":
+		return sqlite.Open(url), nil
+	case "postgres":
+		return postgres.Open(url), nil
+	default:
+		return nil, fmt.Errorf("unsupported db driver: %s", driver)
+	}
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	// defaultIdempotencyTTL 幂等键默认保留时间
+	defaultIdempotencyTTL = 24 * time.Hour
+	// defaultIdempotencyLease 处理中幂等键的默认占用时长
+	defaultIdempotencyLease = time.Minute
+	// idempotencyBodyOverhead 默认请求体上限在图片大小之外预留的 multipart 表单字节
+	idempotencyBodyOverhead = 1 << 20
+)
+
+// IdempotencyTTL 幂等键保留时间，过期后同一幂等键视为新请求
+// 对应配置项 idempotency.ttl（如 "24h"），未配置时默认 24 小时
+func IdempotencyTTL() time.Duration {
+	if d := viper.GetDuration("idempotency.ttl"); d > 0 {
+		return d
+	}
+	return defaultIdempotencyTTL
+}
+
+// IdempotencyLease 首次请求占用幂等键的最长时间，超过后视为已中断，相同请求可以重新占用
+// 对应配置项 idempotency.lease，应不短于请求的最长处理时间，未配置时默认 1 分钟
+func IdempotencyLease() time.Duration {
+	if d := viper.GetDuration("idempotency.lease"); d > 0 {
+		return d
+	}
+	return defaultIdempotencyLease
+}
+
+// IdempotencyMaxBodyBytes 携带幂等键的请求体最大字节数，请求体需完整读入以计算指纹
+// 对应配置项 idempotency.max_body_bytes，未配置时为图片大小上限加 1 MiB，保证图片上传可用
+func IdempotencyMaxBodyBytes() int64 {
+	if n := viper.GetInt64("idempotency.max_body_bytes"); n > 0 {
+		return n
+	}
+	return Storage().Images.MaxBytes + idempotencyBodyOverhead
+}
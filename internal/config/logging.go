@@ -0,0 +1,46 @@
+package config
+
+import (
+	"time"
+
+	"github.com/innovationmech/simple-cli/internal/logging"
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultLogLevel  = "info"
+	defaultLogFormat = "json"
+	// defaultSlowQuery 默认慢查询阈值
+	defaultSlowQuery = 200 * time.Millisecond
+)
+
+// LoggingConfig 日志配置，对应配置项 log
+type LoggingConfig struct {
+	// Level 最低日志级别：debug、info、warn、error，debug 时记录全部 SQL
+	Level string `mapstructure:"level"`
+	// Format 输出格式：json 或 console
+	Format string `mapstructure:"format"`
+	// SlowQuery 执行时间超过该值的 SQL 记为慢查询，负数表示不记录
+	SlowQuery time.Duration `mapstructure:"slow_query"`
+}
+
+// Logging 读取日志配置，未配置的项使用默认值
+func Logging() LoggingConfig {
+	var cfg LoggingConfig
+	_ = viper.UnmarshalKey("log", &cfg)
+	if cfg.Level == "" {
+		cfg.Level = defaultLogLevel
+	}
+	if cfg.Format == "" {
+		cfg.Format = defaultLogFormat
+	}
+	if cfg.SlowQuery == 0 {
+		cfg.SlowQuery = defaultSlowQuery
+	}
+	return cfg
+}
+
+// Logger 返回 logging 包使用的日志配置
+func (c LoggingConfig) Logger() logging.Config {
+	return logging.Config{Level: c.Level, Format: c.Format}
+}
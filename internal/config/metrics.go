@@ -0,0 +1,26 @@
+package config
+
+import "github.com/spf13/viper"
+
+// defaultMetricsPath 指标的默认访问路径
+const defaultMetricsPath = "/metrics"
+
+// MetricsConfig Prometheus 指标配置，对应配置项 metrics
+type MetricsConfig struct {
+	// Enabled 是否暴露指标，默认关闭
+	Enabled bool `mapstructure:"enabled"`
+	// Path 指标的访问路径，默认 /metrics
+	Path string `mapstructure:"path"`
+	// AdminPort 大于 0 时在该端口单独暴露指标，不经过业务端口
+	AdminPort int `mapstructure:"admin_port"`
+}
+
+// Metrics 读取指标配置，未配置的项使用默认值
+func Metrics() MetricsConfig {
+	var cfg MetricsConfig
+	_ = viper.UnmarshalKey("metrics", &cfg)
+	if cfg.Path == "" {
+		cfg.Path = defaultMetricsPath
+	}
+	return cfg
+}
@@ -0,0 +1,125 @@
+package config
+
+import (
+	"errors"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AutoMigrate 根据数据模型自动创建或更新表结构
+// 新增数据模型时需在此处登记
+func AutoMigrate(db *gorm.DB) error {
+	// 购物车行的唯一索引已扩展为 (cart_id, product_id, sku_id)，移除旧索引
+	if db.Migrator().HasIndex(&model.CartItem{}, "idx_cart_product") {
+		if err := db.Migrator().DropIndex(&model.CartItem{}, "idx_cart_product"); err != nil {
+			return err
+		}
+	}
+	// SKU 编码的唯一索引改为只约束未删除的 SKU，移除旧索引
+	if db.Migrator().HasIndex(&model.SKU{}, "idx_skus_code") {
+		if err := db.Migrator().DropIndex(&model.SKU{}, "idx_skus_code"); err != nil {
+			return err
+		}
+	}
+	// 商品与分类的关联表使用自定义结构，需在迁移前注册
+	if err := db.SetupJoinTable(&model.Product{}, "Categories", &model.ProductCategory{}); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(
+		&model.User{},
+		&model.Address{},
+		&model.Category{},
+		&model.ProductCategory{},
+		&model.Product{},
+		&model.SKU{},
+		&model.ProductImage{},
+		&model.ProductPrice{},
+		&model.InventoryAdjustment{},
+		&model.StockAlert{},
+		&model.Order{},
+		&model.OrderItem{},
+		&model.OrderHistory{},
+		&model.Payment{},
+		&model.ReturnRequest{},
+		&model.ReturnItem{},
+		&model.Cart{},
+		&model.CartItem{},
+		&model.Coupon{},
+		&model.CouponScope{},
+		&model.CouponUsage{},
+		&model.OrderDiscount{},
+		&model.IdempotencyRecord{},
+	); err != nil {
+		return err
+	}
+	if err := migrateLegacyOrderItems(db); err != nil {
+		return err
+	}
+	return setupProductSearch(db)
+}
+
+// legacyOrder 单商品订单时期的订单行，商品与数量直接保存在 orders 表中
+type legacyOrder struct {
+	ID          string
+	ProductID   string
+	Quantity    int
+	TotalAmount float64
+}
+
+// migrateLegacyOrderItems 将旧订单的 orders.product_id/quantity 回填为订单明细后删除这两列
+// 旧订单的总价即商品小计（数量 × 下单时单价），无优惠、运费与税费；只执行一次，列删除后跳过
+func migrateLegacyOrderItems(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasColumn(&model.Order{}, "product_id") {
+		return nil
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		var orders []legacyOrder
+		if err := tx.Table("orders").
+			Select("id, product_id, quantity, total_amount").
+			Where("product_id <> '' AND quantity > 0").
+			Where("NOT EXISTS (SELECT 1 FROM order_items WHERE order_items.order_id = orders.id)").
+			Scan(&orders).Error; err != nil {
+			return err
+		}
+		for _, o := range orders {
+			item := model.OrderItem{
+				OrderID:   o.ID,
+				ProductID: o.ProductID,
+				UnitPrice: o.TotalAmount / float64(o.Quantity),
+				Quantity:  o.Quantity,
+				Amount:    o.TotalAmount,
+			}
+			// 商品名称取当前名称（含已删除商品），旧订单未保存下单时的快照
+			var product model.Product
+			if err := tx.Unscoped().Select("name").First(&product, "id = ?", o.ProductID).Error; err == nil {
+				item.ProductName = product.Name
+			} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			if err := tx.Create(&item).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&model.Order{}).Where("id = ? AND (subtotal_amount IS NULL OR subtotal_amount = 0)", o.ID).
+				UpdateColumn("subtotal_amount", o.TotalAmount).Error; err != nil {
+				return err
+			}
+		}
+
+		txMigrator := tx.Migrator()
+		if txMigrator.HasIndex(&model.Order{}, "idx_orders_product_id") {
+			if err := txMigrator.DropIndex(&model.Order{}, "idx_orders_product_id"); err != nil {
+				return err
+			}
+		}
+		// 直接使用 ALTER TABLE 删除列：SQLite 驱动的 DropColumn 通过重建表实现，会丢失 orders 上的其他索引
+		for _, column := range []string{"product_id", "quantity"} {
+			if err := tx.Exec("ALTER TABLE ? DROP COLUMN ?", clause.Table{Name: "orders"}, clause.Column{Name: column}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
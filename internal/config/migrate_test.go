@@ -0,0 +1,89 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestMigrateLegacyOrderItems(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := AutoMigrate(db); err != nil {
+		t.Fatalf("AutoMigrate: %v", err)
+	}
+
+	// 模拟单商品订单时期的表结构与数据
+	for _, stmt := range []string{
+		`ALTER TABLE orders ADD COLUMN product_id text`,
+		`ALTER TABLE orders ADD COLUMN quantity integer`,
+		`CREATE INDEX idx_orders_product_id ON orders(product_id)`,
+		`INSERT INTO products (id, name, price, stock, version) VALUES ('p1', 'Widget', 12.5, 0, 1)`,
+		`INSERT INTO orders (id, user_id, product_id, quantity, total_amount, status, version) VALUES ('legacy', 'u1', 'p1', 2, 25, 'completed', 1)`,
+		`INSERT INTO orders (id, user_id, product_id, quantity, total_amount, status, version) VALUES ('removed', 'u1', 'gone', 1, 8, 'paid', 1)`,
+		`INSERT INTO orders (id, user_id, product_id, quantity, total_amount, subtotal_amount, status, version) VALUES ('current', 'u1', '', 0, 10, 10, 'paid', 1)`,
+		`INSERT INTO order_items (order_id, product_id, product_name, unit_price, quantity, amount) VALUES ('current', 'p1', 'Widget', 10, 1, 10)`,
+	} {
+		if err := db.Exec(stmt).Error; err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+
+	if err := AutoMigrate(db); err != nil {
+		t.Fatalf("AutoMigrate legacy: %v", err)
+	}
+	for _, column := range []string{"product_id", "quantity"} {
+		if db.Migrator().HasColumn(&model.Order{}, column) {
+			t.Errorf("column orders.%s not dropped", column)
+		}
+	}
+	if !db.Migrator().HasIndex(&model.Order{}, "UserID") {
+		t.Errorf("index on orders.user_id lost while dropping legacy columns")
+	}
+
+	tests := []struct {
+		orderID  string
+		want     model.OrderItem
+		subtotal float64
+	}{
+		{"legacy", model.OrderItem{ProductID: "p1", ProductName: "Widget", UnitPrice: 12.5, Quantity: 2, Amount: 25}, 25},
+		{"removed", model.OrderItem{ProductID: "gone", UnitPrice: 8, Quantity: 1, Amount: 8}, 8},
+		{"current", model.OrderItem{ProductID: "p1", ProductName: "Widget", UnitPrice: 10, Quantity: 1, Amount: 10}, 10},
+	}
+	for _, tt := range tests {
+		var order model.Order
+		if err := db.Preload("Items").First(&order, "id = ?", tt.orderID).Error; err != nil {
+			t.Fatalf("load %s: %v", tt.orderID, err)
+		}
+		if len(order.Items) != 1 {
+			t.Fatalf("order %s has %d items, want 1", tt.orderID, len(order.Items))
+		}
+		got := order.Items[0]
+		got.ID, got.OrderID = 0, ""
+		if got.ProductID != tt.want.ProductID || got.ProductName != tt.want.ProductName ||
+			got.UnitPrice != tt.want.UnitPrice || got.Quantity != tt.want.Quantity || got.Amount != tt.want.Amount {
+			t.Errorf("order %s item = %+v, want %+v", tt.orderID, got, tt.want)
+		}
+		if order.SubtotalAmount != tt.subtotal || order.TotalAmount != tt.subtotal {
+			t.Errorf("order %s subtotal %.2f total %.2f, want %.2f", tt.orderID, order.SubtotalAmount, order.TotalAmount, tt.subtotal)
+		}
+	}
+
+	// 再次迁移不会重复回填
+	if err := AutoMigrate(db); err != nil {
+		t.Fatalf("AutoMigrate again: %v", err)
+	}
+	var count int64
+	db.Model(&model.OrderItem{}).Count(&count)
+	if count != 3 {
+		t.Errorf("%d order items after re-running migration, want 3", count)
+	}
+}
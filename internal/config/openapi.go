@@ -0,0 +1,22 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// OpenAPIValidationMode 按 OpenAPI 文档校验请求的方式：off、log 或 enforce
+// 对应配置项 openapi.validation.mode，未配置时为 off
+func OpenAPIValidationMode() string {
+	if mode := strings.TrimSpace(viper.GetString("openapi.validation.mode")); mode != "" {
+		return strings.ToLower(mode)
+	}
+	return "off"
+}
+
+// OpenAPIValidateResponses 是否同时校验响应，仅记录日志，供开发环境排查文档与实现不一致
+// 对应配置项 openapi.validation.responses，默认关闭
+func OpenAPIValidateResponses() bool {
+	return viper.GetBool("openapi.validation.responses")
+}
@@ -0,0 +1,9 @@
+package config
+
+import "github.com/spf13/viper"
+
+// CursorSecret 分页游标的签名密钥
+// 对应配置项 pagination.cursor_secret，未配置时每次启动随机生成，重启后旧游标失效
+func CursorSecret() string {
+	return viper.GetString("pagination.cursor_secret")
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// defaultTaxClass 商品未指定税类时使用的税类
+const defaultTaxClass = "standard"
+
+// PricingConfig 订单计价配置，对应配置项 pricing
+type PricingConfig struct {
+	Shipping ShippingConfig `mapstructure:"shipping"`
+	Tax      TaxConfig      `mapstructure:"tax"`
+}
+
+// ShippingConfig 运费配置
+// Regions 按地区（收货地址的省份）覆盖默认运费
+type ShippingConfig struct {
+	FlatFee       float64            `mapstructure:"flat_fee"`
+	FreeThreshold float64            `mapstructure:"free_threshold"` // 优惠后金额达到该值时免运费，0 表示不包邮
+	Regions       map[string]float64 `mapstructure:"regions"`
+}
+
+// TaxConfig 税率配置
+// Rates 为各税类的默认税率，Regions 按地区覆盖指定税类的税率
+type TaxConfig struct {
+	DefaultClass string                        `mapstructure:"default_class"`
+	Rates        map[string]float64            `mapstructure:"rates"`
+	Regions      map[string]map[string]float64 `mapstructure:"regions"`
+}
+
+// Pricing 读取订单计价配置
+func Pricing() PricingConfig {
+	var cfg PricingConfig
+	_ = viper.UnmarshalKey("pricing", &cfg)
+	if cfg.Tax.DefaultClass == "" {
+		cfg.Tax.DefaultClass = defaultTaxClass
+	}
+	return cfg
+}
+
+// ShippingFee 返回地区对应的运费，未单独配置的地区使用默认运费
+func (c ShippingConfig) ShippingFee(region string) float64 {
+	// viper 会将配置键统一转为小写
+	if fee, ok := c.Regions[strings.ToLower(region)]; ok {
+		return fee
+	}
+	return c.FlatFee
+}
+
+// TaxRate 返回地区与税类对应的税率，地区未配置该税类时使用默认税率
+func (c TaxConfig) TaxRate(region, class string) float64 {
+	if class == "" {
+		class = c.DefaultClass
+	}
+	class = strings.ToLower(class)
+	if rates, ok := c.Regions[strings.ToLower(region)]; ok {
+		if rate, ok := rates[class]; ok {
+			return rate
+		}
+	}
+	return c.Rates[class]
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"errors"
+
+	"github.com/innovationmech/simple-cli/internal/logging"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+// sqliteSearchDDL SQLite 商品全文索引
+// products_fts 保存商品名称与描述的副本，由触发器与 products 表保持同步；
+// 使用商品 ID 而非 rowid 关联，避免 VACUUM 后 rowid 变化导致索引错位
+var sqliteSearchDDL = []string{
+	`CREATE TRIGGER IF NOT EXISTS products_fts_ai AFTER INSERT ON products BEGIN
+		INSERT INTO products_fts(product_id, name, description) VALUES (new.id, new.name, new.description);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS products_fts_ad AFTER DELETE ON products BEGIN
+		DELETE FROM products_fts WHERE product_id = old.id;
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS products_fts_au AFTER UPDATE OF name, description ON products BEGIN
+		UPDATE products_fts SET name = new.name, description = new.description WHERE product_id = old.id;
+	END`,
+}
+
+// postgresSearchDDL Postgres 商品全文索引
+// search_vector 为生成列，名称权重高于描述；使用 simple 配置以兼容中英文混合内容
+var postgresSearchDDL = []string{
+	`ALTER TABLE products ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+		setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+		setweight(to_tsvector('simple', coalesce(description, '')), 'B')
+	) STORED`,
+	`CREATE INDEX IF NOT EXISTS idx_products_search ON products USING GIN (search_vector)`,
+}
+
+// SearchRequireFTS 是否要求启用全文索引
+// 对应配置项 search.require_fts，开启后 SQLite 缺少 FTS5 时启动失败而不是退化为 LIKE 匹配
+func SearchRequireFTS() bool {
+	return viper.GetBool("search.require_fts")
+}
+
+// setupProductSearch 按数据库类型创建商品全文索引
+// SQLite 需以 sqlite_fts5 构建标签编译才支持 FTS5，否则跳过并由搜索退化为 LIKE 匹配（search.require_fts 开启时报错）
+func setupProductSearch(db *gorm.DB) error {
+	switch db.Dialector.Name() {
+	case "postgres":
+		return execAll(db, postgresSearchDDL)
+	case "sqlite":
+		hasIndex := db.Migrator().HasTable("products_fts")
+		if !fts5Enabled(db) {
+			if hasIndex {
+				// 索引触发器依赖 FTS5，缺少该模块时写入商品会失败
+				return errors.New("database has a full-text index but sqlite fts5 is unavailable, rebuild with -tags sqlite_fts5")
+			}
+			if SearchRequireFTS() {
+				return errors.New("search.require_fts is set but sqlite fts5 is unavailable, rebuild with -tags sqlite_fts5")
+			}
+			logging.L().Warn("sqlite fts5 unavailable, product search falls back to LIKE matching (build with -tags sqlite_fts5)")
+			return nil
+		}
+		if !hasIndex {
+			if err := db.Exec(`CREATE VIRTUAL TABLE products_fts USING fts5(
+				product_id UNINDEXED, name, description, tokenize = 'unicode61 remove_diacritics 2'
+			)`).Error; err != nil {
+				return err
+			}
+			// 新建索引时导入已有商品
+			if err := db.Exec(`INSERT INTO products_fts(product_id, name, description)
+				SELECT id, name, description FROM products`).Error; err != nil {
+				return err
+			}
+		}
+		return execAll(db, sqliteSearchDDL)
+	default:
+		return nil
+	}
+}
+
+// fts5Enabled 判断当前 SQLite 是否编译了 FTS5 模块
+func fts5Enabled(db *gorm.DB) bool {
+	var enabled bool
+	if err := db.Raw("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&enabled).Error; err != nil {
+		return false
+	}
+	return enabled
+}
+
+func execAll(db *gorm.DB, statements []string) error {
+	for _, stmt := range statements {
+		if err := db.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
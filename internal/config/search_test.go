@@ -0,0 +1,33 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestSetupProductSearchRequireFTS(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fts5Enabled(db) {
+		t.Skip("built with sqlite_fts5, fallback path not reachable")
+	}
+
+	t.Cleanup(func() { viper.Set("search.require_fts", nil) })
+	viper.Set("search.require_fts", false)
+	if err := AutoMigrate(db); err != nil {
+		t.Fatalf("AutoMigrate without require_fts: %v", err)
+	}
+	viper.Set("search.require_fts", true)
+	if err := AutoMigrate(db); err == nil {
+		t.Fatalf("AutoMigrate with require_fts succeeded without fts5")
+	}
+}
@@ -0,0 +1,69 @@
+package config
+
+import "github.com/spf13/viper"
+
+const (
+	defaultStorageDriver    = "local"
+	defaultStorageLocalRoot = "./media"
+	defaultStoragePublicURL = "/api/v1/media"
+	defaultImageMaxBytes    = 5 << 20
+	defaultThumbnailWidth   = 320
+)
+
+// StorageConfig 文件存储配置，对应配置项 storage
+// Driver 可选 local（本地文件系统）或 s3（S3 兼容的对象存储）
+type StorageConfig struct {
+	Driver string `mapstructure:"driver"`
+	// PublicURL 文件访问地址前缀，默认 /api/v1/media（由服务自身代理访问）
+	PublicURL string          `mapstructure:"public_url"`
+	Local     LocalStorage    `mapstructure:"local"`
+	S3        S3Storage       `mapstructure:"s3"`
+	Images    ImageUploadRule `mapstructure:"images"`
+}
+
+// LocalStorage 本地文件系统存储配置
+type LocalStorage struct {
+	Root string `mapstructure:"root"`
+}
+
+// S3Storage S3 兼容对象存储配置
+// UsePathStyle 为 true 时使用 endpoint/bucket/key 形式的地址，MinIO 等自建服务通常需要开启
+type S3Storage struct {
+	Endpoint     string `mapstructure:"endpoint"`
+	Region       string `mapstructure:"region"`
+	Bucket       string `mapstructure:"bucket"`
+	AccessKey    string `mapstructure:"access_key"`
+	SecretKey    string `mapstructure:"secret_key"`
+	UsePathStyle bool   `mapstructure:"use_path_style"`
+}
+
+// ImageUploadRule 商品图片上传限制
+type ImageUploadRule struct {
+	MaxBytes       int64 `mapstructure:"max_bytes"`
+	ThumbnailWidth int   `mapstructure:"thumbnail_width"`
+}
+
+// Storage 读取文件存储配置，未配置的项使用默认值
+func Storage() StorageConfig {
+	var cfg StorageConfig
+	_ = viper.UnmarshalKey("storage", &cfg)
+	if cfg.Driver == "" {
+		cfg.Driver = defaultStorageDriver
+	}
+	if cfg.PublicURL == "" {
+		cfg.PublicURL = defaultStoragePublicURL
+	}
+	if cfg.Local.Root == "" {
+		cfg.Local.Root = defaultStorageLocalRoot
+	}
+	if cfg.S3.Region == "" {
+		cfg.S3.Region = "us-east-1"
+	}
+	if cfg.Images.MaxBytes <= 0 {
+		cfg.Images.MaxBytes = defaultImageMaxBytes
+	}
+	if cfg.Images.ThumbnailWidth <= 0 {
+		cfg.Images.ThumbnailWidth = defaultThumbnailWidth
+	}
+	return cfg
+}
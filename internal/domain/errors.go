@@ -0,0 +1,85 @@
+// Package domain 定义服务层返回的领域错误
+// 服务以错误码描述失败原因，由 HTTP 层统一映射为状态码与响应体
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Code 领域错误码
+type Code string
+
+const (
+	// CodeInternal 未分类的内部错误，不向调用方暴露细节
+	CodeInternal Code = "internal"
+	// CodeValidation 请求参数不合法
+	CodeValidation Code = "validation"
+	// CodeNotFound 资源不存在
+	CodeNotFound Code = "not_found"
+	// CodeConflict 与已有数据冲突，如编码重复、资源仍被引用
+	CodeConflict Code = "conflict"
+	// CodeInsufficientStock 库存不足
+	CodeInsufficientStock Code = "insufficient_stock"
+	// CodeInvalidTransition 资源当前状态不允许该操作
+	CodeInvalidTransition Code = "invalid_transition"
+	// CodeUnprocessable 请求格式正确但不满足业务规则，如优惠券不可用、金额不符
+	CodeUnprocessable Code = "unprocessable"
+	// CodePreconditionRequired 缺少 If-Match 等前置条件
+	CodePreconditionRequired Code = "precondition_required"
+	// CodePreconditionFailed 前置条件不满足，资源已被修改
+	CodePreconditionFailed Code = "precondition_failed"
+	// CodePayloadTooLarge 请求体超过大小限制
+	CodePayloadTooLarge Code = "payload_too_large"
+	// CodeUnsupportedMediaType 不支持的内容类型
+	CodeUnsupportedMediaType Code = "unsupported_media_type"
+)
+
+// Error 带错误码的领域错误
+// 可直接作为哨兵错误声明，也可包装底层错误，errors.Is/As 均可穿透
+type Error struct {
+	Code    Code
+	Message string
+	// Key 消息目录中的键，用于按请求语言翻译 Message，为空时不翻译
+	Key string
+	// Err 被包装的底层错误
+	Err error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// New 创建领域错误
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// Keyed 创建可按消息目录翻译的领域错误，message 为英文原文
+func Keyed(code Code, key, message string) *Error {
+	return &Error{Code: code, Key: key, Message: message}
+}
+
+// Errorf 按格式创建领域错误，格式中的 %w 参数作为被包装的底层错误
+func Errorf(code Code, format string, args ...interface{}) *Error {
+	err := fmt.Errorf(format, args...)
+	return &Error{Code: code, Message: err.Error(), Err: errors.Unwrap(err)}
+}
+
+// Wrap 以底层错误的信息创建领域错误
+func Wrap(code Code, err error) *Error {
+	return &Error{Code: code, Message: err.Error(), Err: err}
+}
+
+// CodeOf 返回错误链中第一个领域错误的错误码，没有时为 CodeInternal
+func CodeOf(err error) Code {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr.Code
+	}
+	return CodeInternal
+}
@@ -0,0 +1,188 @@
+package cart
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/innovationmech/simple-cli/internal/domain"
+	"github.com/innovationmech/simple-cli/internal/interfaces"
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/types"
+)
+
+// CartHandler 购物车 HTTP 处理器
+type CartHandler struct {
+	cartService interfaces.CartService
+}
+
+// NewCartHandler 创建购物车处理器实例
+func NewCartHandler(cartService interfaces.CartService) *CartHandler {
+	return &CartHandler{cartService: cartService}
+}
+
+// GetCart 获取购物车（按实时价格与库存计算）
+func (h *CartHandler) GetCart(c *gin.Context) {
+	var request model.GetCartRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	cart, err := h.cartService.GetCart(c.Request.Context(), request.UserID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: "Cart retrieved successfully",
+		},
+		Data: cart,
+	})
+}
+
+// AddItem 添加商品到购物车
+func (h *CartHandler) AddItem(c *gin.Context) {
+	var request model.AddCartItemRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	cart, err := h.cartService.AddItem(c.Request.Context(), request.UserID, request.ProductID, request.SKUID, request.Quantity)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: "Cart item added successfully",
+		},
+		Data: cart,
+	})
+}
+
+// UpdateItem 修改购物车商品数量
+func (h *CartHandler) UpdateItem(c *gin.Context) {
+	var uri model.CartItemURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	var request model.UpdateCartItemRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	cart, err := h.cartService.UpdateItem(c.Request.Context(), request.UserID, uri.ProductID, request.SKUID, request.Quantity)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: "Cart item updated successfully",
+		},
+		Data: cart,
+	})
+}
+
+// RemoveItem 从购物车移除商品
+func (h *CartHandler) RemoveItem(c *gin.Context) {
+	var uri model.CartItemURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	var request model.RemoveCartItemRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	cart, err := h.cartService.RemoveItem(c.Request.Context(), request.UserID, uri.ProductID, request.SKUID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: "Cart item removed successfully",
+		},
+		Data: cart,
+	})
+}
+
+// ClearCart 清空购物车
+func (h *CartHandler) ClearCart(c *gin.Context) {
+	var request model.GetCartRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	if err := h.cartService.ClearCart(c.Request.Context(), request.UserID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: "Cart cleared successfully",
+		},
+	})
+}
+
+// Checkout 购物车结算，原子地生成订单并清空购物车
+func (h *CartHandler) Checkout(c *gin.Context) {
+	var request model.CheckoutRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	order, err := h.cartService.Checkout(c.Request.Context(), request.UserID, request.AddressID, request.CouponCodes)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusCreated,
+			Message: "Order created successfully",
+		},
+		Data: model.CheckoutResponse{
+			OrderID:        order.ID,
+			DiscountAmount: order.DiscountAmount,
+			ShippingAmount: order.ShippingAmount,
+			TaxAmount:      order.TaxAmount,
+			TotalAmount:    order.TotalAmount,
+		},
+	})
+}
+
+// RegisterRoutes 注册购物车相关路由
+func (h *CartHandler) RegisterRoutes(router *gin.RouterGroup) {
+	cart := router.Group("/cart")
+	{
+		cart.GET("", h.GetCart)
+		cart.DELETE("", h.ClearCart)
+		cart.POST("/items", h.AddItem)
+		cart.PUT("/items/:product_id", h.UpdateItem)
+		cart.DELETE("/items/:product_id", h.RemoveItem)
+		cart.POST("/checkout", h.Checkout)
+	}
+}
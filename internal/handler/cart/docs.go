@@ -0,0 +1,20 @@
+package cart
+
+import (
+	"net/http"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/openapi"
+)
+
+// Routes 购物车模块路由的文档描述
+func (m *CartModule) Routes() []openapi.Route {
+	return []openapi.Route{
+		{Method: http.MethodGet, Path: "/cart", Summary: "获取购物车", Query: model.GetCartRequest{}, Response: model.CartDetail{}},
+		{Method: http.MethodDelete, Path: "/cart", Summary: "清空购物车", Query: model.GetCartRequest{}},
+		{Method: http.MethodPost, Path: "/cart/items", Summary: "添加商品", Body: model.AddCartItemRequest{}, Response: model.CartDetail{}},
+		{Method: http.MethodPut, Path: "/cart/items/:product_id", Summary: "修改数量", Params: model.CartItemURI{}, Body: model.UpdateCartItemRequest{}, Response: model.CartDetail{}},
+		{Method: http.MethodDelete, Path: "/cart/items/:product_id", Summary: "移除商品", Params: model.CartItemURI{}, Query: model.RemoveCartItemRequest{}, Response: model.CartDetail{}},
+		{Method: http.MethodPost, Path: "/cart/checkout", Summary: "结算", Body: model.CheckoutRequest{}, Response: model.CheckoutResponse{}, Status: http.StatusCreated},
+	}
+}
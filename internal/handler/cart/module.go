@@ -0,0 +1,22 @@
+package cart
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/innovationmech/simple-cli/internal/app"
+)
+
+// CartModule 购物车模块，实现 server.Module 接口
+type CartModule struct {
+	handler *CartHandler
+}
+
+// Init 从 Container 获取依赖并初始化购物车模块
+func (m *CartModule) Init(container *app.Container) error {
+	m.handler = NewCartHandler(container.CartService)
+	return nil
+}
+
+// RegisterRoutes 注册购物车模块的所有路由
+func (m *CartModule) RegisterRoutes(router *gin.RouterGroup) {
+	m.handler.RegisterRoutes(router)
+}
@@ -0,0 +1,191 @@
+package category
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/innovationmech/simple-cli/internal/domain"
+	"github.com/innovationmech/simple-cli/internal/interfaces"
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/types"
+)
+
+// CategoryHandler 商品分类 HTTP 处理器
+type CategoryHandler struct {
+	categoryService interfaces.CategoryService
+}
+
+// NewCategoryHandler 创建商品分类处理器实例
+func NewCategoryHandler(categoryService interfaces.CategoryService) *CategoryHandler {
+	return &CategoryHandler{categoryService: categoryService}
+}
+
+// CreateCategory 创建分类
+func (h *CategoryHandler) CreateCategory(c *gin.Context) {
+	var request model.CreateCategoryRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	category := &model.Category{
+		ID:        uuid.New().String(),
+		ParentID:  request.ParentID,
+		Name:      request.Name,
+		Slug:      request.Slug,
+		SortOrder: request.SortOrder,
+	}
+
+	if err := h.categoryService.CreateCategory(c.Request.Context(), category); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusCreated,
+			Message: "Category created successfully",
+		},
+		Data: model.CreateCategoryResponse{
+			ID:   category.ID,
+			Slug: category.Slug,
+		},
+	})
+}
+
+// GetCategory 获取分类详情
+func (h *CategoryHandler) GetCategory(c *gin.Context) {
+	var request model.GetCategoryRequest
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	category, err := h.categoryService.GetCategory(c.Request.Context(), request.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: "Category retrieved successfully",
+		},
+		Data: category,
+	})
+}
+
+// UpdateCategory 更新分类（名称、slug、父分类与排序）
+func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
+	var uri model.GetCategoryRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	var request model.UpdateCategoryRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	// 先获取现有分类
+	category, err := h.categoryService.GetCategory(c.Request.Context(), uri.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// 更新字段
+	if request.Name != "" {
+		category.Name = request.Name
+	}
+	if request.Slug != "" {
+		category.Slug = request.Slug
+	}
+	if request.ParentID != nil {
+		category.ParentID = *request.ParentID
+	}
+	if request.SortOrder != nil {
+		category.SortOrder = *request.SortOrder
+	}
+
+	if err := h.categoryService.UpdateCategory(c.Request.Context(), category); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: "Category updated successfully",
+		},
+		Data: model.UpdateCategoryResponse{
+			ID: category.ID,
+		},
+	})
+}
+
+// DeleteCategory 删除分类
+// 默认在分类下仍有商品或子分类时拒绝删除，cascade=true 时连同子分类删除并解除商品关联
+func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
+	var uri model.GetCategoryRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	var request model.DeleteCategoryRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	if err := h.categoryService.DeleteCategory(c.Request.Context(), uri.ID, request.Cascade); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: "Category deleted successfully",
+		},
+		Data: model.UpdateCategoryResponse{
+			ID: uri.ID,
+		},
+	})
+}
+
+// ListCategories 获取完整的分类树
+func (h *CategoryHandler) ListCategories(c *gin.Context) {
+	tree, err := h.categoryService.GetCategoryTree(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: "Categories retrieved successfully",
+		},
+		Data: model.ListCategoriesResponse{
+			Categories: tree,
+		},
+	})
+}
+
+// RegisterRoutes 注册商品分类相关路由
+func (h *CategoryHandler) RegisterRoutes(router *gin.RouterGroup) {
+	categories := router.Group("/categories")
+	{
+		categories.POST("", h.CreateCategory)
+		categories.GET("", h.ListCategories)
+		categories.GET("/:id", h.GetCategory)
+		categories.PUT("/:id", h.UpdateCategory)
+		categories.DELETE("/:id", h.DeleteCategory)
+	}
+}
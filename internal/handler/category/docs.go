@@ -0,0 +1,19 @@
+package category
+
+import (
+	"net/http"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/openapi"
+)
+
+// Routes 商品分类模块路由的文档描述
+func (m *CategoryModule) Routes() []openapi.Route {
+	return []openapi.Route{
+		{Method: http.MethodPost, Path: "/categories", Summary: "创建分类", Body: model.CreateCategoryRequest{}, Response: model.CreateCategoryResponse{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/categories", Summary: "获取完整分类树", Response: model.ListCategoriesResponse{}},
+		{Method: http.MethodGet, Path: "/categories/:id", Summary: "获取分类详情", Params: model.GetCategoryRequest{}, Response: model.Category{}},
+		{Method: http.MethodPut, Path: "/categories/:id", Summary: "更新分类", Params: model.GetCategoryRequest{}, Body: model.UpdateCategoryRequest{}, Response: model.UpdateCategoryResponse{}},
+		{Method: http.MethodDelete, Path: "/categories/:id", Summary: "删除分类", Params: model.GetCategoryRequest{}, Query: model.DeleteCategoryRequest{}, Response: model.UpdateCategoryResponse{}},
+	}
+}
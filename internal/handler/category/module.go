@@ -0,0 +1,22 @@
+package category
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/innovationmech/simple-cli/internal/app"
+)
+
+// CategoryModule 商品分类模块，实现 server.Module 接口
+type CategoryModule struct {
+	handler *CategoryHandler
+}
+
+// Init 从 Container 获取依赖并初始化商品分类模块
+func (m *CategoryModule) Init(container *app.Container) error {
+	m.handler = NewCategoryHandler(container.CategoryService)
+	return nil
+}
+
+// RegisterRoutes 注册商品分类模块的所有路由
+func (m *CategoryModule) RegisterRoutes(router *gin.RouterGroup) {
+	m.handler.RegisterRoutes(router)
+}
@@ -0,0 +1,221 @@
+package coupon
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/innovationmech/simple-cli/internal/domain"
+	"github.com/innovationmech/simple-cli/internal/interfaces"
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/queryspec"
+	"github.com/innovationmech/simple-cli/internal/types"
+)
+
+// CouponHandler 优惠券 HTTP 处理器
+type CouponHandler struct {
+	promotionService interfaces.PromotionService
+}
+
+// NewCouponHandler 创建优惠券处理器实例
+func NewCouponHandler(promotionService interfaces.PromotionService) *CouponHandler {
+	return &CouponHandler{promotionService: promotionService}
+}
+
+// CreateCoupon 创建优惠券
+func (h *CouponHandler) CreateCoupon(c *gin.Context) {
+	var request model.CreateCouponRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	coupon := &model.Coupon{
+		ID:           uuid.New().String(),
+		Code:         request.Code,
+		Type:         request.Type,
+		Value:        request.Value,
+		MinSpend:     request.MinSpend,
+		MaxDiscount:  request.MaxDiscount,
+		UsageLimit:   request.UsageLimit,
+		PerUserLimit: request.PerUserLimit,
+		StartsAt:     request.StartsAt,
+		EndsAt:       request.EndsAt,
+		Active:       true,
+	}
+	for _, scope := range request.Scopes {
+		coupon.Scopes = append(coupon.Scopes, model.CouponScope{
+			Type:     scope.Type,
+			TargetID: scope.TargetID,
+		})
+	}
+
+	if err := h.promotionService.CreateCoupon(c.Request.Context(), coupon); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusCreated,
+			Message: "Coupon created successfully",
+		},
+		Data: model.CreateCouponResponse{
+			ID:   coupon.ID,
+			Code: coupon.Code,
+		},
+	})
+}
+
+// GetCoupon 获取优惠券详情
+func (h *CouponHandler) GetCoupon(c *gin.Context) {
+	var request model.GetCouponRequest
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	coupon, err := h.promotionService.GetCoupon(c.Request.Context(), request.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: "Coupon retrieved successfully",
+		},
+		Data: coupon,
+	})
+}
+
+// UpdateCoupon 更新优惠券（启停、使用限制与有效期）
+func (h *CouponHandler) UpdateCoupon(c *gin.Context) {
+	var uri model.GetCouponRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	var request model.UpdateCouponRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	// 先获取现有优惠券
+	coupon, err := h.promotionService.GetCoupon(c.Request.Context(), uri.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// 更新字段
+	if request.Active != nil {
+		coupon.Active = *request.Active
+	}
+	if request.UsageLimit != nil {
+		coupon.UsageLimit = *request.UsageLimit
+	}
+	if request.PerUserLimit != nil {
+		coupon.PerUserLimit = *request.PerUserLimit
+	}
+	if request.StartsAt != nil {
+		coupon.StartsAt = request.StartsAt
+	}
+	if request.EndsAt != nil {
+		coupon.EndsAt = request.EndsAt
+	}
+
+	if err := h.promotionService.UpdateCoupon(c.Request.Context(), coupon); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: "Coupon updated successfully",
+		},
+		Data: model.UpdateCouponResponse{
+			ID: coupon.ID,
+		},
+	})
+}
+
+// DeleteCoupon 删除优惠券
+func (h *CouponHandler) DeleteCoupon(c *gin.Context) {
+	var request model.GetCouponRequest
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	if err := h.promotionService.DeleteCoupon(c.Request.Context(), request.ID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: "Coupon deleted successfully",
+		},
+		Data: model.UpdateCouponResponse{
+			ID: request.ID,
+		},
+	})
+}
+
+// ListCoupons 获取优惠券列表
+func (h *CouponHandler) ListCoupons(c *gin.Context) {
+	var request model.ListCouponsRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	spec, err := queryspec.Parse(c.Request.URL.Query(), model.CouponQuerySchema)
+	if err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	// 设置默认值
+	if request.Page <= 0 {
+		request.Page = 1
+	}
+	if request.PageSize <= 0 {
+		request.PageSize = 10
+	}
+
+	coupons, page, err := h.promotionService.ListCoupons(c.Request.Context(), spec, request.Page, request.PageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: "Coupons retrieved successfully",
+		},
+		Data: spec.Project(model.ListCouponsResponse{
+			Coupons:  coupons,
+			PageInfo: *page,
+		}, "coupons"),
+	})
+}
+
+// RegisterRoutes 注册优惠券相关路由
+func (h *CouponHandler) RegisterRoutes(router *gin.RouterGroup) {
+	coupons := router.Group("/coupons")
+	{
+		coupons.POST("", h.CreateCoupon)
+		coupons.GET("", h.ListCoupons)
+		coupons.GET("/:id", h.GetCoupon)
+		coupons.PUT("/:id", h.UpdateCoupon)
+		coupons.DELETE("/:id", h.DeleteCoupon)
+	}
+}
@@ -0,0 +1,19 @@
+package coupon
+
+import (
+	"net/http"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/openapi"
+)
+
+// Routes 优惠券模块路由的文档描述
+func (m *CouponModule) Routes() []openapi.Route {
+	return []openapi.Route{
+		{Method: http.MethodPost, Path: "/coupons", Summary: "创建优惠券", Body: model.CreateCouponRequest{}, Response: model.CreateCouponResponse{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/coupons", Summary: "获取优惠券列表", Query: model.ListCouponsRequest{}, List: model.CouponQuerySchema, Response: model.ListCouponsResponse{}},
+		{Method: http.MethodGet, Path: "/coupons/:id", Summary: "获取优惠券详情", Params: model.GetCouponRequest{}, Response: model.Coupon{}},
+		{Method: http.MethodPut, Path: "/coupons/:id", Summary: "更新优惠券", Params: model.GetCouponRequest{}, Body: model.UpdateCouponRequest{}, Response: model.UpdateCouponResponse{}},
+		{Method: http.MethodDelete, Path: "/coupons/:id", Summary: "删除优惠券", Params: model.GetCouponRequest{}, Response: model.UpdateCouponResponse{}},
+	}
+}
@@ -0,0 +1,23 @@
+package coupon
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/innovationmech/simple-cli/internal/app"
+)
+
+// CouponModule 优惠券模块，实现 server.Module 接口
+type CouponModule struct {
+	handler *CouponHandler
+}
+
+// Init 从 Container 获取依赖并初始化优惠券模块
+// PromotionService 在 Container 中创建，与购物车结算共享同一实例
+func (m *CouponModule) Init(container *app.Container) error {
+	m.handler = NewCouponHandler(container.PromotionService)
+	return nil
+}
+
+// RegisterRoutes 注册优惠券模块的所有路由
+func (m *CouponModule) RegisterRoutes(router *gin.RouterGroup) {
+	m.handler.RegisterRoutes(router)
+}
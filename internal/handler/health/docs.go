@@ -0,0 +1,14 @@
+package health
+
+import (
+	"net/http"
+
+	"github.com/innovationmech/simple-cli/internal/openapi"
+)
+
+// Routes 健康检查路由的文档描述
+func (m *HealthModule) Routes() []openapi.Route {
+	return []openapi.Route{
+		{Method: http.MethodGet, Path: "/health", Summary: "健康检查", Response: map[string]string{}, Raw: true},
+	}
+}
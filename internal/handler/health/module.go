@@ -14,6 +14,6 @@ func (m *HealthModule) Init(container *app.Container) error {
 }
 
 // RegisterRoutes 注册健康检查路由
-func (m *HealthModule) RegisterRoutes(router *gin.Engine) {
+func (m *HealthModule) RegisterRoutes(router *gin.RouterGroup) {
 	router.GET("/health", HealthCheck)
 }
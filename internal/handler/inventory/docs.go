@@ -0,0 +1,18 @@
+package inventory
+
+import (
+	"net/http"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/openapi"
+)
+
+// Routes 库存模块路由的文档描述
+func (m *InventoryModule) Routes() []openapi.Route {
+	return []openapi.Route{
+		{Method: http.MethodGet, Path: "/products/:id/inventory", Summary: "获取库存与调整记录", Params: model.InventoryURI{}, Query: model.GetInventoryRequest{}, List: model.InventoryQuerySchema, Response: model.InventoryResponse{}},
+		{Method: http.MethodPost, Path: "/products/:id/inventory/adjustments", Summary: "调整库存", Params: model.InventoryURI{}, Body: model.AdjustInventoryRequest{}, Response: model.InventoryAdjustment{}, Status: http.StatusCreated},
+		{Method: http.MethodPut, Path: "/products/:id/inventory/threshold", Summary: "设置低库存阈值", Params: model.InventoryURI{}, Body: model.SetThresholdRequest{}, Response: model.SetThresholdRequest{}},
+		{Method: http.MethodGet, Path: "/inventory/alerts", Summary: "获取低库存预警", Query: model.ListStockAlertsRequest{}, List: model.StockAlertQuerySchema, Response: model.ListStockAlertsResponse{}},
+	}
+}
@@ -0,0 +1,164 @@
+package inventory
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/innovationmech/simple-cli/internal/domain"
+	"github.com/innovationmech/simple-cli/internal/interfaces"
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/queryspec"
+	"github.com/innovationmech/simple-cli/internal/types"
+)
+
+// InventoryHandler 库存 HTTP 处理器
+type InventoryHandler struct {
+	inventoryService interfaces.InventoryService
+}
+
+// NewInventoryHandler 创建库存处理器实例
+func NewInventoryHandler(inventoryService interfaces.InventoryService) *InventoryHandler {
+	return &InventoryHandler{inventoryService: inventoryService}
+}
+
+// GetInventory 获取商品库存与调整记录
+func (h *InventoryHandler) GetInventory(c *gin.Context) {
+	var uri model.InventoryURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	var request model.GetInventoryRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	spec, err := queryspec.Parse(c.Request.URL.Query(), model.InventoryQuerySchema)
+	if err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	inventory, err := h.inventoryService.GetInventory(c.Request.Context(), uri.ProductID, request.SKUID, spec, request.Page, request.PageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: "Inventory retrieved successfully",
+		},
+		Data: spec.Project(inventory, "adjustments"),
+	})
+}
+
+// AdjustInventory 调整库存并记录调整日志
+func (h *InventoryHandler) AdjustInventory(c *gin.Context) {
+	var uri model.InventoryURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	var request model.AdjustInventoryRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	adjustment := &model.InventoryAdjustment{
+		ProductID: uri.ProductID,
+		SKUID:     request.SKUID,
+		Type:      request.Type,
+		Delta:     request.Quantity,
+		Reason:    request.Reason,
+		Actor:     request.Actor,
+	}
+	if err := h.inventoryService.Adjust(c.Request.Context(), adjustment); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusCreated,
+			Message: "Inventory adjusted successfully",
+		},
+		Data: adjustment,
+	})
+}
+
+// SetThreshold 设置商品低库存阈值
+func (h *InventoryHandler) SetThreshold(c *gin.Context) {
+	var uri model.InventoryURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	var request model.SetThresholdRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	if err := h.inventoryService.SetThreshold(c.Request.Context(), uri.ProductID, request.LowStockThreshold); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: "Threshold updated successfully",
+		},
+		Data: request,
+	})
+}
+
+// ListAlerts 获取低库存预警列表
+func (h *InventoryHandler) ListAlerts(c *gin.Context) {
+	var request model.ListStockAlertsRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	spec, err := queryspec.Parse(c.Request.URL.Query(), model.StockAlertQuerySchema)
+	if err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	alerts, page, err := h.inventoryService.ListAlerts(c.Request.Context(), request.Status, spec, request.Page, request.PageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: "Alerts retrieved successfully",
+		},
+		Data: spec.Project(model.ListStockAlertsResponse{
+			Alerts:   alerts,
+			PageInfo: *page,
+		}, "alerts"),
+	})
+}
+
+// RegisterRoutes 注册库存相关路由
+func (h *InventoryHandler) RegisterRoutes(router *gin.RouterGroup) {
+	products := router.Group("/products/:id/inventory")
+	{
+		products.GET("", h.GetInventory)
+		products.POST("/adjustments", h.AdjustInventory)
+		products.PUT("/threshold", h.SetThreshold)
+	}
+	router.GET("/inventory/alerts", h.ListAlerts)
+}
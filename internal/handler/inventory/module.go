@@ -0,0 +1,22 @@
+package inventory
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/innovationmech/simple-cli/internal/app"
+)
+
+// InventoryModule 库存模块，实现 server.Module 接口
+type InventoryModule struct {
+	handler *InventoryHandler
+}
+
+// Init 从 Container 获取依赖并初始化库存模块
+func (m *InventoryModule) Init(container *app.Container) error {
+	m.handler = NewInventoryHandler(container.InventoryService)
+	return nil
+}
+
+// RegisterRoutes 注册库存模块的所有路由
+func (m *InventoryModule) RegisterRoutes(router *gin.RouterGroup) {
+	m.handler.RegisterRoutes(router)
+}
@@ -0,0 +1,14 @@
+package media
+
+import (
+	"net/http"
+
+	"github.com/innovationmech/simple-cli/internal/openapi"
+)
+
+// Routes 媒体文件模块路由的文档描述
+func (m *MediaModule) Routes() []openapi.Route {
+	return []openapi.Route{
+		{Method: http.MethodGet, Path: "/media/*key", Summary: "读取存储中的图片文件", ContentType: "image/*"},
+	}
+}
@@ -0,0 +1,47 @@
+package media
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/innovationmech/simple-cli/internal/interfaces"
+)
+
+// MediaHandler 媒体文件 HTTP 处理器
+type MediaHandler struct {
+	mediaService interfaces.MediaService
+}
+
+// NewMediaHandler 创建媒体文件处理器实例
+func NewMediaHandler(mediaService interfaces.MediaService) *MediaHandler {
+	return &MediaHandler{mediaService: mediaService}
+}
+
+// GetFile 读取存储中的文件并原样返回
+// 文件 key 包含随机 ID 且内容不可变，因此允许客户端长期缓存
+func (h *MediaHandler) GetFile(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+
+	body, contentType, err := h.mediaService.OpenFile(c.Request.Context(), key)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer body.Close()
+
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Status(http.StatusOK)
+	_, _ = io.Copy(c.Writer, body)
+}
+
+// RegisterRoutes 注册媒体文件相关路由
+func (h *MediaHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/media/*key", h.GetFile)
+}
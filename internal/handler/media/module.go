@@ -0,0 +1,22 @@
+package media
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/innovationmech/simple-cli/internal/app"
+)
+
+// MediaModule 媒体文件模块，实现 server.Module 接口
+type MediaModule struct {
+	handler *MediaHandler
+}
+
+// Init 从 Container 获取依赖并初始化媒体文件模块
+func (m *MediaModule) Init(container *app.Container) error {
+	m.handler = NewMediaHandler(container.MediaService)
+	return nil
+}
+
+// RegisterRoutes 注册媒体文件模块的所有路由
+func (m *MediaModule) RegisterRoutes(router *gin.RouterGroup) {
+	m.handler.RegisterRoutes(router)
+}
@@ -0,0 +1,15 @@
+package meta
+
+import (
+	"net/http"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/openapi"
+)
+
+// Routes 元数据路由的文档描述
+func (m *MetaModule) Routes() []openapi.Route {
+	return []openapi.Route{
+		{Method: http.MethodGet, Path: "/meta/enums", Summary: "获取枚举取值", Response: model.ListEnumsResponse{}},
+	}
+}
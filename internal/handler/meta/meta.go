@@ -0,0 +1,34 @@
+package meta
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/innovationmech/simple-cli/internal/i18n"
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/types"
+)
+
+// ListEnums 列出全部公开枚举的取值，名称按请求语言翻译，供前端生成下拉选项
+func ListEnums(c *gin.Context) {
+	ctx := c.Request.Context()
+	enums := make(model.ListEnumsResponse, len(model.Enums))
+	for name, e := range model.Enums {
+		options := make([]model.EnumOption, 0, len(e.Values()))
+		for _, v := range e.Values() {
+			options = append(options, model.EnumOption{
+				Value: v,
+				Label: i18n.T(ctx, "enum."+name+"."+v),
+			})
+		}
+		enums[name] = options
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: i18n.T(ctx, "enum.listed"),
+		},
+		Data: enums,
+	})
+}
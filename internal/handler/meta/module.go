@@ -0,0 +1,19 @@
+package meta
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/innovationmech/simple-cli/internal/app"
+)
+
+// MetaModule 元数据模块，向前端公开枚举等静态信息，实现 server.Module 接口
+type MetaModule struct{}
+
+// Init 初始化元数据模块（该模块无需额外依赖）
+func (m *MetaModule) Init(container *app.Container) error {
+	return nil
+}
+
+// RegisterRoutes 注册元数据路由
+func (m *MetaModule) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/meta/enums", ListEnums)
+}
@@ -0,0 +1,21 @@
+package order
+
+import (
+	"net/http"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/openapi"
+)
+
+// Routes 订单模块路由的文档描述
+func (m *OrderModule) Routes() []openapi.Route {
+	return []openapi.Route{
+		{Method: http.MethodPost, Path: "/orders", Summary: "创建订单", Body: model.CreateOrderRequest{}, Response: model.CreateOrderResponse{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/orders", Summary: "获取订单列表", Query: model.ListOrdersRequest{}, List: model.OrderQuerySchema, Response: model.ListOrdersResponse{}},
+		{Method: http.MethodPost, Path: "/orders/quote", Summary: "订单试算（不创建订单）", Body: model.CreateOrderRequest{}, Response: model.QuoteOrderResponse{}},
+		{Method: http.MethodGet, Path: "/orders/:id", Summary: "获取订单详情", Params: model.GetOrderRequest{}, Response: model.GetOrderResponse{}, ETag: true},
+		{Method: http.MethodGet, Path: "/orders/:id/history", Summary: "获取订单历史", Params: model.GetOrderRequest{}, Response: model.GetOrderHistoryResponse{}},
+		{Method: http.MethodPut, Path: "/orders/:id/status", Summary: "更新订单状态", Params: model.UpdateOrderStatusRequest{}, Body: model.UpdateOrderStatusRequest{}, Response: model.UpdateOrderStatusResponse{}, IfMatch: true},
+		{Method: http.MethodPost, Path: "/orders/:id/cancel", Summary: "取消订单", Params: model.CancelOrderRequest{}, Response: model.CancelOrderResponse{}},
+	}
+}
@@ -26,6 +26,6 @@ func (m *OrderModule) Init(container *app.Container) error {
 }
 
 // RegisterRoutes 注册订单模块的所有路由
-func (m *OrderModule) RegisterRoutes(router *gin.Engine) {
+func (m *OrderModule) RegisterRoutes(router *gin.RouterGroup) {
 	m.handler.RegisterRoutes(router)
 }
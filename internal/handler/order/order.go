@@ -5,8 +5,12 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/innovationmech/simple-cli/internal/domain"
+	"github.com/innovationmech/simple-cli/internal/i18n"
 	"github.com/innovationmech/simple-cli/internal/interfaces"
 	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/queryspec"
+	orderSrv "github.com/innovationmech/simple-cli/internal/service/order"
 	"github.com/innovationmech/simple-cli/internal/types"
 )
 
@@ -25,40 +29,69 @@ func NewOrderHandler(orderService interfaces.OrderService) *OrderHandler {
 func (h *OrderHandler) CreateOrder(c *gin.Context) {
 	var request model.CreateOrderRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusBadRequest,
-				Message: "Invalid request: " + err.Error(),
-			},
-		})
+		c.Error(domain.Wrap(domain.CodeValidation, err))
 		return
 	}
 
-	order := &model.Order{
-		ID:        uuid.New().String(),
-		UserID:    request.UserID,
-		ProductID: request.ProductID,
-		Quantity:  request.Quantity,
+	order, err := buildOrder(&request)
+	if err != nil {
+		c.Error(err)
+		return
 	}
 
 	if err := h.orderService.CreateOrder(c.Request.Context(), order); err != nil {
-		c.JSON(http.StatusInternalServerError, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusInternalServerError,
-				Message: "Failed to create order: " + err.Error(),
-			},
-		})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, types.ApiResponse{
 		Status: types.ResponseStatus{
 			Code:    http.StatusCreated,
-			Message: "Order created successfully",
+			Message: i18n.T(c.Request.Context(), "order.created"),
 		},
 		Data: model.CreateOrderResponse{
-			ID:          order.ID,
-			TotalAmount: order.TotalAmount,
+			ID:             order.ID,
+			SubtotalAmount: order.SubtotalAmount,
+			DiscountAmount: order.DiscountAmount,
+			ShippingAmount: order.ShippingAmount,
+			TaxAmount:      order.TaxAmount,
+			TotalAmount:    order.TotalAmount,
+		},
+	})
+}
+
+// QuoteOrder 订单试算，按下单规则计算价格构成但不创建订单
+func (h *OrderHandler) QuoteOrder(c *gin.Context) {
+	var request model.CreateOrderRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	order, err := buildOrder(&request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.orderService.QuoteOrder(c.Request.Context(), order); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: i18n.T(c.Request.Context(), "order.quoted"),
+		},
+		Data: model.QuoteOrderResponse{
+			Items:          order.Items,
+			SubtotalAmount: order.SubtotalAmount,
+			DiscountAmount: order.DiscountAmount,
+			Discounts:      order.Discounts,
+			ShippingAmount: order.ShippingAmount,
+			TaxAmount:      order.TaxAmount,
+			TotalAmount:    order.TotalAmount,
 		},
 	})
 }
@@ -67,84 +100,73 @@ func (h *OrderHandler) CreateOrder(c *gin.Context) {
 func (h *OrderHandler) GetOrder(c *gin.Context) {
 	var request model.GetOrderRequest
 	if err := c.ShouldBindUri(&request); err != nil {
-		c.JSON(http.StatusBadRequest, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusBadRequest,
-				Message: "Invalid request: " + err.Error(),
-			},
-		})
+		c.Error(domain.Wrap(domain.CodeValidation, err))
 		return
 	}
 
 	order, err := h.orderService.GetOrder(c.Request.Context(), request.ID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusNotFound,
-				Message: "Order not found",
-			},
-		})
+		c.Error(err)
 		return
 	}
 
+	c.Header("ETag", types.ETag(order.Version))
 	c.JSON(http.StatusOK, types.ApiResponse{
 		Status: types.ResponseStatus{
 			Code:    http.StatusOK,
-			Message: "Order retrieved successfully",
-		},
-		Data: model.GetOrderResponse{
-			ID:          order.ID,
-			UserID:      order.UserID,
-			ProductID:   order.ProductID,
-			Quantity:    order.Quantity,
-			TotalAmount: order.TotalAmount,
-			Status:      order.Status,
-			CreatedAt:   order.CreatedAt,
+			Message: i18n.T(c.Request.Context(), "order.retrieved"),
 		},
+		Data: toOrderResponse(order),
 	})
 }
 
 // UpdateOrderStatus 更新订单状态
 func (h *OrderHandler) UpdateOrderStatus(c *gin.Context) {
 	var request model.UpdateOrderStatusRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
 	if err := c.ShouldBindUri(&request); err != nil {
-		c.JSON(http.StatusBadRequest, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusBadRequest,
-				Message: "Invalid request: " + err.Error(),
-			},
-		})
+		c.Error(domain.Wrap(domain.CodeValidation, err))
 		return
 	}
 
-	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusBadRequest,
-				Message: "Invalid request: " + err.Error(),
-			},
-		})
+	// 必须携带 GET 返回的 ETag，避免覆盖他人已做的修改
+	ifMatch := c.GetHeader("If-Match")
+	if ifMatch == "" {
+		c.Error(types.ErrIfMatchRequired)
 		return
 	}
 
-	if err := h.orderService.UpdateOrderStatus(c.Request.Context(), request.ID, request.Status); err != nil {
-		c.JSON(http.StatusInternalServerError, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusInternalServerError,
-				Message: "Failed to update order status: " + err.Error(),
-			},
-		})
+	current, err := h.orderService.GetOrder(c.Request.Context(), request.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if !types.IfMatch(ifMatch, current.Version) {
+		c.Header("ETag", types.ETag(current.Version))
+		c.Error(orderSrv.ErrVersionConflict)
+		return
+	}
+
+	order, err := h.orderService.UpdateOrderStatus(c.Request.Context(), request.ID, request.Status, current.Version)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
+	c.Header("ETag", types.ETag(order.Version))
 	c.JSON(http.StatusOK, types.ApiResponse{
 		Status: types.ResponseStatus{
 			Code:    http.StatusOK,
-			Message: "Order status updated successfully",
+			Message: i18n.T(c.Request.Context(), "order.status_updated"),
 		},
 		Data: model.UpdateOrderStatusResponse{
-			ID:     request.ID,
-			Status: request.Status,
+			ID:      order.ID,
+			Status:  order.Status,
+			Version: order.Version,
 		},
 	})
 }
@@ -153,29 +175,19 @@ func (h *OrderHandler) UpdateOrderStatus(c *gin.Context) {
 func (h *OrderHandler) CancelOrder(c *gin.Context) {
 	var request model.CancelOrderRequest
 	if err := c.ShouldBindUri(&request); err != nil {
-		c.JSON(http.StatusBadRequest, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusBadRequest,
-				Message: "Invalid request: " + err.Error(),
-			},
-		})
+		c.Error(domain.Wrap(domain.CodeValidation, err))
 		return
 	}
 
 	if err := h.orderService.CancelOrder(c.Request.Context(), request.ID); err != nil {
-		c.JSON(http.StatusInternalServerError, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusInternalServerError,
-				Message: "Failed to cancel order: " + err.Error(),
-			},
-		})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, types.ApiResponse{
 		Status: types.ResponseStatus{
 			Code:    http.StatusOK,
-			Message: "Order cancelled successfully",
+			Message: i18n.T(c.Request.Context(), "order.cancelled"),
 		},
 		Data: model.CancelOrderResponse{
 			ID:     request.ID,
@@ -188,12 +200,13 @@ func (h *OrderHandler) CancelOrder(c *gin.Context) {
 func (h *OrderHandler) ListOrders(c *gin.Context) {
 	var request model.ListOrdersRequest
 	if err := c.ShouldBindQuery(&request); err != nil {
-		c.JSON(http.StatusBadRequest, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusBadRequest,
-				Message: "Invalid request: " + err.Error(),
-			},
-		})
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	spec, err := queryspec.Parse(c.Request.URL.Query(), model.OrderQuerySchema)
+	if err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
 		return
 	}
 
@@ -205,51 +218,116 @@ func (h *OrderHandler) ListOrders(c *gin.Context) {
 		request.PageSize = 10
 	}
 
-	orders, total, err := h.orderService.ListOrdersByUser(c.Request.Context(), request.UserID, request.Page, request.PageSize)
+	orders, page, err := h.orderService.ListOrdersByUser(c.Request.Context(), request.UserID, spec, request.Page, request.PageSize)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusInternalServerError,
-				Message: "Failed to list orders",
-			},
-		})
+		c.Error(err)
 		return
 	}
 
 	// 转换响应
 	var orderResponses []model.GetOrderResponse
 	for _, o := range orders {
-		orderResponses = append(orderResponses, model.GetOrderResponse{
-			ID:          o.ID,
-			UserID:      o.UserID,
-			ProductID:   o.ProductID,
-			Quantity:    o.Quantity,
-			TotalAmount: o.TotalAmount,
-			Status:      o.Status,
-			CreatedAt:   o.CreatedAt,
-		})
+		orderResponses = append(orderResponses, toOrderResponse(o))
 	}
 
 	c.JSON(http.StatusOK, types.ApiResponse{
 		Status: types.ResponseStatus{
 			Code:    http.StatusOK,
-			Message: "Orders retrieved successfully",
+			Message: i18n.T(c.Request.Context(), "order.listed"),
 		},
-		Data: model.ListOrdersResponse{
-			Orders: orderResponses,
-			Total:  total,
+		Data: spec.Project(model.ListOrdersResponse{
+			Orders:   orderResponses,
+			PageInfo: *page,
+		}, "orders"),
+	})
+}
+
+// GetOrderHistory 获取订单历史（状态变更与售后事件）
+func (h *OrderHandler) GetOrderHistory(c *gin.Context) {
+	var request model.GetOrderRequest
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	history, err := h.orderService.GetOrderHistory(c.Request.Context(), request.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: i18n.T(c.Request.Context(), "order.history_retrieved"),
+		},
+		Data: model.GetOrderHistoryResponse{
+			OrderID: request.ID,
+			History: history,
 		},
 	})
 }
 
 // RegisterRoutes 注册订单相关路由
-func (h *OrderHandler) RegisterRoutes(router *gin.Engine) {
+func (h *OrderHandler) RegisterRoutes(router *gin.RouterGroup) {
 	orders := router.Group("/orders")
 	{
 		orders.POST("", h.CreateOrder)
 		orders.GET("", h.ListOrders)
+		orders.POST("/quote", h.QuoteOrder)
 		orders.GET("/:id", h.GetOrder)
+		orders.GET("/:id/history", h.GetOrderHistory)
 		orders.PUT("/:id/status", h.UpdateOrderStatus)
 		orders.POST("/:id/cancel", h.CancelOrder)
 	}
 }
+
+// buildOrder 根据下单请求构建订单，商品明细为空时返回参数错误
+func buildOrder(request *model.CreateOrderRequest) (*model.Order, error) {
+	items := request.OrderItems()
+	if len(items) == 0 {
+		return nil, domain.Keyed(domain.CodeValidation, "order.items_required", "items or product_id/quantity are required")
+	}
+
+	order := &model.Order{
+		ID:          uuid.New().String(),
+		UserID:      request.UserID,
+		AddressID:   request.AddressID,
+		CouponCodes: request.CouponCodes,
+	}
+	for _, item := range items {
+		order.Items = append(order.Items, model.OrderItem{
+			ProductID: item.ProductID,
+			SKUID:     item.SKUID,
+			Quantity:  item.Quantity,
+		})
+	}
+	return order, nil
+}
+
+// toOrderResponse 将订单模型转换为响应结构
+func toOrderResponse(o *model.Order) model.GetOrderResponse {
+	response := model.GetOrderResponse{
+		ID:             o.ID,
+		UserID:         o.UserID,
+		Items:          o.Items,
+		SubtotalAmount: o.SubtotalAmount,
+		DiscountAmount: o.DiscountAmount,
+		Discounts:      o.Discounts,
+		ShippingAmount: o.ShippingAmount,
+		TaxAmount:      o.TaxAmount,
+		TotalAmount:    o.TotalAmount,
+		Status:         o.Status,
+		CreatedAt:      o.CreatedAt,
+		Version:        o.Version,
+		Quantity:       o.TotalQuantity(),
+	}
+	if len(o.Items) > 0 {
+		response.ProductID = o.Items[0].ProductID
+	}
+	if o.AddressID != "" {
+		address := o.ShippingAddress
+		response.ShippingAddress = &address
+	}
+	return response
+}
@@ -5,8 +5,10 @@ package order
 
 import (
 	"github.com/google/wire"
+	"github.com/innovationmech/simple-cli/internal/pricing"
 	"github.com/innovationmech/simple-cli/internal/repository"
 	orderSrv "github.com/innovationmech/simple-cli/internal/service/order"
+	promotionSrv "github.com/innovationmech/simple-cli/internal/service/promotion"
 	"gorm.io/gorm"
 )
 
@@ -15,6 +17,12 @@ import (
 var OrderProviderSet = wire.NewSet(
 	repository.NewOrderRepository,
 	repository.NewProductRepository,
+	repository.NewAddressRepository,
+	repository.NewCouponRepository,
+	repository.NewCategoryRepository,
+	repository.NewTxManager,
+	promotionSrv.NewPromotionService,
+	pricing.NewDefaultPipeline,
 	orderSrv.NewOrderService,
 	NewOrderHandler,
 )
@@ -8,8 +8,10 @@ package order
 
 import (
 	"github.com/google/wire"
+	"github.com/innovationmech/simple-cli/internal/pricing"
 	"github.com/innovationmech/simple-cli/internal/repository"
 	"github.com/innovationmech/simple-cli/internal/service/order"
+	"github.com/innovationmech/simple-cli/internal/service/promotion"
 	"gorm.io/gorm"
 )
 
@@ -20,7 +22,13 @@ import (
 func InitializeOrderHandler(db *gorm.DB) (*OrderHandler, error) {
 	orderRepository := repository.NewOrderRepository(db)
 	productRepository := repository.NewProductRepository(db)
-	orderService := order.NewOrderService(orderRepository, productRepository)
+	addressRepository := repository.NewAddressRepository(db)
+	couponRepository := repository.NewCouponRepository(db)
+	categoryRepository := repository.NewCategoryRepository(db)
+	txManager := repository.NewTxManager(db)
+	promotionService := promotion.NewPromotionService(couponRepository, categoryRepository, txManager)
+	pipeline := pricing.NewDefaultPipeline(productRepository, promotionService)
+	orderService := order.NewOrderService(orderRepository, productRepository, addressRepository, promotionService, pipeline, txManager)
 	orderHandler := NewOrderHandler(orderService)
 	return orderHandler, nil
 }
@@ -29,4 +37,4 @@ func InitializeOrderHandler(db *gorm.DB) (*OrderHandler, error) {
 
 // OrderProviderSet 是 Order 模块的依赖提供者集合
 // 包含了构建 OrderHandler 所需的所有依赖
-var OrderProviderSet = wire.NewSet(repository.NewOrderRepository, repository.NewProductRepository, order.NewOrderService, NewOrderHandler)
+var OrderProviderSet = wire.NewSet(repository.NewOrderRepository, repository.NewProductRepository, repository.NewAddressRepository, repository.NewCouponRepository, repository.NewCategoryRepository, repository.NewTxManager, promotion.NewPromotionService, pricing.NewDefaultPipeline, order.NewOrderService, NewOrderHandler)
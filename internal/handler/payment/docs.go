@@ -0,0 +1,19 @@
+package payment
+
+import (
+	"net/http"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/openapi"
+)
+
+// Routes 支付模块路由的文档描述
+func (m *PaymentModule) Routes() []openapi.Route {
+	return []openapi.Route{
+		{Method: http.MethodPost, Path: "/payments", Summary: "创建支付", Body: model.CreatePaymentRequest{}, Response: model.CreatePaymentResponse{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/payments", Summary: "获取支付列表", Query: model.ListPaymentsRequest{}, List: model.PaymentQuerySchema, Response: model.ListPaymentsResponse{}},
+		{Method: http.MethodGet, Path: "/payments/:id", Summary: "获取支付详情", Params: model.GetPaymentRequest{}, Response: model.GetPaymentResponse{}, ETag: true},
+		{Method: http.MethodPost, Path: "/payments/callback", Summary: "支付渠道回调", Body: model.PaymentCallbackRequest{}, Response: model.PaymentCallbackResponse{}},
+		{Method: http.MethodPost, Path: "/payments/:id/refund", Summary: "退款", Params: model.RefundRequest{}, Body: model.RefundRequest{}, BodyOptional: true, Response: model.RefundResponse{}},
+	}
+}
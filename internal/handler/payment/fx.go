@@ -16,6 +16,7 @@ var FxModule = fx.Module("payment",
 	// 提供 Repository
 	fx.Provide(repository.NewPaymentRepository),
 	fx.Provide(repository.NewOrderRepository),
+	fx.Provide(repository.NewTxManager),
 
 	// 提供 Service
 	fx.Provide(paymentSrv.NewPaymentService),
@@ -53,6 +53,6 @@ func (m *PaymentModule) Init(container *app.Container) error {
 }
 
 // RegisterRoutes 注册支付模块的所有路由
-func (m *PaymentModule) RegisterRoutes(router *gin.Engine) {
+func (m *PaymentModule) RegisterRoutes(router *gin.RouterGroup) {
 	m.handler.RegisterRoutes(router)
 }
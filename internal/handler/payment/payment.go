@@ -5,8 +5,11 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/innovationmech/simple-cli/internal/domain"
+	"github.com/innovationmech/simple-cli/internal/i18n"
 	"github.com/innovationmech/simple-cli/internal/interfaces"
 	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/queryspec"
 	"github.com/innovationmech/simple-cli/internal/types"
 )
 
@@ -25,12 +28,7 @@ func NewPaymentHandler(paymentService interfaces.PaymentService) *PaymentHandler
 func (h *PaymentHandler) CreatePayment(c *gin.Context) {
 	var request model.CreatePaymentRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusBadRequest,
-				Message: "Invalid request: " + err.Error(),
-			},
-		})
+		c.Error(domain.Wrap(domain.CodeValidation, err))
 		return
 	}
 
@@ -44,19 +42,14 @@ func (h *PaymentHandler) CreatePayment(c *gin.Context) {
 
 	paymentURL, err := h.paymentService.CreatePayment(c.Request.Context(), payment)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusInternalServerError,
-				Message: "Failed to create payment: " + err.Error(),
-			},
-		})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, types.ApiResponse{
 		Status: types.ResponseStatus{
 			Code:    http.StatusCreated,
-			Message: "Payment created successfully",
+			Message: i18n.T(c.Request.Context(), "payment.created"),
 		},
 		Data: model.CreatePaymentResponse{
 			ID:         payment.ID,
@@ -69,41 +62,34 @@ func (h *PaymentHandler) CreatePayment(c *gin.Context) {
 func (h *PaymentHandler) GetPayment(c *gin.Context) {
 	var request model.GetPaymentRequest
 	if err := c.ShouldBindUri(&request); err != nil {
-		c.JSON(http.StatusBadRequest, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusBadRequest,
-				Message: "Invalid request: " + err.Error(),
-			},
-		})
+		c.Error(domain.Wrap(domain.CodeValidation, err))
 		return
 	}
 
 	payment, err := h.paymentService.GetPayment(c.Request.Context(), request.ID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusNotFound,
-				Message: "Payment not found",
-			},
-		})
+		c.Error(err)
 		return
 	}
 
+	c.Header("ETag", types.ETag(payment.Version))
 	c.JSON(http.StatusOK, types.ApiResponse{
 		Status: types.ResponseStatus{
 			Code:    http.StatusOK,
-			Message: "Payment retrieved successfully",
+			Message: i18n.T(c.Request.Context(), "payment.retrieved"),
 		},
 		Data: model.GetPaymentResponse{
-			ID:            payment.ID,
-			OrderID:       payment.OrderID,
-			UserID:        payment.UserID,
-			Amount:        payment.Amount,
-			Method:        payment.Method,
-			Status:        payment.Status,
-			TransactionID: payment.TransactionID,
-			PaidAt:        payment.PaidAt,
-			CreatedAt:     payment.CreatedAt,
+			ID:             payment.ID,
+			OrderID:        payment.OrderID,
+			UserID:         payment.UserID,
+			Amount:         payment.Amount,
+			RefundedAmount: payment.RefundedAmount,
+			Method:         payment.Method,
+			Status:         payment.Status,
+			TransactionID:  payment.TransactionID,
+			PaidAt:         payment.PaidAt,
+			CreatedAt:      payment.CreatedAt,
+			Version:        payment.Version,
 		},
 	})
 }
@@ -112,22 +98,12 @@ func (h *PaymentHandler) GetPayment(c *gin.Context) {
 func (h *PaymentHandler) PaymentCallback(c *gin.Context) {
 	var request model.PaymentCallbackRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusBadRequest,
-				Message: "Invalid request: " + err.Error(),
-			},
-		})
+		c.Error(domain.Wrap(domain.CodeValidation, err))
 		return
 	}
 
 	if err := h.paymentService.ProcessCallback(c.Request.Context(), request.PaymentID, request.TransactionID, request.Success); err != nil {
-		c.JSON(http.StatusInternalServerError, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusInternalServerError,
-				Message: "Failed to process callback: " + err.Error(),
-			},
-		})
+		c.Error(err)
 		return
 	}
 
@@ -139,7 +115,7 @@ func (h *PaymentHandler) PaymentCallback(c *gin.Context) {
 	c.JSON(http.StatusOK, types.ApiResponse{
 		Status: types.ResponseStatus{
 			Code:    http.StatusOK,
-			Message: "Callback processed successfully",
+			Message: i18n.T(c.Request.Context(), "payment.callback_processed"),
 		},
 		Data: model.PaymentCallbackResponse{
 			ID:     request.PaymentID,
@@ -148,16 +124,11 @@ func (h *PaymentHandler) PaymentCallback(c *gin.Context) {
 	})
 }
 
-// RefundPayment 退款
+// RefundPayment 退还支付剩余的全部金额
 func (h *PaymentHandler) RefundPayment(c *gin.Context) {
 	var request model.RefundRequest
 	if err := c.ShouldBindUri(&request); err != nil {
-		c.JSON(http.StatusBadRequest, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusBadRequest,
-				Message: "Invalid request: " + err.Error(),
-			},
-		})
+		c.Error(domain.Wrap(domain.CodeValidation, err))
 		return
 	}
 
@@ -165,20 +136,15 @@ func (h *PaymentHandler) RefundPayment(c *gin.Context) {
 		// JSON body is optional for refund
 	}
 
-	if err := h.paymentService.RefundPayment(c.Request.Context(), request.ID, request.Reason); err != nil {
-		c.JSON(http.StatusInternalServerError, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusInternalServerError,
-				Message: "Failed to refund payment: " + err.Error(),
-			},
-		})
+	if err := h.paymentService.RefundPayment(c.Request.Context(), request.ID, 0, request.Reason); err != nil {
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, types.ApiResponse{
 		Status: types.ResponseStatus{
 			Code:    http.StatusOK,
-			Message: "Payment refunded successfully",
+			Message: i18n.T(c.Request.Context(), "payment.refunded"),
 		},
 		Data: model.RefundResponse{
 			ID:     request.ID,
@@ -191,12 +157,13 @@ func (h *PaymentHandler) RefundPayment(c *gin.Context) {
 func (h *PaymentHandler) ListPayments(c *gin.Context) {
 	var request model.ListPaymentsRequest
 	if err := c.ShouldBindQuery(&request); err != nil {
-		c.JSON(http.StatusBadRequest, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusBadRequest,
-				Message: "Invalid request: " + err.Error(),
-			},
-		})
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	spec, err := queryspec.Parse(c.Request.URL.Query(), model.PaymentQuerySchema)
+	if err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
 		return
 	}
 
@@ -208,14 +175,9 @@ func (h *PaymentHandler) ListPayments(c *gin.Context) {
 		request.PageSize = 10
 	}
 
-	payments, total, err := h.paymentService.ListPayments(c.Request.Context(), request.UserID, request.OrderID, request.Page, request.PageSize)
+	payments, page, err := h.paymentService.ListPayments(c.Request.Context(), request.UserID, request.OrderID, spec, request.Page, request.PageSize)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusInternalServerError,
-				Message: "Failed to list payments",
-			},
-		})
+		c.Error(err)
 		return
 	}
 
@@ -238,17 +200,17 @@ func (h *PaymentHandler) ListPayments(c *gin.Context) {
 	c.JSON(http.StatusOK, types.ApiResponse{
 		Status: types.ResponseStatus{
 			Code:    http.StatusOK,
-			Message: "Payments retrieved successfully",
+			Message: i18n.T(c.Request.Context(), "payment.listed"),
 		},
-		Data: model.ListPaymentsResponse{
+		Data: spec.Project(model.ListPaymentsResponse{
 			Payments: paymentResponses,
-			Total:    total,
-		},
+			PageInfo: *page,
+		}, "payments"),
 	})
 }
 
 // RegisterRoutes 注册支付相关路由
-func (h *PaymentHandler) RegisterRoutes(router *gin.Engine) {
+func (h *PaymentHandler) RegisterRoutes(router *gin.RouterGroup) {
 	payments := router.Group("/payments")
 	{
 		payments.POST("", h.CreatePayment)
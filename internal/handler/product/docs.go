@@ -0,0 +1,34 @@
+package product
+
+import (
+	"net/http"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/openapi"
+)
+
+// Routes 商品模块路由的文档描述
+func (m *ProductModule) Routes() []openapi.Route {
+	return []openapi.Route{
+		{Method: http.MethodPost, Path: "/products", Summary: "创建产品", Body: model.CreateProductRequest{}, Response: model.CreateProductResponse{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/products", Summary: "获取产品列表", Query: model.ListProductsRequest{}, List: model.ProductQuerySchema, Response: model.ListProductsResponse{}},
+		{Method: http.MethodGet, Path: "/products/search", Summary: "全文搜索产品", Query: model.SearchProductsRequest{}, Response: model.SearchProductsResponse{}},
+		{Method: http.MethodGet, Path: "/products/:id", Summary: "获取产品详情", Params: model.GetProductRequest{}, Response: model.GetProductResponse{}, ETag: true},
+		{Method: http.MethodPut, Path: "/products/:id", Summary: "更新产品", Params: model.UpdateProductRequest{}, Body: model.UpdateProductRequest{}, Response: model.UpdateProductResponse{}, IfMatch: true},
+		{Method: http.MethodDelete, Path: "/products/:id", Summary: "删除产品（软删除）", Params: model.DeleteProductRequest{}, Response: model.DeleteProductResponse{}},
+		{Method: http.MethodPost, Path: "/products/:id/restore", Summary: "恢复已删除的产品", Params: model.RestoreProductRequest{}, Response: model.GetProductResponse{}},
+
+		{Method: http.MethodPost, Path: "/products/:id/skus", Summary: "创建 SKU", Params: model.SKUURI{}, Body: model.CreateSKURequest{}, Response: model.CreateSKUResponse{}, Status: http.StatusCreated},
+		{Method: http.MethodPut, Path: "/products/:id/skus/:sku_id", Summary: "更新 SKU", Params: model.SKUURI{}, Body: model.UpdateSKURequest{}, Response: model.UpdateSKUResponse{}},
+		{Method: http.MethodDelete, Path: "/products/:id/skus/:sku_id", Summary: "删除 SKU", Params: model.SKUURI{}, Response: model.UpdateSKUResponse{}},
+
+		{Method: http.MethodGet, Path: "/products/:id/prices", Summary: "获取当前价格与价格历史", Params: model.ProductPriceURI{}, Query: model.ListPricesRequest{}, Response: model.ListPricesResponse{}},
+		{Method: http.MethodPost, Path: "/products/:id/prices", Summary: "预约调价或设置促销价", Params: model.ProductPriceURI{}, Body: model.SchedulePriceRequest{}, Response: model.ListPricesResponse{}, Status: http.StatusCreated},
+		{Method: http.MethodDelete, Path: "/products/:id/prices/:price_id", Summary: "取消尚未生效的价格记录", Params: model.ProductPriceURI{}},
+
+		{Method: http.MethodPost, Path: "/products/:id/images", Summary: "上传产品图片", Params: model.ProductImageURI{}, Query: model.UploadImageRequest{}, Upload: "file", Response: model.ImageResponse{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/products/:id/images", Summary: "获取产品图片列表", Params: model.ProductImageURI{}, Response: model.ListImagesResponse{}},
+		{Method: http.MethodPut, Path: "/products/:id/images/:image_id", Summary: "更新图片替代文本与排序", Params: model.ProductImageURI{}, Body: model.UpdateImageRequest{}, Response: model.ImageResponse{}},
+		{Method: http.MethodDelete, Path: "/products/:id/images/:image_id", Summary: "删除图片", Params: model.ProductImageURI{}},
+	}
+}
@@ -0,0 +1,200 @@
+package product
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/innovationmech/simple-cli/internal/domain"
+	"github.com/innovationmech/simple-cli/internal/i18n"
+	"github.com/innovationmech/simple-cli/internal/model"
+	mediaSrv "github.com/innovationmech/simple-cli/internal/service/media"
+	"github.com/innovationmech/simple-cli/internal/types"
+)
+
+// multipartOverhead multipart 请求中表单字段与分隔符占用的额外字节
+const multipartOverhead = 1 << 20
+
+// UploadImage 上传商品图片（multipart/form-data，文件字段名为 file）
+func (h *ProductHandler) UploadImage(c *gin.Context) {
+	var uri model.ProductImageURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	// 限制请求体大小，避免超大文件被完整读入
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadBytes+multipartOverhead)
+
+	var request model.UploadImageRequest
+	if err := c.ShouldBind(&request); err != nil {
+		c.Error(uploadError(err))
+		return
+	}
+
+	data, err := readFormFile(c, h.maxUploadBytes)
+	if err != nil {
+		c.Error(uploadError(err))
+		return
+	}
+
+	image := &model.ProductImage{
+		ID:        uuid.New().String(),
+		ProductID: uri.ProductID,
+		AltText:   request.AltText,
+		SortOrder: -1,
+	}
+	if request.SortOrder != nil {
+		image.SortOrder = *request.SortOrder
+	}
+
+	if err := h.mediaService.UploadProductImage(c.Request.Context(), image, data); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusCreated,
+			Message: i18n.T(c.Request.Context(), "image.uploaded"),
+		},
+		Data: h.toImageResponse(image),
+	})
+}
+
+// ListImages 获取商品图片列表
+func (h *ProductHandler) ListImages(c *gin.Context) {
+	var uri model.ProductImageURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	images, err := h.mediaService.ListProductImages(c.Request.Context(), uri.ProductID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responses := make([]model.ImageResponse, 0, len(images))
+	for _, image := range images {
+		responses = append(responses, h.toImageResponse(image))
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: i18n.T(c.Request.Context(), "image.listed"),
+		},
+		Data: model.ListImagesResponse{
+			Images: responses,
+		},
+	})
+}
+
+// UpdateImage 更新商品图片的替代文本与排序
+func (h *ProductHandler) UpdateImage(c *gin.Context) {
+	var uri model.ProductImageURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	var request model.UpdateImageRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	image, err := h.mediaService.GetProductImage(c.Request.Context(), uri.ProductID, uri.ImageID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if request.AltText != nil {
+		image.AltText = *request.AltText
+	}
+	if request.SortOrder != nil {
+		image.SortOrder = *request.SortOrder
+	}
+
+	if err := h.mediaService.UpdateProductImage(c.Request.Context(), image); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: i18n.T(c.Request.Context(), "image.updated"),
+		},
+		Data: h.toImageResponse(image),
+	})
+}
+
+// DeleteImage 删除商品图片及其缩略图
+func (h *ProductHandler) DeleteImage(c *gin.Context) {
+	var uri model.ProductImageURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	if err := h.mediaService.DeleteProductImage(c.Request.Context(), uri.ProductID, uri.ImageID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: i18n.T(c.Request.Context(), "image.deleted"),
+		},
+	})
+}
+
+// uploadError 转换读取上传表单时的错误：请求体超限视为图片过大，其余视为参数错误
+func uploadError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return mediaSrv.ErrImageTooLarge
+	}
+	if domain.CodeOf(err) == domain.CodeInternal {
+		return domain.Wrap(domain.CodeValidation, err)
+	}
+	return err
+}
+
+// readFormFile 读取表单中的 file 字段，超过 limit 字节时返回 ErrImageTooLarge
+func readFormFile(c *gin.Context, limit int64) ([]byte, error) {
+	header, err := c.FormFile("file")
+	if err != nil {
+		return nil, err
+	}
+	if header.Size > limit {
+		return nil, mediaSrv.ErrImageTooLarge
+	}
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(io.LimitReader(file, limit+1))
+}
+
+// toImageResponse 将商品图片模型转换为响应结构
+func (h *ProductHandler) toImageResponse(image *model.ProductImage) model.ImageResponse {
+	return model.ImageResponse{
+		ID:           image.ID,
+		URL:          h.mediaService.ImageURL(image.Key),
+		ThumbnailURL: h.mediaService.ImageURL(image.ThumbnailKey),
+		ContentType:  image.ContentType,
+		Width:        image.Width,
+		Height:       image.Height,
+		AltText:      image.AltText,
+		SortOrder:    image.SortOrder,
+	}
+}
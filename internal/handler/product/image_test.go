@@ -0,0 +1,153 @@
+package product
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/innovationmech/simple-cli/internal/interfaces"
+	"github.com/innovationmech/simple-cli/internal/middleware"
+	"github.com/innovationmech/simple-cli/internal/model"
+	mediaSrv "github.com/innovationmech/simple-cli/internal/service/media"
+	"github.com/innovationmech/simple-cli/internal/types"
+)
+
+// fakeMediaService 记录上传内容，并按 err 返回结果
+type fakeMediaService struct {
+	interfaces.MediaService
+	err      error
+	uploaded *model.ProductImage
+	data     []byte
+}
+
+func (s *fakeMediaService) UploadProductImage(ctx context.Context, image *model.ProductImage, data []byte) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.uploaded, s.data = image, data
+	image.Key = "products/" + image.ProductID + "/" + image.ID + ".png"
+	image.ContentType = "image/png"
+	return nil
+}
+
+func (s *fakeMediaService) ImageURL(key string) string {
+	return "/api/v1/media/" + key
+}
+
+func newUploadRouter(media interfaces.MediaService, maxBytes int64) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.Errors())
+	h := NewProductHandler(nil, media, maxBytes)
+	router.POST("/products/:id/images", h.UploadImage)
+	return router
+}
+
+// multipartBody 构造上传表单，file 为 nil 时不包含文件字段
+func multipartBody(t *testing.T, file []byte, fields map[string]string) (*bytes.Buffer, string) {
+	t.Helper()
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for k, v := range fields {
+		if err := w.WriteField(k, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if file != nil {
+		part, err := w.CreateFormFile("file", "photo.png")
+		if err != nil {
+			t.Fatal(err)
+		}
+		part.Write(file)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &buf, w.FormDataContentType()
+}
+
+func upload(router *gin.Engine, body *bytes.Buffer, contentType string) (*httptest.ResponseRecorder, types.ApiResponse) {
+	req := httptest.NewRequest(http.MethodPost, "/products/p1/images", body)
+	req.Header.Set("Content-Type", contentType)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	var resp types.ApiResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	return rec, resp
+}
+
+func TestUploadImage(t *testing.T) {
+	media := &fakeMediaService{}
+	router := newUploadRouter(media, 1024)
+	file := []byte("\x89PNG\r\n\x1a\nimage bytes")
+
+	body, contentType := multipartBody(t, file, map[string]string{"alt_text": "front", "sort_order": "2"})
+	rec, _ := upload(router, body, contentType)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status %d, want 201: %s", rec.Code, rec.Body)
+	}
+	if media.uploaded == nil || !bytes.Equal(media.data, file) {
+		t.Fatalf("service received %q, want %q", media.data, file)
+	}
+	if media.uploaded.ProductID != "p1" || media.uploaded.AltText != "front" || media.uploaded.SortOrder != 2 {
+		t.Errorf("uploaded image = %+v", media.uploaded)
+	}
+}
+
+func TestUploadImageDefaultSortOrder(t *testing.T) {
+	media := &fakeMediaService{}
+	router := newUploadRouter(media, 1024)
+
+	body, contentType := multipartBody(t, []byte("data"), nil)
+	if rec, _ := upload(router, body, contentType); rec.Code != http.StatusCreated {
+		t.Fatalf("status %d, want 201: %s", rec.Code, rec.Body)
+	}
+	// 未指定排序时交由服务层排在已有图片之后
+	if media.uploaded.SortOrder != -1 {
+		t.Errorf("sort order %d, want -1", media.uploaded.SortOrder)
+	}
+}
+
+func TestUploadImageValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxBytes    int64
+		file        []byte
+		fields      map[string]string
+		contentType string
+		serviceErr  error
+		want        int
+	}{
+		{name: "missing file", maxBytes: 1024, fields: map[string]string{"alt_text": "x"}, want: http.StatusBadRequest},
+		{name: "not multipart", maxBytes: 1024, file: []byte("data"), contentType: "application/json", want: http.StatusBadRequest},
+		{name: "invalid sort order", maxBytes: 1024, file: []byte("data"), fields: map[string]string{"sort_order": "first"}, want: http.StatusBadRequest},
+		{name: "file over limit", maxBytes: 16, file: bytes.Repeat([]byte("a"), 17), want: http.StatusRequestEntityTooLarge},
+		{name: "body over limit", maxBytes: 16, file: bytes.Repeat([]byte("a"), 2<<20), want: http.StatusRequestEntityTooLarge},
+		{name: "unsupported type", maxBytes: 1024, file: []byte("text"), serviceErr: mediaSrv.ErrUnsupportedImage, want: http.StatusUnsupportedMediaType},
+		{name: "unknown product", maxBytes: 1024, file: []byte("data"), serviceErr: mediaSrv.ErrProductNotFound, want: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			media := &fakeMediaService{err: tt.serviceErr}
+			router := newUploadRouter(media, tt.maxBytes)
+
+			body, contentType := multipartBody(t, tt.file, tt.fields)
+			if tt.contentType != "" {
+				contentType = tt.contentType
+			}
+			rec, resp := upload(router, body, contentType)
+			if rec.Code != tt.want || resp.Status.Code != tt.want {
+				t.Fatalf("status %d (body %d), want %d: %s", rec.Code, resp.Status.Code, tt.want, rec.Body)
+			}
+			if tt.serviceErr == nil && media.uploaded != nil {
+				t.Errorf("service called for rejected upload")
+			}
+		})
+	}
+}
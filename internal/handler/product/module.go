@@ -3,6 +3,7 @@ package product
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/innovationmech/simple-cli/internal/app"
+	"github.com/innovationmech/simple-cli/internal/config"
 )
 
 // ProductModule 商品模块，实现 server.Module 接口
@@ -13,11 +14,11 @@ type ProductModule struct {
 // Init 从 Container 获取依赖并初始化商品模块
 // Service 已在 Container 中创建为单例，可被多个模块共享
 func (m *ProductModule) Init(container *app.Container) error {
-	m.handler = NewProductHandler(container.ProductService)
+	m.handler = NewProductHandler(container.ProductService, container.MediaService, config.Storage().Images.MaxBytes)
 	return nil
 }
 
 // RegisterRoutes 注册商品模块的所有路由
-func (m *ProductModule) RegisterRoutes(router *gin.Engine) {
+func (m *ProductModule) RegisterRoutes(router *gin.RouterGroup) {
 	m.handler.RegisterRoutes(router)
 }
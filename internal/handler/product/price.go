@@ -0,0 +1,107 @@
+package product
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/innovationmech/simple-cli/internal/domain"
+	"github.com/innovationmech/simple-cli/internal/i18n"
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/types"
+)
+
+// ListPrices 获取商品当前价格与价格历史
+func (h *ProductHandler) ListPrices(c *gin.Context) {
+	var uri model.ProductPriceURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	var request model.ListPricesRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	prices, err := h.productService.ListPrices(c.Request.Context(), uri.ProductID, request.SKUID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: i18n.T(c.Request.Context(), "price.listed"),
+		},
+		Data: prices,
+	})
+}
+
+// SchedulePrice 预约调价或设置促销价
+func (h *ProductHandler) SchedulePrice(c *gin.Context) {
+	var uri model.ProductPriceURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	var request model.SchedulePriceRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	price := &model.ProductPrice{
+		ProductID:      uri.ProductID,
+		SKUID:          request.SKUID,
+		Kind:           request.Kind,
+		Price:          *request.Price,
+		EffectiveUntil: request.EffectiveUntil,
+		Actor:          request.Actor,
+	}
+	if request.EffectiveFrom != nil {
+		price.EffectiveFrom = *request.EffectiveFrom
+	}
+
+	if err := h.productService.SchedulePrice(c.Request.Context(), price); err != nil {
+		c.Error(err)
+		return
+	}
+
+	prices, err := h.productService.ListPrices(c.Request.Context(), uri.ProductID, request.SKUID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusCreated,
+			Message: i18n.T(c.Request.Context(), "price.scheduled"),
+		},
+		Data: prices,
+	})
+}
+
+// CancelPrice 取消尚未生效的价格记录
+func (h *ProductHandler) CancelPrice(c *gin.Context) {
+	var uri model.ProductPriceURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	if err := h.productService.CancelPrice(c.Request.Context(), uri.ProductID, uri.PriceID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: i18n.T(c.Request.Context(), "price.cancelled"),
+		},
+	})
+}
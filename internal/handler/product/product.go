@@ -2,34 +2,41 @@ package product
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/innovationmech/simple-cli/internal/domain"
+	"github.com/innovationmech/simple-cli/internal/i18n"
 	"github.com/innovationmech/simple-cli/internal/interfaces"
 	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/queryspec"
+	productSrv "github.com/innovationmech/simple-cli/internal/service/product"
 	"github.com/innovationmech/simple-cli/internal/types"
 )
 
 // ProductHandler 商品 HTTP 处理器
 type ProductHandler struct {
 	productService interfaces.ProductService
+	mediaService   interfaces.MediaService
+	// maxUploadBytes 单张图片上传的最大字节数
+	maxUploadBytes int64
 }
 
 // NewProductHandler 创建商品处理器实例
-func NewProductHandler(productService interfaces.ProductService) *ProductHandler {
-	return &ProductHandler{productService: productService}
+func NewProductHandler(productService interfaces.ProductService, mediaService interfaces.MediaService, maxUploadBytes int64) *ProductHandler {
+	return &ProductHandler{
+		productService: productService,
+		mediaService:   mediaService,
+		maxUploadBytes: maxUploadBytes,
+	}
 }
 
 // CreateProduct 创建商品
 func (h *ProductHandler) CreateProduct(c *gin.Context) {
 	var request model.CreateProductRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusBadRequest,
-				Message: "Invalid request: " + err.Error(),
-			},
-		})
+		c.Error(domain.Wrap(domain.CodeValidation, err))
 		return
 	}
 
@@ -37,24 +44,22 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 		ID:          uuid.New().String(),
 		Name:        request.Name,
 		Description: request.Description,
-		Price:       request.Price,
+		Price:       *request.Price,
 		Stock:       request.Stock,
+		TaxClass:    request.TaxClass,
+		Categories:  categoryRefs(request.CategoryIDs),
+		ChangedBy:   request.Actor,
 	}
 
 	if err := h.productService.CreateProduct(c.Request.Context(), product); err != nil {
-		c.JSON(http.StatusInternalServerError, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusInternalServerError,
-				Message: "Failed to create product",
-			},
-		})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, types.ApiResponse{
 		Status: types.ResponseStatus{
 			Code:    http.StatusCreated,
-			Message: "Product created successfully",
+			Message: i18n.T(c.Request.Context(), "product.created"),
 		},
 		Data: model.CreateProductResponse{
 			ID: product.ID,
@@ -66,73 +71,55 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 func (h *ProductHandler) GetProduct(c *gin.Context) {
 	var request model.GetProductRequest
 	if err := c.ShouldBindUri(&request); err != nil {
-		c.JSON(http.StatusBadRequest, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusBadRequest,
-				Message: "Invalid request: " + err.Error(),
-			},
-		})
+		c.Error(domain.Wrap(domain.CodeValidation, err))
 		return
 	}
 
 	product, err := h.productService.GetProduct(c.Request.Context(), request.ID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusNotFound,
-				Message: "Product not found",
-			},
-		})
+		c.Error(err)
 		return
 	}
 
+	c.Header("ETag", types.ETag(product.Version))
 	c.JSON(http.StatusOK, types.ApiResponse{
 		Status: types.ResponseStatus{
 			Code:    http.StatusOK,
-			Message: "Product retrieved successfully",
-		},
-		Data: model.GetProductResponse{
-			ID:          product.ID,
-			Name:        product.Name,
-			Description: product.Description,
-			Price:       product.Price,
-			Stock:       product.Stock,
+			Message: i18n.T(c.Request.Context(), "product.retrieved"),
 		},
+		Data: h.toProductResponse(product),
 	})
 }
 
 // UpdateProduct 更新商品
 func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 	var request model.UpdateProductRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
 	if err := c.ShouldBindUri(&request); err != nil {
-		c.JSON(http.StatusBadRequest, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusBadRequest,
-				Message: "Invalid request: " + err.Error(),
-			},
-		})
+		c.Error(domain.Wrap(domain.CodeValidation, err))
 		return
 	}
 
-	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusBadRequest,
-				Message: "Invalid request: " + err.Error(),
-			},
-		})
+	// 必须携带 GET 返回的 ETag，避免覆盖他人已做的修改
+	ifMatch := c.GetHeader("If-Match")
+	if ifMatch == "" {
+		c.Error(types.ErrIfMatchRequired)
 		return
 	}
 
 	// 先获取现有商品
 	product, err := h.productService.GetProduct(c.Request.Context(), request.ID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusNotFound,
-				Message: "Product not found",
-			},
-		})
+		c.Error(err)
+		return
+	}
+	if !types.IfMatch(ifMatch, product.Version) {
+		c.Header("ETag", types.ETag(product.Version))
+		c.Error(productSrv.ErrVersionConflict)
 		return
 	}
 
@@ -143,30 +130,34 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 	if request.Description != "" {
 		product.Description = request.Description
 	}
-	if request.Price > 0 {
-		product.Price = request.Price
+	if request.Price != nil {
+		product.Price = *request.Price
 	}
-	if request.Stock >= 0 {
-		product.Stock = request.Stock
+	if request.Stock != nil {
+		product.Stock = *request.Stock
 	}
+	if request.TaxClass != "" {
+		product.TaxClass = request.TaxClass
+	}
+	if request.CategoryIDs != nil {
+		product.Categories = categoryRefs(*request.CategoryIDs)
+	}
+	product.ChangedBy = request.Actor
 
 	if err := h.productService.UpdateProduct(c.Request.Context(), product); err != nil {
-		c.JSON(http.StatusInternalServerError, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusInternalServerError,
-				Message: "Failed to update product",
-			},
-		})
+		c.Error(err)
 		return
 	}
 
+	c.Header("ETag", types.ETag(product.Version))
 	c.JSON(http.StatusOK, types.ApiResponse{
 		Status: types.ResponseStatus{
 			Code:    http.StatusOK,
-			Message: "Product updated successfully",
+			Message: i18n.T(c.Request.Context(), "product.updated"),
 		},
 		Data: model.UpdateProductResponse{
-			ID: product.ID,
+			ID:      product.ID,
+			Version: product.Version,
 		},
 	})
 }
@@ -175,29 +166,20 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 func (h *ProductHandler) DeleteProduct(c *gin.Context) {
 	var request model.DeleteProductRequest
 	if err := c.ShouldBindUri(&request); err != nil {
-		c.JSON(http.StatusBadRequest, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusBadRequest,
-				Message: "Invalid request: " + err.Error(),
-			},
-		})
+		c.Error(domain.Wrap(domain.CodeValidation, err))
 		return
 	}
 
+	// 软删除保留商品图片，彻底删除时由 purge 命令清理
 	if err := h.productService.DeleteProduct(c.Request.Context(), request.ID); err != nil {
-		c.JSON(http.StatusInternalServerError, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusInternalServerError,
-				Message: "Failed to delete product",
-			},
-		})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, types.ApiResponse{
 		Status: types.ResponseStatus{
 			Code:    http.StatusOK,
-			Message: "Product deleted successfully",
+			Message: i18n.T(c.Request.Context(), "product.deleted"),
 		},
 		Data: model.DeleteProductResponse{
 			ID: request.ID,
@@ -205,16 +187,45 @@ func (h *ProductHandler) DeleteProduct(c *gin.Context) {
 	})
 }
 
+// RestoreProduct 恢复已软删除的商品
+func (h *ProductHandler) RestoreProduct(c *gin.Context) {
+	var request model.RestoreProductRequest
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	if err := h.productService.RestoreProduct(c.Request.Context(), request.ID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	product, err := h.productService.GetProduct(c.Request.Context(), request.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: i18n.T(c.Request.Context(), "product.restored"),
+		},
+		Data: h.toProductResponse(product),
+	})
+}
+
 // ListProducts 获取商品列表
 func (h *ProductHandler) ListProducts(c *gin.Context) {
 	var request model.ListProductsRequest
 	if err := c.ShouldBindQuery(&request); err != nil {
-		c.JSON(http.StatusBadRequest, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusBadRequest,
-				Message: "Invalid request: " + err.Error(),
-			},
-		})
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	spec, err := queryspec.Parse(c.Request.URL.Query(), model.ProductQuerySchema)
+	if err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
 		return
 	}
 
@@ -226,49 +237,163 @@ func (h *ProductHandler) ListProducts(c *gin.Context) {
 		request.PageSize = 10
 	}
 
-	products, total, err := h.productService.ListProducts(c.Request.Context(), request.Page, request.PageSize)
+	filter := model.ProductFilter{Category: request.Category, IncludeDeleted: request.IncludeDeleted}
+	products, page, err := h.productService.ListProducts(c.Request.Context(), filter, spec, request.Page, request.PageSize)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusInternalServerError,
-				Message: "Failed to list products",
-			},
-		})
+		c.Error(err)
 		return
 	}
 
 	// 转换响应
 	var productResponses []model.GetProductResponse
 	for _, p := range products {
-		productResponses = append(productResponses, model.GetProductResponse{
-			ID:          p.ID,
-			Name:        p.Name,
-			Description: p.Description,
-			Price:       p.Price,
-			Stock:       p.Stock,
-		})
+		productResponses = append(productResponses, h.toProductResponse(p))
 	}
 
 	c.JSON(http.StatusOK, types.ApiResponse{
 		Status: types.ResponseStatus{
 			Code:    http.StatusOK,
-			Message: "Products retrieved successfully",
+			Message: i18n.T(c.Request.Context(), "product.listed"),
 		},
-		Data: model.ListProductsResponse{
+		Data: spec.Project(model.ListProductsResponse{
 			Products: productResponses,
+			PageInfo: *page,
+		}, "products"),
+	})
+}
+
+// SearchProducts 全文搜索商品
+func (h *ProductHandler) SearchProducts(c *gin.Context) {
+	var request model.SearchProductsRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	// 设置默认值
+	if request.Page <= 0 {
+		request.Page = 1
+	}
+	if request.PageSize <= 0 {
+		request.PageSize = 10
+	}
+
+	filter := model.ProductFilter{
+		Category: request.Category,
+		MinPrice: request.MinPrice,
+		MaxPrice: request.MaxPrice,
+		InStock:  request.InStock,
+	}
+	hits, total, err := h.productService.SearchProducts(c.Request.Context(), request.Q, filter, request.Page, request.PageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	results := make([]model.SearchProductResult, 0, len(hits))
+	for _, hit := range hits {
+		results = append(results, model.SearchProductResult{
+			GetProductResponse: h.toProductResponse(hit.Product),
+			Score:              hit.Score,
+			Highlights: model.SearchHighlights{
+				Name:        hit.NameHighlight,
+				Description: hit.DescriptionHighlight,
+			},
+		})
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: i18n.T(c.Request.Context(), "product.listed"),
+		},
+		Data: model.SearchProductsResponse{
+			Products: results,
 			Total:    total,
 		},
 	})
 }
 
 // RegisterRoutes 注册商品相关路由
-func (h *ProductHandler) RegisterRoutes(router *gin.Engine) {
+func (h *ProductHandler) RegisterRoutes(router *gin.RouterGroup) {
 	products := router.Group("/products")
 	{
 		products.POST("", h.CreateProduct)
 		products.GET("", h.ListProducts)
+		products.GET("/search", h.SearchProducts)
 		products.GET("/:id", h.GetProduct)
 		products.PUT("/:id", h.UpdateProduct)
 		products.DELETE("/:id", h.DeleteProduct)
+		products.POST("/:id/restore", h.RestoreProduct)
+
+		products.POST("/:id/skus", h.CreateSKU)
+		products.PUT("/:id/skus/:sku_id", h.UpdateSKU)
+		products.DELETE("/:id/skus/:sku_id", h.DeleteSKU)
+
+		products.GET("/:id/prices", h.ListPrices)
+		products.POST("/:id/prices", h.SchedulePrice)
+		products.DELETE("/:id/prices/:price_id", h.CancelPrice)
+
+		products.POST("/:id/images", h.UploadImage)
+		products.GET("/:id/images", h.ListImages)
+		products.PUT("/:id/images/:image_id", h.UpdateImage)
+		products.DELETE("/:id/images/:image_id", h.DeleteImage)
+	}
+}
+
+// toProductResponse 将商品模型转换为响应结构
+func (h *ProductHandler) toProductResponse(p *model.Product) model.GetProductResponse {
+	categories := make([]model.CategorySummary, 0, len(p.Categories))
+	for _, c := range p.Categories {
+		categories = append(categories, model.CategorySummary{ID: c.ID, Name: c.Name, Slug: c.Slug})
+	}
+	now := time.Now()
+	variants := make([]model.VariantResponse, 0, len(p.SKUs))
+	for i := range p.SKUs {
+		sku := &p.SKUs[i]
+		price := p.CurrentPrice(sku, now)
+		variants = append(variants, model.VariantResponse{
+			ID:         sku.ID,
+			Code:       sku.Code,
+			Attributes: sku.Attributes,
+			Price:      price.Price,
+			SalePrice:  price.SalePrice,
+			SaleEndsAt: price.SaleEndsAt,
+			Stock:      sku.Stock,
+		})
+	}
+	images := make([]model.ImageResponse, 0, len(p.Images))
+	for i := range p.Images {
+		images = append(images, h.toImageResponse(&p.Images[i]))
+	}
+	price := p.CurrentPrice(nil, now)
+	var deletedAt *time.Time
+	if p.DeletedAt.Valid {
+		deletedAt = &p.DeletedAt.Time
+	}
+	return model.GetProductResponse{
+		ID:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		Price:       price.Price,
+		SalePrice:   price.SalePrice,
+		SaleEndsAt:  price.SaleEndsAt,
+		Stock:       p.AvailableStock(nil),
+		TaxClass:    p.TaxClass,
+		Categories:  categories,
+		Options:     p.VariantOptions(),
+		Variants:    variants,
+		Images:      images,
+		DeletedAt:   deletedAt,
+		Version:     p.Version,
+	}
+}
+
+// categoryRefs 将分类 ID 列表转换为仅含 ID 的分类引用，由服务层校验并补全
+func categoryRefs(ids []string) []model.Category {
+	categories := make([]model.Category, 0, len(ids))
+	for _, id := range ids {
+		categories = append(categories, model.Category{ID: id})
 	}
+	return categories
 }
@@ -0,0 +1,128 @@
+package product
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/innovationmech/simple-cli/internal/domain"
+	"github.com/innovationmech/simple-cli/internal/i18n"
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/types"
+)
+
+// CreateSKU 为商品创建 SKU
+func (h *ProductHandler) CreateSKU(c *gin.Context) {
+	var uri model.SKUURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	var request model.CreateSKURequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	sku := &model.SKU{
+		ID:         uuid.New().String(),
+		ProductID:  uri.ProductID,
+		Code:       request.Code,
+		Attributes: request.Attributes,
+		Price:      request.Price,
+		Stock:      request.Stock,
+		ChangedBy:  request.Actor,
+	}
+
+	if err := h.productService.CreateSKU(c.Request.Context(), sku); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusCreated,
+			Message: i18n.T(c.Request.Context(), "sku.created"),
+		},
+		Data: model.CreateSKUResponse{
+			ID: sku.ID,
+		},
+	})
+}
+
+// UpdateSKU 更新 SKU
+func (h *ProductHandler) UpdateSKU(c *gin.Context) {
+	var uri model.SKUURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	var request model.UpdateSKURequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	// 先获取现有 SKU
+	sku, err := h.productService.GetSKU(c.Request.Context(), uri.ProductID, uri.SKUID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// 更新字段
+	if request.Code != "" {
+		sku.Code = request.Code
+	}
+	if len(request.Attributes) > 0 {
+		sku.Attributes = request.Attributes
+	}
+	if request.Price != nil {
+		sku.Price = request.Price
+	}
+	if request.Stock != nil {
+		sku.Stock = *request.Stock
+	}
+	sku.ChangedBy = request.Actor
+
+	if err := h.productService.UpdateSKU(c.Request.Context(), sku); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: i18n.T(c.Request.Context(), "sku.updated"),
+		},
+		Data: model.UpdateSKUResponse{
+			ID: sku.ID,
+		},
+	})
+}
+
+// DeleteSKU 删除 SKU
+func (h *ProductHandler) DeleteSKU(c *gin.Context) {
+	var uri model.SKUURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	if err := h.productService.DeleteSKU(c.Request.Context(), uri.ProductID, uri.SKUID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: i18n.T(c.Request.Context(), "sku.deleted"),
+		},
+		Data: model.UpdateSKUResponse{
+			ID: uri.SKUID,
+		},
+	})
+}
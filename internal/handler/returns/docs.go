@@ -0,0 +1,20 @@
+package returns
+
+import (
+	"net/http"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/openapi"
+)
+
+// Routes 退货模块路由的文档描述
+func (m *ReturnModule) Routes() []openapi.Route {
+	return []openapi.Route{
+		{Method: http.MethodPost, Path: "/returns", Summary: "发起退货申请", Body: model.CreateReturnRequest{}, Response: model.CreateReturnResponse{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/returns", Summary: "获取退货申请列表", Query: model.ListReturnsRequest{}, List: model.ReturnQuerySchema, Response: model.ListReturnsResponse{}},
+		{Method: http.MethodGet, Path: "/returns/:id", Summary: "获取退货申请详情", Params: model.GetReturnRequest{}, Response: model.ReturnRequest{}},
+		{Method: http.MethodPost, Path: "/returns/:id/approve", Summary: "批准退货", Params: model.GetReturnRequest{}, Body: model.ReviewReturnRequest{}, BodyOptional: true, Response: model.UpdateReturnResponse{}},
+		{Method: http.MethodPost, Path: "/returns/:id/reject", Summary: "拒绝退货", Params: model.GetReturnRequest{}, Body: model.ReviewReturnRequest{}, BodyOptional: true, Response: model.UpdateReturnResponse{}},
+		{Method: http.MethodPost, Path: "/returns/:id/receive", Summary: "确认收货并退款", Params: model.GetReturnRequest{}, Response: model.UpdateReturnResponse{}},
+	}
+}
@@ -0,0 +1,23 @@
+package returns
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/innovationmech/simple-cli/internal/app"
+)
+
+// ReturnModule 退货（RMA）模块，实现 server.Module 接口
+type ReturnModule struct {
+	handler *ReturnHandler
+}
+
+// Init 从 Container 获取依赖并初始化退货模块
+// 退货服务依赖订单、商品与支付，统一由 Container 组装
+func (m *ReturnModule) Init(container *app.Container) error {
+	m.handler = NewReturnHandler(container.ReturnService)
+	return nil
+}
+
+// RegisterRoutes 注册退货模块的所有路由
+func (m *ReturnModule) RegisterRoutes(router *gin.RouterGroup) {
+	m.handler.RegisterRoutes(router)
+}
@@ -0,0 +1,209 @@
+package returns
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/innovationmech/simple-cli/internal/domain"
+	"github.com/innovationmech/simple-cli/internal/interfaces"
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/queryspec"
+	"github.com/innovationmech/simple-cli/internal/types"
+)
+
+// ReturnHandler 退货 HTTP 处理器
+type ReturnHandler struct {
+	returnService interfaces.ReturnService
+}
+
+// NewReturnHandler 创建退货处理器实例
+func NewReturnHandler(returnService interfaces.ReturnService) *ReturnHandler {
+	return &ReturnHandler{returnService: returnService}
+}
+
+// CreateReturn 客户发起退货申请
+func (h *ReturnHandler) CreateReturn(c *gin.Context) {
+	var request model.CreateReturnRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	ret := &model.ReturnRequest{
+		ID:      uuid.New().String(),
+		OrderID: request.OrderID,
+		UserID:  request.UserID,
+		Reason:  request.Reason,
+	}
+	for _, item := range request.Items {
+		ret.Items = append(ret.Items, model.ReturnItem{
+			ProductID: item.ProductID,
+			SKUID:     item.SKUID,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	if err := h.returnService.CreateReturn(c.Request.Context(), ret); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusCreated,
+			Message: "Return requested successfully",
+		},
+		Data: model.CreateReturnResponse{
+			ID:           ret.ID,
+			Status:       ret.Status,
+			RefundAmount: ret.RefundAmount,
+		},
+	})
+}
+
+// GetReturn 获取退货申请详情
+func (h *ReturnHandler) GetReturn(c *gin.Context) {
+	var request model.GetReturnRequest
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	ret, err := h.returnService.GetReturn(c.Request.Context(), request.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: "Return retrieved successfully",
+		},
+		Data: ret,
+	})
+}
+
+// ApproveReturn 员工批准退货申请
+func (h *ReturnHandler) ApproveReturn(c *gin.Context) {
+	h.review(c, model.ReturnStatusApproved)
+}
+
+// RejectReturn 员工拒绝退货申请
+func (h *ReturnHandler) RejectReturn(c *gin.Context) {
+	h.review(c, model.ReturnStatusRejected)
+}
+
+// ReceiveReturn 员工确认收到退货，自动入库并退款
+func (h *ReturnHandler) ReceiveReturn(c *gin.Context) {
+	var request model.GetReturnRequest
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	if err := h.returnService.ReceiveReturn(c.Request.Context(), request.ID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: "Return received and refunded successfully",
+		},
+		Data: model.UpdateReturnResponse{
+			ID:     request.ID,
+			Status: model.ReturnStatusRefunded,
+		},
+	})
+}
+
+// ListReturns 获取退货申请列表
+func (h *ReturnHandler) ListReturns(c *gin.Context) {
+	var request model.ListReturnsRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	spec, err := queryspec.Parse(c.Request.URL.Query(), model.ReturnQuerySchema)
+	if err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	// 设置默认值
+	if request.Page <= 0 {
+		request.Page = 1
+	}
+	if request.PageSize <= 0 {
+		request.PageSize = 10
+	}
+
+	returns, page, err := h.returnService.ListReturns(c.Request.Context(), request.OrderID, request.UserID, request.Status, spec, request.Page, request.PageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: "Returns retrieved successfully",
+		},
+		Data: spec.Project(model.ListReturnsResponse{
+			Returns:  returns,
+			PageInfo: *page,
+		}, "returns"),
+	})
+}
+
+// review 处理批准/拒绝请求
+func (h *ReturnHandler) review(c *gin.Context, status model.ReturnStatus) {
+	var uri model.GetReturnRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	// 审核备注可选
+	var request model.ReviewReturnRequest
+	_ = c.ShouldBindJSON(&request)
+
+	var err error
+	if status == model.ReturnStatusApproved {
+		err = h.returnService.ApproveReturn(c.Request.Context(), uri.ID, request.Note)
+	} else {
+		err = h.returnService.RejectReturn(c.Request.Context(), uri.ID, request.Note)
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: "Return reviewed successfully",
+		},
+		Data: model.UpdateReturnResponse{
+			ID:     uri.ID,
+			Status: status,
+		},
+	})
+}
+
+// RegisterRoutes 注册退货相关路由
+func (h *ReturnHandler) RegisterRoutes(router *gin.RouterGroup) {
+	returns := router.Group("/returns")
+	{
+		returns.POST("", h.CreateReturn)
+		returns.GET("", h.ListReturns)
+		returns.GET("/:id", h.GetReturn)
+		returns.POST("/:id/approve", h.ApproveReturn)
+		returns.POST("/:id/reject", h.RejectReturn)
+		returns.POST("/:id/receive", h.ReceiveReturn)
+	}
+}
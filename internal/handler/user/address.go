@@ -0,0 +1,263 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/innovationmech/simple-cli/internal/domain"
+	"github.com/innovationmech/simple-cli/internal/i18n"
+	"github.com/innovationmech/simple-cli/internal/interfaces"
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/types"
+)
+
+// AddressHandler 用户收货地址 HTTP 处理器
+type AddressHandler struct {
+	addressService interfaces.AddressService
+}
+
+// NewAddressHandler 创建收货地址处理器实例
+func NewAddressHandler(addressService interfaces.AddressService) *AddressHandler {
+	return &AddressHandler{addressService: addressService}
+}
+
+// CreateAddress 创建收货地址
+func (h *AddressHandler) CreateAddress(c *gin.Context) {
+	var uri model.AddressURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	var request model.CreateAddressRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	address := &model.Address{
+		ID:         uuid.New().String(),
+		UserID:     uri.UserID,
+		Recipient:  request.Recipient,
+		Phone:      request.Phone,
+		Province:   request.Province,
+		City:       request.City,
+		District:   request.District,
+		Street:     request.Street,
+		PostalCode: request.PostalCode,
+		IsDefault:  request.IsDefault,
+	}
+
+	if err := h.addressService.CreateAddress(c.Request.Context(), address); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusCreated,
+			Message: i18n.T(c.Request.Context(), "address.created"),
+		},
+		Data: model.CreateAddressResponse{
+			ID:        address.ID,
+			IsDefault: address.IsDefault,
+		},
+	})
+}
+
+// GetAddress 获取收货地址详情
+func (h *AddressHandler) GetAddress(c *gin.Context) {
+	var uri model.AddressURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	address, err := h.addressService.GetAddress(c.Request.Context(), uri.UserID, uri.AddressID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: i18n.T(c.Request.Context(), "address.retrieved"),
+		},
+		Data: toAddressResponse(address),
+	})
+}
+
+// UpdateAddress 更新收货地址
+func (h *AddressHandler) UpdateAddress(c *gin.Context) {
+	var uri model.AddressURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	var request model.UpdateAddressRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	// 先获取现有地址
+	address, err := h.addressService.GetAddress(c.Request.Context(), uri.UserID, uri.AddressID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// 更新字段
+	if request.Recipient != "" {
+		address.Recipient = request.Recipient
+	}
+	if request.Phone != "" {
+		address.Phone = request.Phone
+	}
+	if request.Province != "" {
+		address.Province = request.Province
+	}
+	if request.City != "" {
+		address.City = request.City
+	}
+	if request.District != "" {
+		address.District = request.District
+	}
+	if request.Street != "" {
+		address.Street = request.Street
+	}
+	if request.PostalCode != "" {
+		address.PostalCode = request.PostalCode
+	}
+	// 取消默认地址需通过设置另一个地址为默认来完成
+	if request.IsDefault != nil && *request.IsDefault {
+		address.IsDefault = true
+	}
+
+	if err := h.addressService.UpdateAddress(c.Request.Context(), address); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: i18n.T(c.Request.Context(), "address.updated"),
+		},
+		Data: model.UpdateAddressResponse{
+			ID: address.ID,
+		},
+	})
+}
+
+// DeleteAddress 删除收货地址
+func (h *AddressHandler) DeleteAddress(c *gin.Context) {
+	var uri model.AddressURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	if err := h.addressService.DeleteAddress(c.Request.Context(), uri.UserID, uri.AddressID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: i18n.T(c.Request.Context(), "address.deleted"),
+		},
+		Data: model.DeleteAddressResponse{
+			ID: uri.AddressID,
+		},
+	})
+}
+
+// ListAddresses 获取用户的收货地址列表
+func (h *AddressHandler) ListAddresses(c *gin.Context) {
+	var uri model.AddressURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	addresses, err := h.addressService.ListAddresses(c.Request.Context(), uri.UserID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// 转换响应
+	addressResponses := make([]model.GetAddressResponse, 0, len(addresses))
+	for _, a := range addresses {
+		addressResponses = append(addressResponses, toAddressResponse(a))
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: i18n.T(c.Request.Context(), "address.listed"),
+		},
+		Data: model.ListAddressesResponse{
+			Addresses: addressResponses,
+		},
+	})
+}
+
+// SetDefaultAddress 设置默认收货地址
+func (h *AddressHandler) SetDefaultAddress(c *gin.Context) {
+	var uri model.AddressURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	if err := h.addressService.SetDefaultAddress(c.Request.Context(), uri.UserID, uri.AddressID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: i18n.T(c.Request.Context(), "address.default_updated"),
+		},
+		Data: model.UpdateAddressResponse{
+			ID: uri.AddressID,
+		},
+	})
+}
+
+// RegisterRoutes 注册收货地址相关路由（挂载在 /users/:id 下）
+func (h *AddressHandler) RegisterRoutes(users *gin.RouterGroup) {
+	addresses := users.Group("/:id/addresses")
+	{
+		addresses.POST("", h.CreateAddress)
+		addresses.GET("", h.ListAddresses)
+		addresses.GET("/:address_id", h.GetAddress)
+		addresses.PUT("/:address_id", h.UpdateAddress)
+		addresses.DELETE("/:address_id", h.DeleteAddress)
+		addresses.POST("/:address_id/default", h.SetDefaultAddress)
+	}
+}
+
+// toAddressResponse 将地址模型转换为响应结构
+func toAddressResponse(a *model.Address) model.GetAddressResponse {
+	return model.GetAddressResponse{
+		ID:         a.ID,
+		UserID:     a.UserID,
+		Recipient:  a.Recipient,
+		Phone:      a.Phone,
+		Province:   a.Province,
+		City:       a.City,
+		District:   a.District,
+		Street:     a.Street,
+		PostalCode: a.PostalCode,
+		IsDefault:  a.IsDefault,
+		CreatedAt:  a.CreatedAt,
+	}
+}
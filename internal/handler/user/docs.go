@@ -0,0 +1,26 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/openapi"
+)
+
+// Routes 用户模块路由的文档描述
+func (m *UserModule) Routes() []openapi.Route {
+	return []openapi.Route{
+		{Method: http.MethodPost, Path: "/users", Summary: "创建用户", Body: model.CreateUserRequest{}, Response: model.CreateUserResponse{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/users/:id", Summary: "获取用户详情", Params: model.GetUserRequest{}, Response: model.GetUserResponse{}},
+		{Method: http.MethodPut, Path: "/users/:id", Summary: "更新用户", Body: model.UpdateUserRequest{}},
+		{Method: http.MethodDelete, Path: "/users/:id", Summary: "删除用户（软删除）", Params: model.DeleteUserRequest{}, Response: model.DeleteUserResponse{}},
+		{Method: http.MethodPost, Path: "/users/:id/restore", Summary: "恢复已删除的用户", Params: model.RestoreUserRequest{}, Response: model.DeleteUserResponse{}},
+
+		{Method: http.MethodPost, Path: "/users/:id/addresses", Summary: "新增收货地址", Params: model.AddressURI{}, Body: model.CreateAddressRequest{}, Response: model.CreateAddressResponse{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/users/:id/addresses", Summary: "获取地址簿", Params: model.AddressURI{}, Response: model.ListAddressesResponse{}},
+		{Method: http.MethodGet, Path: "/users/:id/addresses/:address_id", Summary: "获取地址详情", Params: model.AddressURI{}, Response: model.GetAddressResponse{}},
+		{Method: http.MethodPut, Path: "/users/:id/addresses/:address_id", Summary: "更新地址", Params: model.AddressURI{}, Body: model.UpdateAddressRequest{}, Response: model.UpdateAddressResponse{}},
+		{Method: http.MethodDelete, Path: "/users/:id/addresses/:address_id", Summary: "删除地址", Params: model.AddressURI{}, Response: model.DeleteAddressResponse{}},
+		{Method: http.MethodPost, Path: "/users/:id/addresses/:address_id/default", Summary: "设为默认地址", Params: model.AddressURI{}, Response: model.UpdateAddressResponse{}},
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/innovationmech/simple-cli/internal/domain"
+	"github.com/innovationmech/simple-cli/internal/i18n"
 	"github.com/innovationmech/simple-cli/internal/interfaces"
 	"github.com/innovationmech/simple-cli/internal/model"
 	"github.com/innovationmech/simple-cli/internal/types"
@@ -22,33 +25,25 @@ func NewUserHandler(userService interfaces.UserService) *UserHandler {
 func (h *UserHandler) CreateUser(c *gin.Context) {
 	var request model.CreateUserRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusBadRequest,
-				Message: "Invalid request",
-			},
-		})
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
 	}
 
 	user := &model.User{
+		ID:       uuid.New().String(),
 		Username: request.Username,
 		Email:    request.Email,
 	}
 
 	if err := h.userService.CreateUser(c.Request.Context(), user); err != nil {
-		c.JSON(http.StatusInternalServerError, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusInternalServerError,
-				Message: "Failed to create user",
-			},
-		})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, types.ApiResponse{
 		Status: types.ResponseStatus{
 			Code:    http.StatusCreated,
-			Message: "User created successfully",
+			Message: i18n.T(c.Request.Context(), "user.created"),
 		},
 		Data: model.CreateUserResponse{
 			ID: user.ID,
@@ -58,31 +53,21 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 
 func (h *UserHandler) GetUser(c *gin.Context) {
 	var request model.GetUserRequest
-	if err := c.ShouldBindQuery(&request); err != nil {
-		c.JSON(http.StatusBadRequest, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusBadRequest,
-				Message: "Invalid request",
-			},
-		})
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
 		return
 	}
 
 	user, err := h.userService.GetUser(c.Request.Context(), request.ID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusInternalServerError,
-				Message: "Failed to get user",
-			},
-		})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, types.ApiResponse{
 		Status: types.ResponseStatus{
 			Code:    http.StatusOK,
-			Message: "User retrieved successfully",
+			Message: i18n.T(c.Request.Context(), "user.retrieved"),
 		},
 		Data: model.GetUserResponse{
 			ID:       user.ID,
@@ -95,33 +80,66 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 func (h *UserHandler) UpdateUser(c *gin.Context) {
 	var request model.UpdateUserRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusBadRequest,
-				Message: "Invalid request",
-			},
-		})
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
 	}
 }
 
+// DeleteUser 软删除用户，已有订单仍保留对用户的引用
 func (h *UserHandler) DeleteUser(c *gin.Context) {
 	var request model.DeleteUserRequest
-	if err := c.ShouldBindQuery(&request); err != nil {
-		c.JSON(http.StatusBadRequest, types.ApiResponse{
-			Status: types.ResponseStatus{
-				Code:    http.StatusBadRequest,
-				Message: "Invalid request",
-			},
-		})
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
 	}
+
+	if err := h.userService.DeleteUser(c.Request.Context(), request.ID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: i18n.T(c.Request.Context(), "user.deleted"),
+		},
+		Data: model.DeleteUserResponse{
+			ID: request.ID,
+		},
+	})
+}
+
+// RestoreUser 恢复已软删除的用户
+func (h *UserHandler) RestoreUser(c *gin.Context) {
+	var request model.RestoreUserRequest
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(domain.Wrap(domain.CodeValidation, err))
+		return
+	}
+
+	if err := h.userService.RestoreUser(c.Request.Context(), request.ID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    http.StatusOK,
+			Message: i18n.T(c.Request.Context(), "user.restored"),
+		},
+		Data: model.DeleteUserResponse{
+			ID: request.ID,
+		},
+	})
 }
 
-func (h *UserHandler) RegisterRoutes(router *gin.Engine) {
-	router.Group("/users")
+func (h *UserHandler) RegisterRoutes(router *gin.RouterGroup) {
+	users := router.Group("/users")
 	{
-		router.POST("", h.CreateUser)
-		router.GET("/:id", h.GetUser)
-		router.PUT("/:id", h.UpdateUser)
-		router.DELETE("/:id", h.DeleteUser)
+		users.POST("", h.CreateUser)
+		users.GET("/:id", h.GetUser)
+		users.PUT("/:id", h.UpdateUser)
+		users.DELETE("/:id", h.DeleteUser)
+		users.POST("/:id/restore", h.RestoreUser)
 	}
 }
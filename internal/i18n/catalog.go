@@ -0,0 +1,8 @@
+package i18n
+
+// catalogs 各语言的消息目录
+// 新增消息时需在每种语言中补充同名键
+var catalogs = map[Locale]map[string]string{
+	English: enMessages,
+	Chinese: zhCNMessages,
+}
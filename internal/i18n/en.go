@@ -0,0 +1,161 @@
+package i18n
+
+// enMessages 英文消息目录
+var enMessages = map[string]string{
+	// 通用
+	"error.internal": "Internal Server Error",
+	"field.type":     "%s must be %s",
+	"type.boolean":   "a boolean",
+	"type.integer":   "an integer",
+	"type.number":    "a number",
+	"type.string":    "a string",
+	"type.array":     "an array",
+	"type.object":    "an object",
+	"type.value":     "a valid value",
+
+	// 按 OpenAPI 文档校验请求，键为 schema.<规则>，参数为字段与规则参数
+	"schema.required":             "%s is required",
+	"schema.json":                 "request body must be valid JSON",
+	"schema.enum":                 "%s must be one of: %s",
+	"schema.additionalProperties": "%s is not a recognized field",
+	"schema.format":               "%s must be a valid %s",
+	"schema.minimum":              "%s must be %s or greater",
+	"schema.maximum":              "%s must be %s or less",
+	"schema.exclusiveMinimum":     "%s must be greater than %s",
+	"schema.exclusiveMaximum":     "%s must be less than %s",
+	"schema.minLength":            "%s must be at least %s characters in length",
+	"schema.maxLength":            "%s must be at most %s characters in length",
+	"schema.minItems":             "%s must contain at least %s items",
+	"schema.maxItems":             "%s must contain at most %s items",
+	"schema.minProperties":        "%s must contain at least %s entries",
+
+	// 请求体内容类型与文档不符
+	"request.unsupported_media_type": "unsupported media type, expected application/json",
+
+	// 领域错误，键为领域错误的 Key
+	"address.not_found":           "address not found",
+	"cart.empty":                  "cart is empty",
+	"cart.item_not_found":         "product not in cart",
+	"category.invalid_parent":     "category cannot be moved under itself or its descendants",
+	"category.invalid_slug":       "slug must contain only lowercase letters, digits and hyphens",
+	"category.not_empty":          "category still has products or child categories",
+	"category.not_found":          "category not found",
+	"category.parent_not_found":   "parent category not found",
+	"category.slug_exists":        "category slug already exists",
+	"coupon.code_exists":          "coupon code already exists",
+	"coupon.code_required":        "coupon code is required",
+	"coupon.exhausted":            "coupon usage limit reached",
+	"coupon.invalid_amount":       "fixed coupon value must be positive",
+	"coupon.invalid_percentage":   "percentage coupon value must be between 0 and 100",
+	"coupon.invalid_period":       "coupon ends_at must be after starts_at",
+	"coupon.invalid_scope":        "invalid coupon scope type",
+	"coupon.invalid_type":         "invalid coupon type",
+	"coupon.not_found":            "coupon not found",
+	"coupon.unavailable":          "coupon is not active or outside its validity period",
+	"coupon.user_limit":           "coupon per-user limit reached",
+	"file.not_found":              "file not found",
+	"idempotency.body_too_large":  "request body with Idempotency-Key is too large",
+	"idempotency.in_flight":       "a request with this Idempotency-Key is still being processed",
+	"idempotency.key_invalid":     "Idempotency-Key must be at most 255 characters",
+	"idempotency.key_reused":      "Idempotency-Key was already used for a different request",
+	"image.invalid_dimensions":    "invalid image dimensions",
+	"image.invalid_sort_order":    "sort order must not be negative",
+	"image.not_found":             "image not found",
+	"image.too_large":             "image too large",
+	"image.unsupported_type":      "unsupported image type, only jpeg, png and gif are allowed",
+	"inventory.invalid_quantity":  "quantity must be positive, only corrections may be negative",
+	"inventory.invalid_sku":       "invalid sku",
+	"order.invalid_transition":    "invalid status transition",
+	"order.items_required":        "items or product_id/quantity are required",
+	"order.not_cancellable":       "only pending orders can be cancelled",
+	"order.no_items":              "order items are required",
+	"order.not_found":             "order not found",
+	"order.version_conflict":      "order has been modified",
+	"payment.amount_mismatch":     "payment amount does not match order total",
+	"payment.not_found":           "payment not found",
+	"payment.not_pending":         "payment is not in pending status",
+	"payment.not_refundable":      "only successful payments can be refunded",
+	"payment.refund_exceeded":     "refund amount exceeds the refundable amount",
+	"payment.order_not_pending":   "order is not in pending status",
+	"price.in_effect":             "price is already in effect",
+	"price.invalid_schedule":      "sale price requires effective_until after effective_from",
+	"price.not_found":             "price not found",
+	"product.invalid_price_range": "min_price must not be greater than max_price",
+	"product.not_found":           "product not found",
+	"product.version_conflict":    "product has been modified",
+	"request.if_match_required":   "If-Match header is required",
+	"return.already_reviewed":     "return has already been reviewed",
+	"return.in_progress":          "order already has a return in progress",
+	"return.no_payment":           "no successful payment found for order",
+	"return.not_approved":         "only approved returns can be received",
+	"return.not_found":            "return not found",
+	"return.order_not_completed":  "only completed orders can be returned",
+	"return.quantity_exceeded":    "return quantity exceeds ordered quantity",
+	"sku.attributes_required":     "sku attributes are required",
+	"sku.code_exists":             "sku code already exists",
+	"sku.code_required":           "sku code is required",
+	"sku.duplicate_attributes":    "a sku with the same attributes already exists",
+	"sku.not_found":               "sku not found",
+	"sku.required":                "sku is required for products with variants",
+	"stock.insufficient":          "insufficient stock",
+	"user.not_found":              "user not found",
+	"version.conflict":            "version conflict",
+
+	// 枚举取值的展示名称，键为 enum.<枚举名称>.<取值>
+	"enum.order_status.pending":       "Pending payment",
+	"enum.order_status.paid":          "Paid",
+	"enum.order_status.shipped":       "Shipped",
+	"enum.order_status.completed":     "Completed",
+	"enum.order_status.cancelled":     "Cancelled",
+	"enum.order_status.returned":      "Returned",
+	"enum.payment_status.pending":     "Pending",
+	"enum.payment_status.success":     "Succeeded",
+	"enum.payment_status.failed":      "Failed",
+	"enum.payment_status.refunded":    "Refunded",
+	"enum.payment_status.cancelled":   "Cancelled",
+	"enum.payment_method.alipay":      "Alipay",
+	"enum.payment_method.wechat":      "WeChat Pay",
+	"enum.payment_method.credit_card": "Credit card",
+	"enum.payment_method.balance":     "Account balance",
+
+	// 处理器成功提示
+	"address.created":            "Address created successfully",
+	"address.default_updated":    "Default address updated successfully",
+	"address.deleted":            "Address deleted successfully",
+	"address.listed":             "Addresses retrieved successfully",
+	"address.retrieved":          "Address retrieved successfully",
+	"address.updated":            "Address updated successfully",
+	"enum.listed":                "Enums retrieved successfully",
+	"image.deleted":              "Image deleted successfully",
+	"image.listed":               "Images retrieved successfully",
+	"image.updated":              "Image updated successfully",
+	"image.uploaded":             "Image uploaded successfully",
+	"order.cancelled":            "Order cancelled successfully",
+	"order.created":              "Order created successfully",
+	"order.history_retrieved":    "Order history retrieved successfully",
+	"order.listed":               "Orders retrieved successfully",
+	"order.quoted":               "Order quoted successfully",
+	"order.retrieved":            "Order retrieved successfully",
+	"order.status_updated":       "Order status updated successfully",
+	"payment.callback_processed": "Callback processed successfully",
+	"payment.created":            "Payment created successfully",
+	"payment.listed":             "Payments retrieved successfully",
+	"payment.refunded":           "Payment refunded successfully",
+	"payment.retrieved":          "Payment retrieved successfully",
+	"price.cancelled":            "Price cancelled successfully",
+	"price.listed":               "Prices retrieved successfully",
+	"price.scheduled":            "Price scheduled successfully",
+	"product.created":            "Product created successfully",
+	"product.deleted":            "Product deleted successfully",
+	"product.listed":             "Products retrieved successfully",
+	"product.restored":           "Product restored successfully",
+	"product.retrieved":          "Product retrieved successfully",
+	"product.updated":            "Product updated successfully",
+	"sku.created":                "SKU created successfully",
+	"sku.deleted":                "SKU deleted successfully",
+	"sku.updated":                "SKU updated successfully",
+	"user.created":               "User created successfully",
+	"user.deleted":               "User deleted successfully",
+	"user.restored":              "User restored successfully",
+	"user.retrieved":             "User retrieved successfully",
+}
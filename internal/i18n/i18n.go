@@ -0,0 +1,74 @@
+// Package i18n 提供 API 消息的多语言支持
+// 消息以键索引，键与领域错误的 Key、处理器的成功提示一一对应
+package i18n
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/text/language"
+)
+
+// Locale 语言标识
+type Locale string
+
+const (
+	// English 英文，默认语言
+	English Locale = "en"
+	// Chinese 简体中文
+	Chinese Locale = "zh-CN"
+)
+
+// Default 未指定或无法匹配时使用的语言
+const Default = English
+
+// supported 支持的语言，顺序与 matcher 中的标签一致
+var supported = []Locale{English, Chinese}
+
+var matcher = language.NewMatcher([]language.Tag{
+	language.English,
+	language.SimplifiedChinese,
+})
+
+// Resolve 按 lang 参数与 Accept-Language 请求头选择语言
+// lang 优先，二者都无法匹配时返回 Default
+func Resolve(lang, acceptLanguage string) Locale {
+	_, index := language.MatchStrings(matcher, lang, acceptLanguage)
+	return supported[index]
+}
+
+type contextKey struct{}
+
+// WithLocale 将请求语言写入 context
+func WithLocale(ctx context.Context, locale Locale) context.Context {
+	return context.WithValue(ctx, contextKey{}, locale)
+}
+
+// FromContext 读取请求语言，未设置时返回 Default
+func FromContext(ctx context.Context) Locale {
+	if locale, ok := ctx.Value(contextKey{}).(Locale); ok {
+		return locale
+	}
+	return Default
+}
+
+// Lookup 查找指定语言下的消息
+func Lookup(locale Locale, key string) (string, bool) {
+	message, ok := catalogs[locale][key]
+	return message, ok
+}
+
+// T 按请求语言翻译消息，args 用于格式化消息中的占位符
+// 当前语言缺少该键时回退到英文，英文也缺少时返回键本身
+func T(ctx context.Context, key string, args ...interface{}) string {
+	message, ok := Lookup(FromContext(ctx), key)
+	if !ok {
+		if message, ok = Lookup(English, key); !ok {
+			message = key
+		}
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
@@ -0,0 +1,30 @@
+package i18n
+
+import (
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/zh"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	zhTranslations "github.com/go-playground/validator/v10/translations/zh"
+)
+
+var universal = ut.New(en.New(), en.New(), zh.New())
+
+// RegisterValidator 为参数校验注册各语言的默认错误消息
+func RegisterValidator(v *validator.Validate) error {
+	if err := enTranslations.RegisterDefaultTranslations(v, Translator(English)); err != nil {
+		return err
+	}
+	return zhTranslations.RegisterDefaultTranslations(v, Translator(Chinese))
+}
+
+// Translator 返回语言对应的校验消息翻译器
+func Translator(locale Locale) ut.Translator {
+	name := "en"
+	if locale == Chinese {
+		name = "zh"
+	}
+	trans, _ := universal.GetTranslator(name)
+	return trans
+}
@@ -0,0 +1,161 @@
+package i18n
+
+// zhCNMessages 简体中文消息目录
+var zhCNMessages = map[string]string{
+	// 通用
+	"error.internal": "服务器内部错误",
+	"field.type":     "%s 必须是%s",
+	"type.boolean":   "布尔值",
+	"type.integer":   "整数",
+	"type.number":    "数字",
+	"type.string":    "字符串",
+	"type.array":     "数组",
+	"type.object":    "对象",
+	"type.value":     "有效的值",
+
+	// 按 OpenAPI 文档校验请求，键为 schema.<规则>，参数为字段与规则参数
+	"schema.required":             "%s为必填字段",
+	"schema.json":                 "请求体必须是合法的 JSON",
+	"schema.enum":                 "%s必须是[%s]中的一个",
+	"schema.additionalProperties": "%s不是可识别的字段",
+	"schema.format":               "%s必须是有效的%s",
+	"schema.minimum":              "%s必须大于或等于%s",
+	"schema.maximum":              "%s必须小于或等于%s",
+	"schema.exclusiveMinimum":     "%s必须大于%s",
+	"schema.exclusiveMaximum":     "%s必须小于%s",
+	"schema.minLength":            "%s长度必须至少为%s个字符",
+	"schema.maxLength":            "%s长度不能超过%s个字符",
+	"schema.minItems":             "%s必须至少包含%s项",
+	"schema.maxItems":             "%s最多只能包含%s项",
+	"schema.minProperties":        "%s必须至少包含%s项",
+
+	// 请求体内容类型与文档不符
+	"request.unsupported_media_type": "不支持的内容类型，请使用 application/json",
+
+	// 领域错误，键为领域错误的 Key
+	"address.not_found":           "收货地址不存在",
+	"cart.empty":                  "购物车为空",
+	"cart.item_not_found":         "购物车中没有该商品",
+	"category.invalid_parent":     "不能将分类移动到自身或其子分类下",
+	"category.invalid_slug":       "slug 只能包含小写字母、数字和连字符",
+	"category.not_empty":          "分类下仍有商品或子分类",
+	"category.not_found":          "分类不存在",
+	"category.parent_not_found":   "父分类不存在",
+	"category.slug_exists":        "分类 slug 已存在",
+	"coupon.code_exists":          "优惠券编码已存在",
+	"coupon.code_required":        "优惠券编码不能为空",
+	"coupon.exhausted":            "优惠券已达到使用次数上限",
+	"coupon.invalid_amount":       "满减券的金额必须为正数",
+	"coupon.invalid_percentage":   "折扣券的数值必须在 0 到 100 之间",
+	"coupon.invalid_period":       "优惠券的 ends_at 必须晚于 starts_at",
+	"coupon.invalid_scope":        "优惠券适用范围类型无效",
+	"coupon.invalid_type":         "优惠券类型无效",
+	"coupon.not_found":            "优惠券不存在",
+	"coupon.unavailable":          "优惠券未启用或不在有效期内",
+	"coupon.user_limit":           "已达到该优惠券的每人使用次数上限",
+	"file.not_found":              "文件不存在",
+	"idempotency.body_too_large":  "携带 Idempotency-Key 的请求体过大",
+	"idempotency.in_flight":       "使用该 Idempotency-Key 的请求仍在处理中",
+	"idempotency.key_invalid":     "Idempotency-Key 长度不能超过 255 个字符",
+	"idempotency.key_reused":      "该 Idempotency-Key 已用于其他请求",
+	"image.invalid_dimensions":    "图片尺寸无效",
+	"image.invalid_sort_order":    "排序值不能为负数",
+	"image.not_found":             "图片不存在",
+	"image.too_large":             "图片过大",
+	"image.unsupported_type":      "不支持的图片类型，仅支持 jpeg、png 和 gif",
+	"inventory.invalid_quantity":  "数量必须为正数，只有盘点修正可以为负数",
+	"inventory.invalid_sku":       "规格无效",
+	"order.invalid_transition":    "订单状态不允许该变更",
+	"order.items_required":        "必须提供 items 或 product_id/quantity",
+	"order.not_cancellable":       "只有待支付的订单可以取消",
+	"order.no_items":              "订单商品不能为空",
+	"order.not_found":             "订单不存在",
+	"order.version_conflict":      "订单已被修改，请重新获取后再提交",
+	"payment.amount_mismatch":     "支付金额与订单总额不一致",
+	"payment.not_found":           "支付记录不存在",
+	"payment.not_pending":         "支付不是待处理状态",
+	"payment.not_refundable":      "只有支付成功的记录可以退款",
+	"payment.refund_exceeded":     "退款金额超过剩余可退金额",
+	"payment.order_not_pending":   "订单不是待支付状态",
+	"price.in_effect":             "价格已生效，不能取消",
+	"price.invalid_schedule":      "促销价的 effective_until 必须晚于 effective_from",
+	"price.not_found":             "价格记录不存在",
+	"product.invalid_price_range": "min_price 不能大于 max_price",
+	"product.not_found":           "产品不存在",
+	"product.version_conflict":    "产品已被修改，请重新获取后再提交",
+	"request.if_match_required":   "缺少 If-Match 请求头",
+	"return.already_reviewed":     "退货申请已审核",
+	"return.in_progress":          "该订单已有进行中的退货申请",
+	"return.no_payment":           "订单没有支付成功的记录",
+	"return.not_approved":         "只有已批准的退货可以确认收货",
+	"return.not_found":            "退货申请不存在",
+	"return.order_not_completed":  "只有已完成的订单可以退货",
+	"return.quantity_exceeded":    "退货数量超过购买数量",
+	"sku.attributes_required":     "规格属性不能为空",
+	"sku.code_exists":             "规格编码已存在",
+	"sku.code_required":           "规格编码不能为空",
+	"sku.duplicate_attributes":    "已存在相同属性的规格",
+	"sku.not_found":               "规格不存在",
+	"sku.required":                "该商品有多个规格，必须指定规格",
+	"stock.insufficient":          "库存不足",
+	"user.not_found":              "用户不存在",
+	"version.conflict":            "数据已被修改",
+
+	// 枚举取值的展示名称，键为 enum.<枚举名称>.<取值>
+	"enum.order_status.pending":       "待支付",
+	"enum.order_status.paid":          "已支付",
+	"enum.order_status.shipped":       "已发货",
+	"enum.order_status.completed":     "已完成",
+	"enum.order_status.cancelled":     "已取消",
+	"enum.order_status.returned":      "已退货",
+	"enum.payment_status.pending":     "待支付",
+	"enum.payment_status.success":     "支付成功",
+	"enum.payment_status.failed":      "支付失败",
+	"enum.payment_status.refunded":    "已退款",
+	"enum.payment_status.cancelled":   "已取消",
+	"enum.payment_method.alipay":      "支付宝",
+	"enum.payment_method.wechat":      "微信支付",
+	"enum.payment_method.credit_card": "信用卡",
+	"enum.payment_method.balance":     "余额支付",
+
+	// 处理器成功提示
+	"address.created":            "收货地址创建成功",
+	"address.default_updated":    "默认收货地址设置成功",
+	"address.deleted":            "收货地址删除成功",
+	"address.listed":             "获取收货地址列表成功",
+	"address.retrieved":          "获取收货地址成功",
+	"address.updated":            "收货地址更新成功",
+	"enum.listed":                "获取枚举成功",
+	"image.deleted":              "图片删除成功",
+	"image.listed":               "获取图片列表成功",
+	"image.updated":              "图片更新成功",
+	"image.uploaded":             "图片上传成功",
+	"order.cancelled":            "订单取消成功",
+	"order.created":              "订单创建成功",
+	"order.history_retrieved":    "获取订单历史成功",
+	"order.listed":               "获取订单列表成功",
+	"order.quoted":               "订单试算成功",
+	"order.retrieved":            "获取订单成功",
+	"order.status_updated":       "订单状态更新成功",
+	"payment.callback_processed": "支付回调处理成功",
+	"payment.created":            "支付创建成功",
+	"payment.listed":             "获取支付记录列表成功",
+	"payment.refunded":           "退款成功",
+	"payment.retrieved":          "获取支付记录成功",
+	"price.cancelled":            "价格取消成功",
+	"price.listed":               "获取价格列表成功",
+	"price.scheduled":            "价格设置成功",
+	"product.created":            "产品创建成功",
+	"product.deleted":            "产品删除成功",
+	"product.listed":             "获取产品列表成功",
+	"product.restored":           "产品恢复成功",
+	"product.retrieved":          "获取产品成功",
+	"product.updated":            "产品更新成功",
+	"sku.created":                "规格创建成功",
+	"sku.deleted":                "规格删除成功",
+	"sku.updated":                "规格更新成功",
+	"user.created":               "用户创建成功",
+	"user.deleted":               "用户删除成功",
+	"user.restored":              "用户恢复成功",
+	"user.retrieved":             "获取用户成功",
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+)
+
+// AddressService 收货地址服务接口
+// 定义用户地址簿相关的业务操作，所有操作均限定在指定用户范围内
+type AddressService interface {
+	CreateAddress(ctx context.Context, address *model.Address) error
+	GetAddress(ctx context.Context, userID, id string) (*model.Address, error)
+	UpdateAddress(ctx context.Context, address *model.Address) error
+	DeleteAddress(ctx context.Context, userID, id string) error
+	ListAddresses(ctx context.Context, userID string) ([]*model.Address, error)
+	SetDefaultAddress(ctx context.Context, userID, id string) error
+}
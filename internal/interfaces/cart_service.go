@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+)
+
+// CartService 购物车服务接口
+// 定义购物车商品增删改、实时价格库存校验以及结算下单的业务操作
+type CartService interface {
+	GetCart(ctx context.Context, userID string) (*model.CartDetail, error)
+	// 购物车行由商品与 SKU 共同确定，无规格商品的 skuID 为空
+	AddItem(ctx context.Context, userID, productID, skuID string, quantity int) (*model.CartDetail, error)
+	UpdateItem(ctx context.Context, userID, productID, skuID string, quantity int) (*model.CartDetail, error)
+	RemoveItem(ctx context.Context, userID, productID, skuID string) (*model.CartDetail, error)
+	ClearCart(ctx context.Context, userID string) error
+	Checkout(ctx context.Context, userID, addressID string, couponCodes []string) (*model.Order, error)
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+)
+
+// CategoryService 商品分类服务接口
+// 定义分类树维护相关的业务操作
+type CategoryService interface {
+	CreateCategory(ctx context.Context, category *model.Category) error
+	GetCategory(ctx context.Context, id string) (*model.Category, error)
+	UpdateCategory(ctx context.Context, category *model.Category) error
+	// DeleteCategory 删除分类，cascade 为 true 时连同子分类删除并解除商品关联
+	DeleteCategory(ctx context.Context, id string, cascade bool) error
+	GetCategoryTree(ctx context.Context) ([]*model.CategoryNode, error)
+}
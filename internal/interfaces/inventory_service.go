@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/queryspec"
+)
+
+// InventoryService 库存服务接口
+// 定义库存调整、调整日志查询与低库存预警的业务操作
+type InventoryService interface {
+	// GetInventory 获取商品库存与调整记录，skuID 非空时只返回该 SKU 的库存与记录
+	GetInventory(ctx context.Context, productID, skuID string, spec *queryspec.Spec, page, pageSize int) (*model.InventoryResponse, error)
+	// Adjust 按调整类型增减库存，adj.Delta 为请求数量，方向由类型决定
+	Adjust(ctx context.Context, adj *model.InventoryAdjustment) error
+	// SetThreshold 设置低库存阈值，为 nil 时关闭预警
+	SetThreshold(ctx context.Context, productID string, threshold *int) error
+	ListAlerts(ctx context.Context, status model.StockAlertStatus, spec *queryspec.Spec, page, pageSize int) ([]*model.StockAlert, *queryspec.PageInfo, error)
+}
@@ -0,0 +1,26 @@
+package interfaces
+
+import (
+	"context"
+	"io"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+)
+
+// MediaService 媒体文件服务接口
+// 定义商品图片上传、缩略图生成与文件访问相关的业务操作
+type MediaService interface {
+	// UploadProductImage 校验并保存图片原图与缩略图，image 中的尺寸、key 等字段由服务填充
+	// SortOrder 为负数时排在该商品已有图片之后
+	UploadProductImage(ctx context.Context, image *model.ProductImage, data []byte) error
+	GetProductImage(ctx context.Context, productID, imageID string) (*model.ProductImage, error)
+	ListProductImages(ctx context.Context, productID string) ([]*model.ProductImage, error)
+	UpdateProductImage(ctx context.Context, image *model.ProductImage) error
+	DeleteProductImage(ctx context.Context, productID, imageID string) error
+	// DeleteProductImages 删除商品的全部图片，用于商品删除后的清理
+	DeleteProductImages(ctx context.Context, productID string) error
+	// OpenFile 读取存储中的文件，返回内容与内容类型
+	OpenFile(ctx context.Context, key string) (io.ReadCloser, string, error)
+	// ImageURL 返回文件的访问地址
+	ImageURL(key string) string
+}
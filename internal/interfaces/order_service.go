@@ -4,14 +4,19 @@ import (
 	"context"
 
 	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/queryspec"
 )
 
 // OrderService 订单服务接口
 // 定义订单相关的业务操作
 type OrderService interface {
 	CreateOrder(ctx context.Context, order *model.Order) error
+	// QuoteOrder 按与下单相同的规则计算价格，但不创建订单
+	QuoteOrder(ctx context.Context, order *model.Order) error
 	GetOrder(ctx context.Context, id string) (*model.Order, error)
-	UpdateOrderStatus(ctx context.Context, id string, status model.OrderStatus) error
+	// UpdateOrderStatus 更新订单状态，version 为调用方读取到的订单版本号，不一致时拒绝更新
+	UpdateOrderStatus(ctx context.Context, id string, status model.OrderStatus, version int) (*model.Order, error)
 	CancelOrder(ctx context.Context, id string) error
-	ListOrdersByUser(ctx context.Context, userID string, page, pageSize int) ([]*model.Order, int64, error)
+	ListOrdersByUser(ctx context.Context, userID string, spec *queryspec.Spec, page, pageSize int) ([]*model.Order, *queryspec.PageInfo, error)
+	GetOrderHistory(ctx context.Context, id string) ([]*model.OrderHistory, error)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/queryspec"
 )
 
 // PaymentService 支付服务接口
@@ -12,6 +13,7 @@ type PaymentService interface {
 	CreatePayment(ctx context.Context, payment *model.Payment) (paymentURL string, err error)
 	GetPayment(ctx context.Context, id string) (*model.Payment, error)
 	ProcessCallback(ctx context.Context, paymentID, transactionID string, success bool) error
-	RefundPayment(ctx context.Context, id string, reason string) error
-	ListPayments(ctx context.Context, userID, orderID string, page, pageSize int) ([]*model.Payment, int64, error)
+	// RefundPayment 退款 amount 元，amount 不大于 0 时退还剩余全部金额
+	RefundPayment(ctx context.Context, id string, amount float64, reason string) error
+	ListPayments(ctx context.Context, userID, orderID string, spec *queryspec.Spec, page, pageSize int) ([]*model.Payment, *queryspec.PageInfo, error)
 }
@@ -2,8 +2,10 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/queryspec"
 )
 
 // ProductService 商品服务接口
@@ -12,6 +14,28 @@ type ProductService interface {
 	CreateProduct(ctx context.Context, product *model.Product) error
 	GetProduct(ctx context.Context, id string) (*model.Product, error)
 	UpdateProduct(ctx context.Context, product *model.Product) error
+	// DeleteProduct 软删除商品，可通过 RestoreProduct 恢复
 	DeleteProduct(ctx context.Context, id string) error
-	ListProducts(ctx context.Context, page, pageSize int) ([]*model.Product, int64, error)
+	RestoreProduct(ctx context.Context, id string) error
+	// ListDeletedProductIDs 返回在 before 之前软删除的商品 ID
+	ListDeletedProductIDs(ctx context.Context, before time.Time) ([]string, error)
+	// PurgeProduct 彻底删除已软删除的商品及其 SKU、分类关联、价格与库存记录
+	PurgeProduct(ctx context.Context, id string) error
+	// ListProducts 分页获取商品列表，filter.Category 可按分类（含子分类）过滤
+	ListProducts(ctx context.Context, filter model.ProductFilter, spec *queryspec.Spec, page, pageSize int) ([]*model.Product, *queryspec.PageInfo, error)
+	// SearchProducts 按关键词全文搜索商品，可叠加分类、价格区间与库存过滤
+	SearchProducts(ctx context.Context, query string, filter model.ProductFilter, page, pageSize int) ([]*model.ProductSearchHit, int64, error)
+
+	// SKU 管理，同一商品的 SKU 需使用相同的属性名且属性组合唯一
+	CreateSKU(ctx context.Context, sku *model.SKU) error
+	GetSKU(ctx context.Context, productID, skuID string) (*model.SKU, error)
+	UpdateSKU(ctx context.Context, sku *model.SKU) error
+	DeleteSKU(ctx context.Context, productID, skuID string) error
+
+	// ListPrices 返回商品（或指定 SKU）的当前价格与价格历史
+	ListPrices(ctx context.Context, productID, skuID string) (*model.ListPricesResponse, error)
+	// SchedulePrice 预约调价或设置促销价，立即生效的基础价同时更新商品或 SKU 价格
+	SchedulePrice(ctx context.Context, price *model.ProductPrice) error
+	// CancelPrice 取消尚未生效的价格记录
+	CancelPrice(ctx context.Context, productID string, priceID uint) error
 }
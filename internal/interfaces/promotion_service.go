@@ -0,0 +1,25 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/queryspec"
+)
+
+// PromotionService 促销服务接口
+// 定义优惠券管理以及订单优惠计算、核销与归还的业务操作
+type PromotionService interface {
+	CreateCoupon(ctx context.Context, coupon *model.Coupon) error
+	GetCoupon(ctx context.Context, id string) (*model.Coupon, error)
+	UpdateCoupon(ctx context.Context, coupon *model.Coupon) error
+	DeleteCoupon(ctx context.Context, id string) error
+	ListCoupons(ctx context.Context, spec *queryspec.Spec, page, pageSize int) ([]*model.Coupon, *queryspec.PageInfo, error)
+
+	// CalculateDiscounts 校验优惠码并计算每张券的优惠金额（不核销）
+	CalculateDiscounts(ctx context.Context, userID string, items []model.OrderItem, codes []string) ([]model.OrderDiscount, error)
+	// RedeemDiscounts 原子地核销订单使用的优惠券
+	RedeemDiscounts(ctx context.Context, userID string, discounts []model.OrderDiscount) error
+	// ReleaseDiscounts 归还订单使用的优惠券次数
+	ReleaseDiscounts(ctx context.Context, userID string, discounts []model.OrderDiscount) error
+}
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/queryspec"
+)
+
+// ReturnService 退货（RMA）服务接口
+// 定义退货申请、审核、收货入库及退款的业务操作
+type ReturnService interface {
+	CreateReturn(ctx context.Context, ret *model.ReturnRequest) error
+	GetReturn(ctx context.Context, id string) (*model.ReturnRequest, error)
+	ApproveReturn(ctx context.Context, id, note string) error
+	RejectReturn(ctx context.Context, id, note string) error
+	ReceiveReturn(ctx context.Context, id string) error
+	ListReturns(ctx context.Context, orderID, userID string, status model.ReturnStatus, spec *queryspec.Spec, page, pageSize int) ([]*model.ReturnRequest, *queryspec.PageInfo, error)
+}
@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/innovationmech/simple-cli/internal/model"
 )
@@ -10,5 +11,9 @@ type UserService interface {
 	CreateUser(ctx context.Context, user *model.User) error
 	GetUser(ctx context.Context, id string) (*model.User, error)
 	UpdateUser(ctx context.Context, user *model.User) error
+	// DeleteUser 软删除用户，可通过 RestoreUser 恢复
 	DeleteUser(ctx context.Context, id string) error
+	RestoreUser(ctx context.Context, id string) error
+	// PurgeDeletedUsers 彻底删除在 before 之前软删除的用户，返回删除的用户数
+	PurgeDeletedUsers(ctx context.Context, before time.Time) (int64, error)
 }
@@ -0,0 +1,83 @@
+// Package logging 结构化日志：基于 zap 输出 JSON 日志，日志行携带请求 ID
+package logging
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Config 日志配置
+type Config struct {
+	// Level 最低日志级别：debug、info、warn、error
+	Level string
+	// Format 输出格式：json 或 console（便于本地开发阅读）
+	Format string
+}
+
+type requestIDKey struct{}
+
+// Setup 按配置创建日志并设为全局日志，标准库 log 的输出一并转为结构化日志
+func Setup(cfg Config) error {
+	logger, err := New(cfg)
+	if err != nil {
+		return err
+	}
+	zap.ReplaceGlobals(logger)
+	zap.RedirectStdLog(logger)
+	return nil
+}
+
+// New 按配置创建日志
+func New(cfg Config) (*zap.Logger, error) {
+	level, err := zapcore.ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log level %q: %w", cfg.Level, err)
+	}
+
+	var zc zap.Config
+	switch cfg.Format {
+	case "json":
+		zc = zap.NewProductionConfig()
+		zc.EncoderConfig.TimeKey = "time"
+		zc.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	case "console":
+		zc = zap.NewDevelopmentConfig()
+	default:
+		return nil, fmt.Errorf("invalid log format %q, want json or console", cfg.Format)
+	}
+	zc.Level = zap.NewAtomicLevelAt(level)
+	zc.Sampling = nil
+	return zc.Build()
+}
+
+// L 返回全局日志
+func L() *zap.Logger {
+	return zap.L()
+}
+
+// Sync 写出缓冲中的日志，程序退出前调用
+func Sync() {
+	_ = zap.L().Sync()
+}
+
+// WithRequestID 将请求 ID 写入 context
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID 返回 context 中的请求 ID，没有时为空
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// FromContext 返回带有请求 ID 字段的日志，context 中没有请求 ID 时返回全局日志
+func FromContext(ctx context.Context) *zap.Logger {
+	if id := RequestID(ctx); id != "" {
+		return zap.L().With(zap.String("request_id", id))
+	}
+	return zap.L()
+}
@@ -0,0 +1,108 @@
+// Package metrics Prometheus 指标：HTTP 请求、数据库连接池、Go 运行时与业务计数
+// 指标始终在内存中累计，只有开启 metrics.enabled 后才对外暴露
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace 指标名称前缀
+const namespace = "simple_cli"
+
+var registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "HTTP requests by method, route template and status code.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by method, route template and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	ordersCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "orders_created_total",
+		Help:      "Orders created.",
+	})
+
+	payments = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "payments_total",
+		Help:      "Completed payments by method and result (succeeded or failed).",
+	}, []string{"method", "result"})
+
+	refundAmount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "refund_amount_total",
+		Help:      "Refunded amount by payment method.",
+	}, []string{"method"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpDuration,
+		ordersCreated,
+		payments,
+		refundAmount,
+	)
+}
+
+// Handler 以 Prometheus 文本格式输出全部指标
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// RegisterDB 采集数据库连接池状态（sql.DB.Stats），重复注册时忽略
+func RegisterDB(db *sql.DB) error {
+	err := registry.Register(collectors.NewDBStatsCollector(db, "main"))
+	var registered prometheus.AlreadyRegisteredError
+	if errors.As(err, &registered) {
+		return nil
+	}
+	return err
+}
+
+// ObserveRequest 记录一次 HTTP 请求，route 为路由模板，避免按实际路径产生过多标签
+func ObserveRequest(method, route string, status int, elapsed time.Duration) {
+	code := strconv.Itoa(status)
+	httpRequests.WithLabelValues(method, route, code).Inc()
+	httpDuration.WithLabelValues(method, route, code).Observe(elapsed.Seconds())
+}
+
+// OrderCreated 记录一笔新订单
+func OrderCreated() {
+	ordersCreated.Inc()
+}
+
+// PaymentCompleted 记录一笔支付结果
+func PaymentCompleted(method string, success bool) {
+	result := "failed"
+	if success {
+		result = "succeeded"
+	}
+	payments.WithLabelValues(method, result).Inc()
+}
+
+// Refunded 记录一笔退款金额
+func Refunded(method string, amount float64) {
+	refundAmount.WithLabelValues(method).Add(amount)
+}
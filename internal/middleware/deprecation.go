@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Deprecation 接口的弃用信息
+type Deprecation struct {
+	// Since 弃用时间，为零时 Deprecation 响应头为 true
+	Since time.Time
+	// Sunset 计划下线时间，为零时不发送 Sunset 响应头
+	Sunset time.Time
+	// Successor 返回替代接口的地址，通过 Link rel="successor-version" 告知调用方，可为空
+	Successor func(c *gin.Context) string
+}
+
+// Deprecated 为已弃用的路由或路由组添加 Deprecation（RFC 9745）与 Sunset（RFC 8594）响应头
+// 新版本接口上线后，旧版本路由挂载本中间件即可与新版本并存
+func Deprecated(d Deprecation) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if d.Since.IsZero() {
+			c.Header("Deprecation", "true")
+		} else {
+			c.Header("Deprecation", "@"+strconv.FormatInt(d.Since.Unix(), 10))
+		}
+		if !d.Sunset.IsZero() {
+			c.Header("Sunset", d.Sunset.UTC().Format(http.TimeFormat))
+		}
+		if d.Successor != nil {
+			if successor := d.Successor(c); successor != "" {
+				c.Header("Link", "<"+successor+`>; rel="successor-version"`)
+			}
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,158 @@
+// Package middleware 提供各模块共用的 gin 中间件
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/innovationmech/simple-cli/internal/domain"
+	"github.com/innovationmech/simple-cli/internal/i18n"
+	"github.com/innovationmech/simple-cli/internal/logging"
+	"github.com/innovationmech/simple-cli/internal/queryspec"
+	"github.com/innovationmech/simple-cli/internal/types"
+	"go.uber.org/zap"
+)
+
+// problemTypePrefix 问题类型 URI 前缀，后接领域错误码
+const problemTypePrefix = "urn:simple-cli:problem:"
+
+// statusByCode 领域错误码对应的 HTTP 状态码
+var statusByCode = map[domain.Code]int{
+	domain.CodeInternal:             http.StatusInternalServerError,
+	domain.CodeValidation:           http.StatusBadRequest,
+	domain.CodeNotFound:             http.StatusNotFound,
+	domain.CodeConflict:             http.StatusConflict,
+	domain.CodeInsufficientStock:    http.StatusConflict,
+	domain.CodeInvalidTransition:    http.StatusConflict,
+	domain.CodeUnprocessable:        http.StatusUnprocessableEntity,
+	domain.CodePreconditionRequired: http.StatusPreconditionRequired,
+	domain.CodePreconditionFailed:   http.StatusPreconditionFailed,
+	domain.CodePayloadTooLarge:      http.StatusRequestEntityTooLarge,
+	domain.CodeUnsupportedMediaType: http.StatusUnsupportedMediaType,
+}
+
+// HTTPStatus 返回领域错误码对应的 HTTP 状态码
+func HTTPStatus(code domain.Code) int {
+	if status, ok := statusByCode[code]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// Errors 统一的错误响应中间件
+// 处理器通过 c.Error 记录错误后直接返回，由本中间件按最后一个错误的领域错误码
+// 写出状态码与响应体；处理器已写出响应时不做处理。
+// 默认返回 types.ApiResponse，Accept 优先 application/problem+json 时返回 RFC 7807 问题详情
+func Errors() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		writeError(c, c.Errors.Last().Err)
+	}
+}
+
+// writeError 按领域错误码写出错误响应，供在 Errors 之外运行的中间件直接使用
+func writeError(c *gin.Context, err error) {
+	code := codeOf(err)
+	status := HTTPStatus(code)
+	ctx := c.Request.Context()
+	message := localize(ctx, err)
+	fields := fieldErrors(ctx, err)
+	if len(fields) > 0 {
+		message = joinFieldMessages(fields)
+	}
+	if code == domain.CodeInternal {
+		// 内部错误只记录日志，不向调用方暴露细节
+		logging.FromContext(ctx).Error("internal error",
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.Error(err),
+		)
+		message = i18n.T(ctx, "error.internal")
+	}
+
+	if c.NegotiateFormat(binding.MIMEJSON, types.ProblemContentType) == types.ProblemContentType {
+		writeProblem(c, status, code, message, fields)
+		return
+	}
+
+	details := []types.ErrorDetail{{
+		Code:    status,
+		Reason:  string(code),
+		Message: message,
+	}}
+	if len(fields) > 0 {
+		details = details[:0]
+		for _, f := range fields {
+			details = append(details, types.ErrorDetail{
+				Code:    status,
+				Reason:  string(code),
+				Field:   f.Field,
+				Message: f.Message,
+			})
+		}
+	}
+	c.JSON(status, types.ApiResponse{
+		Status: types.ResponseStatus{
+			Code:    status,
+			Message: message,
+		},
+		Errors: details,
+	})
+}
+
+// writeProblem 以 application/problem+json 写出问题详情
+func writeProblem(c *gin.Context, status int, code domain.Code, message string, fields []types.FieldError) {
+	problem := types.Problem{
+		Type:     problemTypePrefix + string(code),
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   message,
+		Instance: c.Request.URL.Path,
+		Code:     string(code),
+		Errors:   fields,
+	}
+	body, err := json.Marshal(problem)
+	if err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+	c.Data(status, types.ProblemContentType, body)
+}
+
+// localize 按请求语言翻译错误消息
+// 只翻译未经包装的领域错误，包装后的错误带有上下文信息，保留原文
+func localize(ctx context.Context, err error) string {
+	if domainErr, ok := err.(*domain.Error); ok && domainErr.Key != "" {
+		if message, ok := i18n.Lookup(i18n.FromContext(ctx), domainErr.Key); ok {
+			return message
+		}
+	}
+	return err.Error()
+}
+
+// joinFieldMessages 将逐字段的错误说明合并为一条消息
+func joinFieldMessages(fields []types.FieldError) string {
+	messages := make([]string, len(fields))
+	for i, f := range fields {
+		messages[i] = f.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// codeOf 返回错误的领域错误码，查询参数错误视为参数不合法
+func codeOf(err error) domain.Code {
+	var specErr *queryspec.Error
+	if errors.As(err, &specErr) {
+		return domain.CodeValidation
+	}
+	return domain.CodeOf(err)
+}
@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/innovationmech/simple-cli/internal/domain"
+	"github.com/innovationmech/simple-cli/internal/logging"
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/repository"
+	"go.uber.org/zap"
+)
+
+const (
+	// IdempotencyKeyHeader 客户端为每个逻辑请求生成的唯一键，重试时原样携带
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// idempotentReplayedHeader 标记响应为首次请求响应的重放
+	idempotentReplayedHeader = "Idempotent-Replayed"
+	// maxIdempotencyKeyLength 幂等键的最大长度
+	maxIdempotencyKeyLength = 255
+)
+
+var (
+	// ErrIdempotencyKeyInvalid 幂等键过长
+	ErrIdempotencyKeyInvalid = domain.Keyed(domain.CodeValidation, "idempotency.key_invalid", "Idempotency-Key must be at most 255 characters")
+	// ErrIdempotencyKeyReused 幂等键已用于不同的请求
+	ErrIdempotencyKeyReused = domain.Keyed(domain.CodeUnprocessable, "idempotency.key_reused", "Idempotency-Key was already used for a different request")
+	// ErrIdempotencyBodyTooLarge 携带幂等键的请求体超过上限
+	ErrIdempotencyBodyTooLarge = domain.Keyed(domain.CodePayloadTooLarge, "idempotency.body_too_large", "request body with Idempotency-Key is too large")
+	// ErrIdempotencyKeyInFlight 使用同一幂等键的首次请求尚未完成
+	ErrIdempotencyKeyInFlight = domain.Keyed(domain.CodeConflict, "idempotency.in_flight", "a request with this Idempotency-Key is still being processed")
+)
+
+// Idempotency 为携带 Idempotency-Key 请求头的 POST、PUT、PATCH、DELETE 请求提供幂等保证
+// 首次请求的响应连同用户与请求指纹保存 ttl 时长，重试时原样返回并带有 Idempotent-Replayed 响应头；
+// 同一幂等键用于不同请求返回 422，首次请求尚未完成时返回 409。
+// 首次请求返回 5xx 时不保存响应，客户端可以用同一幂等键重试；
+// 首次请求处理超过 lease 仍未完成（如进程崩溃）时，相同请求可以重新占用幂等键。
+// 请求体需完整读入以计算指纹，超过 maxBodyBytes 时返回 413。
+// 需挂载在 Errors 之外，才能保存由 Errors 写出的错误响应
+func Idempotency(repo repository.IdempotencyRepository, ttl, lease time.Duration, maxBodyBytes int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" || !mutating(c.Request.Method) {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			writeError(c, ErrIdempotencyKeyInvalid)
+			c.Abort()
+			return
+		}
+
+		var body []byte
+		if c.Request.Body != nil {
+			var err error
+			if body, err = io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxBodyBytes)); err != nil {
+				var maxBytesErr *http.MaxBytesError
+				if errors.As(err, &maxBytesErr) {
+					err = ErrIdempotencyBodyTooLarge
+				} else {
+					err = domain.Wrap(domain.CodeValidation, err)
+				}
+				writeError(c, err)
+				c.Abort()
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		userID := idempotencyUser(c, body)
+		hash := requestHash(c.Request, body)
+		claim := &model.IdempotencyRecord{
+			UserID:      userID,
+			Key:         key,
+			RequestHash: hash,
+			ExpiresAt:   time.Now().Add(ttl),
+		}
+		existing, err := repo.Claim(c.Request.Context(), claim, lease)
+		if err != nil {
+			writeError(c, err)
+			c.Abort()
+			return
+		}
+		if existing != nil {
+			switch {
+			case existing.RequestHash != hash:
+				writeError(c, ErrIdempotencyKeyReused)
+			case existing.InFlight():
+				writeError(c, ErrIdempotencyKeyInFlight)
+			default:
+				c.Header(idempotentReplayedHeader, "true")
+				c.Data(existing.StatusCode, existing.ContentType, existing.Body)
+			}
+			c.Abort()
+			return
+		}
+
+		// 客户端断开后仍需保存或释放幂等键
+		ctx := context.WithoutCancel(c.Request.Context())
+		completed := false
+		defer func() {
+			if !completed {
+				// 处理中发生 panic 或返回 5xx，释放幂等键以便重试
+				if err := repo.Release(ctx, claim); err != nil {
+					logging.FromContext(ctx).Error("release idempotency key", zap.String("key", key), zap.Error(err))
+				}
+			}
+		}()
+
+		recorder := &bodyRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError {
+			return
+		}
+		if err := repo.Complete(ctx, claim, status, recorder.Header().Get("Content-Type"), recorder.body.Bytes()); err != nil {
+			logging.FromContext(ctx).Error("save idempotent response", zap.String("key", key), zap.Error(err))
+			return
+		}
+		completed = true
+	}
+}
+
+// mutating 是否为会修改数据的请求方法
+func mutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// idempotencyUser 幂等键所属的用户，取自 JSON 请求体或查询参数中的 user_id，没有时为空
+func idempotencyUser(c *gin.Context, body []byte) string {
+	var payload struct {
+		UserID string `json:"user_id"`
+	}
+	if json.Unmarshal(body, &payload) == nil && payload.UserID != "" {
+		return payload.UserID
+	}
+	return c.Query("user_id")
+}
+
+// requestHash 请求指纹：方法、路径、查询参数与请求体的 SHA-256
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.RequestURI()+"\n")
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
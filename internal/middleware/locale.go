@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/innovationmech/simple-cli/internal/i18n"
+)
+
+// Locale 按 lang 查询参数或 Accept-Language 请求头选择响应语言，写入请求 context
+// 并通过 Content-Language 响应头告知调用方
+func Locale() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locale := i18n.Resolve(c.Query("lang"), c.GetHeader("Accept-Language"))
+		c.Request = c.Request.WithContext(i18n.WithLocale(c.Request.Context(), locale))
+		c.Header("Content-Language", string(locale))
+		c.Next()
+	}
+}
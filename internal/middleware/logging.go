@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/innovationmech/simple-cli/internal/domain"
+	"github.com/innovationmech/simple-cli/internal/logging"
+	"go.uber.org/zap"
+)
+
+const (
+	// RequestIDHeader 请求 ID 请求头与响应头
+	RequestIDHeader = "X-Request-ID"
+	// maxRequestIDLength 接受调用方传入的请求 ID 的最大长度
+	maxRequestIDLength = 128
+)
+
+// RequestID 沿用调用方传入的 X-Request-ID，没有或不合法时生成新的 ID
+// 请求 ID 写入请求 context 与响应头，经 context 传递到服务与数据访问层的日志中
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID(id) {
+			id = uuid.New().String()
+		}
+		c.Request = c.Request.WithContext(logging.WithRequestID(c.Request.Context(), id))
+		c.Header(RequestIDHeader, id)
+		c.Next()
+	}
+}
+
+// validRequestID 只接受长度合理的可见 ASCII 字符，避免日志注入
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// AccessLog 请求完成后记录一条结构化访问日志，5xx 记为 error，4xx 记为 warn
+func AccessLog() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		fields := []zap.Field{
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("route", c.FullPath()),
+			zap.String("query", c.Request.URL.RawQuery),
+			zap.Int("status", status),
+			zap.Duration("latency", time.Since(start)),
+			zap.Int("bytes", c.Writer.Size()),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_agent", c.Request.UserAgent()),
+		}
+		logger := logging.FromContext(c.Request.Context())
+		switch {
+		case status >= http.StatusInternalServerError:
+			logger.Error("request", fields...)
+		case status >= http.StatusBadRequest:
+			logger.Warn("request", fields...)
+		default:
+			logger.Info("request", fields...)
+		}
+	}
+}
+
+// Recovery 捕获处理器中的 panic，记录堆栈并返回 500
+func Recovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if r := recover(); r != nil {
+				if err, ok := r.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+					// 连接已被中断，无需响应
+					panic(r)
+				}
+				logging.FromContext(c.Request.Context()).Error("panic recovered",
+					zap.Any("panic", r),
+					zap.ByteString("stack", debug.Stack()),
+				)
+				if !c.Writer.Written() {
+					writeError(c, domain.New(domain.CodeInternal, "internal server error"))
+				}
+				c.Abort()
+			}
+		}()
+		c.Next()
+	}
+}
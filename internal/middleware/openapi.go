@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/gin-gonic/gin"
+	"github.com/innovationmech/simple-cli/internal/domain"
+	"github.com/innovationmech/simple-cli/internal/logging"
+	"github.com/innovationmech/simple-cli/internal/openapi"
+	"go.uber.org/zap"
+)
+
+// ErrUnsupportedMediaType 请求体的内容类型不是文档声明的类型
+var ErrUnsupportedMediaType = domain.Keyed(domain.CodeUnsupportedMediaType, "request.unsupported_media_type", "unsupported media type, expected application/json")
+
+// ValidationMode 按 OpenAPI 文档校验请求的方式
+type ValidationMode string
+
+const (
+	// ValidationOff 不校验
+	ValidationOff ValidationMode = "off"
+	// ValidationLog 只记录不符之处，请求照常处理
+	ValidationLog ValidationMode = "log"
+	// ValidationEnforce 拒绝不符合文档的请求，返回 400
+	ValidationEnforce ValidationMode = "enforce"
+)
+
+// ParseValidationMode 解析配置中的校验方式
+func ParseValidationMode(s string) (ValidationMode, error) {
+	switch mode := ValidationMode(s); mode {
+	case ValidationOff, ValidationLog, ValidationEnforce:
+		return mode, nil
+	}
+	return "", fmt.Errorf("unknown openapi validation mode %q, want off, log or enforce", s)
+}
+
+// OpenAPI 在处理器之前按文档校验查询参数与 JSON 请求体，拒绝未声明的字段、类型错误与非法的枚举值
+// responses 为 true 时还校验 JSON 响应体，不符之处只记录日志。文档中没有的路由不做校验
+func OpenAPI(validator *openapi.Validator, mode ValidationMode, responses bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		op := validator.Operation(c.Request.Method, c.FullPath())
+		if op == nil {
+			c.Next()
+			return
+		}
+
+		if mode != ValidationOff {
+			var body []byte
+			if op.RequestBody != nil && c.Request.Body != nil {
+				if _, ok := op.RequestBody.Content["application/json"]; ok {
+					var err error
+					if body, err = io.ReadAll(c.Request.Body); err != nil {
+						_ = c.Error(domain.Wrap(domain.CodeValidation, err))
+						c.Abort()
+						return
+					}
+					// 读取后放回，供处理器再次绑定
+					c.Request.Body = io.NopCloser(bytes.NewReader(body))
+				}
+			}
+
+			// 非空请求体必须使用文档声明的内容类型，否则可能以其他类型绕过校验后仍被按 JSON 绑定
+			if len(bytes.TrimSpace(body)) > 0 && !op.AcceptsContentType(c.GetHeader("Content-Type")) {
+				if mode == ValidationEnforce {
+					_ = c.Error(ErrUnsupportedMediaType)
+					c.Abort()
+					return
+				}
+				logging.FromContext(c.Request.Context()).Warn("request content type does not match the openapi document",
+					zap.String("method", c.Request.Method),
+					zap.String("path", c.Request.URL.Path),
+					zap.String("content_type", c.GetHeader("Content-Type")),
+				)
+			}
+
+			if violations := validator.ValidateRequest(op, c.Request, body); len(violations) > 0 {
+				err := &openapi.ValidationError{Violations: violations}
+				if mode == ValidationEnforce {
+					_ = c.Error(domain.Wrap(domain.CodeValidation, err))
+					c.Abort()
+					return
+				}
+				logging.FromContext(c.Request.Context()).Warn("request does not match the openapi document",
+					zap.String("method", c.Request.Method),
+					zap.String("path", c.Request.URL.Path),
+					zap.Error(err),
+				)
+			}
+		}
+
+		if !responses {
+			c.Next()
+			return
+		}
+		recorder := &bodyRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+		if recorder.body.Len() == 0 {
+			return
+		}
+		violations := validator.ValidateResponse(op, c.Writer.Status(), c.Writer.Header().Get("Content-Type"), recorder.body.Bytes())
+		if len(violations) > 0 {
+			logging.FromContext(c.Request.Context()).Warn("response does not match the openapi document",
+				zap.String("method", c.Request.Method),
+				zap.String("path", c.Request.URL.Path),
+				zap.Int("status", c.Writer.Status()),
+				zap.Error(&openapi.ValidationError{Violations: violations}),
+			)
+		}
+	}
+}
+
+// bodyRecorder 在写出响应的同时保留一份响应体
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *bodyRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
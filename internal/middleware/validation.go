@@ -0,0 +1,182 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/innovationmech/simple-cli/internal/i18n"
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/openapi"
+	"github.com/innovationmech/simple-cli/internal/types"
+)
+
+// ConfigureValidator 配置 gin 的参数校验：以 json/uri/form 标签中的名称报告字段，
+// 并注册各语言的校验错误消息。需在注册路由前调用一次
+func ConfigureValidator() error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return nil
+	}
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"json", "uri", "form"} {
+			name := strings.Split(field.Tag.Get(tag), ",")[0]
+			if name == "-" {
+				return "-"
+			}
+			if name != "" {
+				return name
+			}
+		}
+		return ""
+	})
+	if err := i18n.RegisterValidator(v); err != nil {
+		return err
+	}
+	return registerEnum(v)
+}
+
+// registerEnum 注册 enum 校验标签：字段须为 model.Enum 的合法取值，空值交由 required 校验
+func registerEnum(v *validator.Validate) error {
+	err := v.RegisterValidation("enum", func(fl validator.FieldLevel) bool {
+		e, ok := fl.Field().Interface().(model.Enum)
+		return !ok || fl.Field().IsZero() || e.Valid()
+	})
+	if err != nil {
+		return err
+	}
+	for _, locale := range []i18n.Locale{i18n.English, i18n.Chinese} {
+		err := v.RegisterTranslation("enum", i18n.Translator(locale),
+			func(ut.Translator) error { return nil },
+			func(_ ut.Translator, fe validator.FieldError) string {
+				message, _ := i18n.Lookup(locale, "schema.enum")
+				return fmt.Sprintf(message, fe.Field(), enumValues(fe.Value()))
+			})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// enumValues 列出枚举的全部取值
+func enumValues(v interface{}) string {
+	if e, ok := v.(model.Enum); ok {
+		return strings.Join(e.Values(), ", ")
+	}
+	return ""
+}
+
+// fieldErrors 将参数绑定与校验错误拆分为逐字段的错误，消息按请求语言翻译
+// 错误链中没有此类错误时返回 nil
+func fieldErrors(ctx context.Context, err error) []types.FieldError {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		trans := i18n.Translator(i18n.FromContext(ctx))
+		fields := make([]types.FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			field := fieldPath(fe)
+			fields = append(fields, types.FieldError{
+				Field: field,
+				Rule:  fe.Tag(),
+				// 翻译消息只含字段名，替换为完整路径以区分数组中的元素
+				Message: strings.Replace(fe.Translate(trans), fe.Field(), field, 1),
+			})
+		}
+		return fields
+	}
+
+	var schemaErr *openapi.ValidationError
+	if errors.As(err, &schemaErr) {
+		fields := make([]types.FieldError, 0, len(schemaErr.Violations))
+		for _, v := range schemaErr.Violations {
+			fields = append(fields, types.FieldError{
+				Field:   v.Field,
+				Rule:    v.Rule,
+				Message: violationMessage(ctx, v),
+			})
+		}
+		return fields
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Type != nil {
+		// 枚举在反序列化时拒绝未知取值；部分 Go 版本不为自定义反序列化的错误补充字段路径，此时以取值代替字段名
+		if values := enumValues(reflect.Zero(typeErr.Type).Interface()); values != "" {
+			name := typeErr.Field
+			if name == "" {
+				name = strings.TrimPrefix(typeErr.Value, "string ")
+			}
+			return []types.FieldError{{
+				Field:   typeErr.Field,
+				Rule:    "enum",
+				Message: i18n.T(ctx, "schema.enum", name, values),
+			}}
+		}
+	}
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return []types.FieldError{{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Message: i18n.T(ctx, "field.type", typeErr.Field, i18n.T(ctx, "type."+jsonType(typeErr.Type))),
+		}}
+	}
+	return nil
+}
+
+// violationMessage 按请求语言生成与文档不符之处的说明
+func violationMessage(ctx context.Context, v openapi.Violation) string {
+	switch v.Rule {
+	case "type":
+		return i18n.T(ctx, "field.type", v.Field, i18n.T(ctx, "type."+v.Param))
+	case "json":
+		return i18n.T(ctx, "schema.json")
+	case "required", "additionalProperties":
+		return i18n.T(ctx, "schema."+v.Rule, v.Field)
+	}
+	if _, ok := i18n.Lookup(i18n.English, "schema."+v.Rule); !ok {
+		return v.Message()
+	}
+	return i18n.T(ctx, "schema."+v.Rule, v.Field, v.Param)
+}
+
+// fieldPath 去掉命名空间开头的结构体名，如 CreateOrderRequest.items[0].quantity → items[0].quantity
+func fieldPath(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if i := strings.Index(ns, "."); i >= 0 {
+		return ns[i+1:]
+	}
+	return fe.Field()
+}
+
+// jsonType 返回 Go 类型对应的 JSON 类型名
+func jsonType(t reflect.Type) string {
+	if t == nil {
+		return "value"
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	}
+	return "value"
+}
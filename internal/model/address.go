@@ -0,0 +1,111 @@
+package model
+
+import "time"
+
+// Address 用户收货地址（地址簿）
+type Address struct {
+	ID         string    `json:"id" gorm:"primaryKey"`
+	UserID     string    `json:"user_id" gorm:"index"`
+	Recipient  string    `json:"recipient"`
+	Phone      string    `json:"phone"`
+	Province   string    `json:"province"`
+	City       string    `json:"city"`
+	District   string    `json:"district"`
+	Street     string    `json:"street"`
+	PostalCode string    `json:"postal_code"`
+	IsDefault  bool      `json:"is_default"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Snapshot 生成地址快照
+// 订单保存的是下单时刻的地址副本，之后修改地址簿不会影响历史订单
+func (a *Address) Snapshot() ShippingAddress {
+	return ShippingAddress{
+		Recipient:  a.Recipient,
+		Phone:      a.Phone,
+		Province:   a.Province,
+		City:       a.City,
+		District:   a.District,
+		Street:     a.Street,
+		PostalCode: a.PostalCode,
+	}
+}
+
+// ShippingAddress 订单收货地址快照（嵌入订单表，不可变）
+type ShippingAddress struct {
+	Recipient  string `json:"recipient"`
+	Phone      string `json:"phone"`
+	Province   string `json:"province"`
+	City       string `json:"city"`
+	District   string `json:"district"`
+	Street     string `json:"street"`
+	PostalCode string `json:"postal_code"`
+}
+
+// AddressURI 地址相关路由的路径参数
+type AddressURI struct {
+	UserID    string `uri:"id" binding:"required"`
+	AddressID string `uri:"address_id"`
+}
+
+// CreateAddressRequest 创建收货地址请求
+type CreateAddressRequest struct {
+	Recipient  string `json:"recipient" binding:"required"`
+	Phone      string `json:"phone" binding:"required"`
+	Province   string `json:"province" binding:"required"`
+	City       string `json:"city" binding:"required"`
+	District   string `json:"district"`
+	Street     string `json:"street" binding:"required"`
+	PostalCode string `json:"postal_code"`
+	IsDefault  bool   `json:"is_default"`
+}
+
+// CreateAddressResponse 创建收货地址响应
+type CreateAddressResponse struct {
+	ID        string `json:"id"`
+	IsDefault bool   `json:"is_default"`
+}
+
+// UpdateAddressRequest 更新收货地址请求
+// 仅更新非空字段；IsDefault 为空表示不修改默认状态
+type UpdateAddressRequest struct {
+	Recipient  string `json:"recipient"`
+	Phone      string `json:"phone"`
+	Province   string `json:"province"`
+	City       string `json:"city"`
+	District   string `json:"district"`
+	Street     string `json:"street"`
+	PostalCode string `json:"postal_code"`
+	IsDefault  *bool  `json:"is_default"`
+}
+
+// UpdateAddressResponse 更新收货地址响应
+type UpdateAddressResponse struct {
+	ID string `json:"id"`
+}
+
+// GetAddressResponse 获取收货地址响应
+type GetAddressResponse struct {
+	ID         string    `json:"id"`
+	UserID     string    `json:"user_id"`
+	Recipient  string    `json:"recipient"`
+	Phone      string    `json:"phone"`
+	Province   string    `json:"province"`
+	City       string    `json:"city"`
+	District   string    `json:"district"`
+	Street     string    `json:"street"`
+	PostalCode string    `json:"postal_code"`
+	IsDefault  bool      `json:"is_default"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// DeleteAddressResponse 删除收货地址响应
+type DeleteAddressResponse struct {
+	ID string `json:"id"`
+}
+
+// ListAddressesResponse 收货地址列表响应
+type ListAddressesResponse struct {
+	Addresses []GetAddressResponse `json:"addresses"`
+}
@@ -0,0 +1,95 @@
+package model
+
+import "time"
+
+// Cart 购物车数据模型，每个用户一个
+type Cart struct {
+	ID        string     `json:"id" gorm:"primaryKey"`
+	UserID    string     `json:"user_id" gorm:"uniqueIndex"`
+	Items     []CartItem `json:"items" gorm:"foreignKey:CartID"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"` // 最近一次修改时间，用于判断闲置过期
+}
+
+// CartItem 购物车商品行
+// 只保存商品、规格与数量，价格与库存在读取时实时校验；无规格商品的 SKUID 为空
+type CartItem struct {
+	ID        uint      `json:"-" gorm:"primaryKey"`
+	CartID    string    `json:"-" gorm:"uniqueIndex:idx_cart_product_sku"`
+	ProductID string    `json:"product_id" gorm:"uniqueIndex:idx_cart_product_sku"`
+	SKUID     string    `json:"sku_id" gorm:"column:sku_id;uniqueIndex:idx_cart_product_sku"`
+	Quantity  int       `json:"quantity"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CartLine 购物车商品行的实时视图
+type CartLine struct {
+	ProductID   string            `json:"product_id"`
+	SKUID       string            `json:"sku_id,omitempty"`
+	SKUCode     string            `json:"sku_code,omitempty"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+	ProductName string            `json:"product_name"`
+	UnitPrice   float64           `json:"unit_price"`
+	Quantity    int               `json:"quantity"`
+	Amount      float64           `json:"amount"`
+	Stock       int               `json:"stock"`
+	Available   bool              `json:"available"`
+	Issue       string            `json:"issue,omitempty"` // 不可购买的原因
+}
+
+// CartDetail 购物车实时视图（按当前价格与库存计算）
+type CartDetail struct {
+	UserID       string     `json:"user_id"`
+	Lines        []CartLine `json:"lines"`
+	TotalAmount  float64    `json:"total_amount"`
+	Checkoutable bool       `json:"checkoutable"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+}
+
+// GetCartRequest 获取/清空购物车请求
+type GetCartRequest struct {
+	UserID string `form:"user_id" binding:"required"`
+}
+
+// AddCartItemRequest 添加购物车商品请求
+type AddCartItemRequest struct {
+	UserID    string `json:"user_id" binding:"required"`
+	ProductID string `json:"product_id" binding:"required"`
+	SKUID     string `json:"sku_id"`
+	Quantity  int    `json:"quantity" binding:"required,gt=0"`
+}
+
+// CartItemURI 购物车商品行路径参数
+type CartItemURI struct {
+	ProductID string `uri:"product_id" binding:"required"`
+}
+
+// UpdateCartItemRequest 修改购物车商品数量请求，数量为 0 时移除该行
+type UpdateCartItemRequest struct {
+	UserID   string `json:"user_id" binding:"required"`
+	SKUID    string `json:"sku_id"`
+	Quantity int    `json:"quantity" binding:"gte=0"`
+}
+
+// RemoveCartItemRequest 移除购物车商品行请求
+type RemoveCartItemRequest struct {
+	UserID string `form:"user_id" binding:"required"`
+	SKUID  string `form:"sku_id"`
+}
+
+// CheckoutRequest 购物车结算请求
+type CheckoutRequest struct {
+	UserID      string   `json:"user_id" binding:"required"`
+	AddressID   string   `json:"address_id"`
+	CouponCodes []string `json:"coupon_codes"`
+}
+
+// CheckoutResponse 购物车结算响应
+type CheckoutResponse struct {
+	OrderID        string  `json:"order_id"`
+	DiscountAmount float64 `json:"discount_amount"`
+	ShippingAmount float64 `json:"shipping_amount"`
+	TaxAmount      float64 `json:"tax_amount"`
+	TotalAmount    float64 `json:"total_amount"`
+}
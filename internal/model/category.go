@@ -0,0 +1,82 @@
+package model
+
+import "time"
+
+// Category 商品分类数据模型
+// ParentID 为空表示顶级分类，同级分类按 SortOrder 升序排列
+type Category struct {
+	ID        string    `json:"id" gorm:"primaryKey"`
+	ParentID  string    `json:"parent_id" gorm:"index"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug" gorm:"uniqueIndex"`
+	SortOrder int       `json:"sort_order"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ProductCategory 商品与分类的多对多关联
+type ProductCategory struct {
+	ProductID  string `gorm:"primaryKey"`
+	CategoryID string `gorm:"primaryKey;index"`
+}
+
+// CategoryNode 分类树节点
+type CategoryNode struct {
+	ID        string          `json:"id"`
+	ParentID  string          `json:"parent_id"`
+	Name      string          `json:"name"`
+	Slug      string          `json:"slug"`
+	SortOrder int             `json:"sort_order"`
+	Children  []*CategoryNode `json:"children"`
+}
+
+// CategorySummary 商品详情中展示的分类摘要
+type CategorySummary struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// CreateCategoryRequest 创建分类请求
+type CreateCategoryRequest struct {
+	Name      string `json:"name" binding:"required"`
+	Slug      string `json:"slug"`
+	ParentID  string `json:"parent_id"`
+	SortOrder int    `json:"sort_order"`
+}
+
+// CreateCategoryResponse 创建分类响应
+type CreateCategoryResponse struct {
+	ID   string `json:"id"`
+	Slug string `json:"slug"`
+}
+
+// GetCategoryRequest 获取分类请求
+type GetCategoryRequest struct {
+	ID string `uri:"id" binding:"required"`
+}
+
+// UpdateCategoryRequest 更新分类请求
+// ParentID 为指针类型，传空字符串表示移动为顶级分类
+type UpdateCategoryRequest struct {
+	Name      string  `json:"name"`
+	Slug      string  `json:"slug"`
+	ParentID  *string `json:"parent_id"`
+	SortOrder *int    `json:"sort_order"`
+}
+
+// UpdateCategoryResponse 更新分类响应
+type UpdateCategoryResponse struct {
+	ID string `json:"id"`
+}
+
+// DeleteCategoryRequest 删除分类请求
+// Cascade 为 true 时连同子分类一并删除并解除商品关联，否则分类下仍有商品或子分类时拒绝删除
+type DeleteCategoryRequest struct {
+	Cascade bool `form:"cascade"`
+}
+
+// ListCategoriesResponse 分类树响应
+type ListCategoriesResponse struct {
+	Categories []*CategoryNode `json:"categories"`
+}
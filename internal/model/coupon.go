@@ -0,0 +1,143 @@
+package model
+
+import (
+	"time"
+
+	"github.com/innovationmech/simple-cli/internal/queryspec"
+)
+
+// CouponType 优惠券类型
+type CouponType string
+
+const (
+	CouponTypePercentage CouponType = "percentage" // 按百分比折扣
+	CouponTypeFixed      CouponType = "fixed"      // 固定金额立减
+)
+
+// CouponScopeType 优惠券适用范围类型
+type CouponScopeType string
+
+const (
+	CouponScopeProduct  CouponScopeType = "product"  // 指定商品
+	CouponScopeCategory CouponScopeType = "category" // 指定分类及其子分类下的商品
+)
+
+// Coupon 优惠券数据模型
+type Coupon struct {
+	ID           string        `json:"id" gorm:"primaryKey"`
+	Code         string        `json:"code" gorm:"uniqueIndex"`
+	Type         CouponType    `json:"type"`
+	Value        float64       `json:"value"`        // 百分比（0-100）或立减金额
+	MinSpend     float64       `json:"min_spend"`    // 适用商品的最低消费金额
+	MaxDiscount  float64       `json:"max_discount"` // 百分比券的优惠上限，0 表示不限
+	UsageLimit   int           `json:"usage_limit"`  // 全局可用次数，0 表示不限
+	PerUserLimit int           `json:"per_user_limit"`
+	UsedCount    int           `json:"used_count"`
+	StartsAt     *time.Time    `json:"starts_at"`
+	EndsAt       *time.Time    `json:"ends_at"`
+	Active       bool          `json:"active"`
+	Scopes       []CouponScope `json:"scopes" gorm:"foreignKey:CouponID"` // 为空表示全场通用
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+}
+
+// CouponScope 优惠券适用范围
+type CouponScope struct {
+	ID       uint            `json:"-" gorm:"primaryKey"`
+	CouponID string          `json:"-" gorm:"index"`
+	Type     CouponScopeType `json:"type"`
+	TargetID string          `json:"target_id"`
+}
+
+// CouponUsage 用户优惠券使用次数
+type CouponUsage struct {
+	CouponID string `gorm:"primaryKey"`
+	UserID   string `gorm:"primaryKey"`
+	Count    int
+}
+
+// OrderDiscount 订单优惠明细
+type OrderDiscount struct {
+	ID       uint    `json:"-" gorm:"primaryKey"`
+	OrderID  string  `json:"-" gorm:"index"`
+	CouponID string  `json:"coupon_id"`
+	Code     string  `json:"code"`
+	Amount   float64 `json:"amount"`
+	// ProductIDs 该券适用的商品，为空表示作用于全部商品；仅用于计价时分摊优惠
+	ProductIDs []string `json:"-" gorm:"-"`
+}
+
+// CouponScopeRequest 优惠券适用范围请求
+type CouponScopeRequest struct {
+	Type     CouponScopeType `json:"type" binding:"required"`
+	TargetID string          `json:"target_id" binding:"required"`
+}
+
+// CreateCouponRequest 创建优惠券请求
+type CreateCouponRequest struct {
+	Code         string               `json:"code" binding:"required"`
+	Type         CouponType           `json:"type" binding:"required"`
+	Value        float64              `json:"value" binding:"required,gt=0"`
+	MinSpend     float64              `json:"min_spend" binding:"gte=0"`
+	MaxDiscount  float64              `json:"max_discount" binding:"gte=0"`
+	UsageLimit   int                  `json:"usage_limit" binding:"gte=0"`
+	PerUserLimit int                  `json:"per_user_limit" binding:"gte=0"`
+	StartsAt     *time.Time           `json:"starts_at"`
+	EndsAt       *time.Time           `json:"ends_at"`
+	Scopes       []CouponScopeRequest `json:"scopes" binding:"omitempty,dive"`
+}
+
+// CreateCouponResponse 创建优惠券响应
+type CreateCouponResponse struct {
+	ID   string `json:"id"`
+	Code string `json:"code"`
+}
+
+// GetCouponRequest 获取优惠券请求
+type GetCouponRequest struct {
+	ID string `uri:"id" binding:"required"`
+}
+
+// UpdateCouponRequest 更新优惠券请求
+// 仅支持调整启用状态、使用限制与有效期，折扣规则创建后不可修改
+type UpdateCouponRequest struct {
+	Active       *bool      `json:"active"`
+	UsageLimit   *int       `json:"usage_limit" binding:"omitempty,gte=0"`
+	PerUserLimit *int       `json:"per_user_limit" binding:"omitempty,gte=0"`
+	StartsAt     *time.Time `json:"starts_at"`
+	EndsAt       *time.Time `json:"ends_at"`
+}
+
+// UpdateCouponResponse 更新优惠券响应
+type UpdateCouponResponse struct {
+	ID string `json:"id"`
+}
+
+// ListCouponsRequest 优惠券列表请求
+type ListCouponsRequest struct {
+	Page     int `form:"page" binding:"gte=0"`
+	PageSize int `form:"page_size" binding:"gte=0,lte=100"`
+}
+
+// ListCouponsResponse 优惠券列表响应
+type ListCouponsResponse struct {
+	Coupons []*Coupon `json:"coupons"`
+	queryspec.PageInfo
+}
+
+// CouponQuerySchema 优惠券列表可排序、过滤与选择的字段
+var CouponQuerySchema = &queryspec.Schema{
+	Fields: []queryspec.Field{
+		{Name: "code", Column: "coupons.code", Type: queryspec.String, Sortable: true, Filterable: true},
+		{Name: "type", Column: "coupons.type", Type: queryspec.String, Filterable: true},
+		{Name: "value", Column: "coupons.value", Type: queryspec.Number, Sortable: true, Filterable: true},
+		{Name: "active", Column: "coupons.active", Type: queryspec.Bool, Filterable: true},
+		{Name: "used_count", Column: "coupons.used_count", Type: queryspec.Number, Sortable: true, Filterable: true},
+		{Name: "starts_at", Column: "coupons.starts_at", Type: queryspec.Time, Sortable: true, Filterable: true, Nullable: true},
+		{Name: "ends_at", Column: "coupons.ends_at", Type: queryspec.Time, Sortable: true, Filterable: true, Nullable: true},
+		{Name: "created_at", Column: "coupons.created_at", Type: queryspec.Time, Sortable: true, Filterable: true},
+	},
+	Selectable:  queryspec.JSONFields(Coupon{}),
+	DefaultSort: []queryspec.Sort{{Field: "created_at", Desc: true}},
+	TieBreaker:  "coupons.id",
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+)
+
+// Enum 取值有限的字符串类型
+// 字段可使用 enum 校验标签；JSON 反序列化时拒绝未知取值；OpenAPI 文档中列出全部取值
+type Enum interface {
+	// Values 全部合法取值
+	Values() []string
+	// Valid 当前值是否合法
+	Valid() bool
+}
+
+// Enums 通过 GET /meta/enums 公开的枚举，键为枚举名称
+var Enums = map[string]Enum{
+	"order_status":   OrderStatus(""),
+	"payment_status": PaymentStatus(""),
+	"payment_method": PaymentMethod(""),
+}
+
+// EnumOption 枚举取值及按请求语言翻译的名称
+type EnumOption struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+}
+
+// ListEnumsResponse 枚举列表响应，键为枚举名称
+type ListEnumsResponse map[string][]EnumOption
+
+// validEnum 判断取值是否合法
+func validEnum(value string, values []string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// unmarshalEnum 解析 JSON 字符串并校验取值，空字符串交由 required 校验
+// 未知取值返回 json.UnmarshalTypeError，解码器会补充字段路径
+func unmarshalEnum(data []byte, e Enum) (string, error) {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return "", err
+	}
+	if s != "" && !validEnum(s, e.Values()) {
+		return "", &json.UnmarshalTypeError{Value: "string " + strconv.Quote(s), Type: reflect.TypeOf(e)}
+	}
+	return s, nil
+}
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// IdempotencyRecord 幂等键记录，保存首次请求的指纹与响应，供客户端重试时原样返回
+// 幂等键按用户隔离；StatusCode 为 0 表示首次请求仍在处理中
+type IdempotencyRecord struct {
+	UserID string `gorm:"primaryKey"`
+	Key    string `gorm:"primaryKey;column:idempotency_key"`
+	// RequestHash 请求方法、路径与请求体的 SHA-256，同一幂等键只能用于相同的请求
+	RequestHash string `gorm:"not null"`
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	ExpiresAt   time.Time `gorm:"index"`
+	// ClaimedAt 首次请求占用幂等键的时间，处理中超过占用时长的记录可被相同请求重新占用
+	ClaimedAt time.Time
+	CreatedAt time.Time
+}
+
+// InFlight 首次请求是否仍在处理中
+func (r *IdempotencyRecord) InFlight() bool {
+	return r.StatusCode == 0
+}
@@ -0,0 +1,55 @@
+package model
+
+import "time"
+
+// ProductImage 商品图片数据模型
+// Key 与 ThumbnailKey 为对象存储中的路径，访问地址由存储配置决定
+type ProductImage struct {
+	ID           string    `json:"id" gorm:"primaryKey"`
+	ProductID    string    `json:"product_id" gorm:"index"`
+	Key          string    `json:"-"`
+	ThumbnailKey string    `json:"-"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	Width        int       `json:"width"`
+	Height       int       `json:"height"`
+	AltText      string    `json:"alt_text"`
+	SortOrder    int       `json:"sort_order"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// ImageResponse 商品图片响应
+type ImageResponse struct {
+	ID           string `json:"id"`
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	ContentType  string `json:"content_type"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+	AltText      string `json:"alt_text"`
+	SortOrder    int    `json:"sort_order"`
+}
+
+// ProductImageURI 商品图片路径参数
+type ProductImageURI struct {
+	ProductID string `uri:"id" binding:"required"`
+	ImageID   string `uri:"image_id"`
+}
+
+// UploadImageRequest 上传商品图片请求（multipart/form-data）
+// 文件字段名为 file；SortOrder 为空时排在已有图片之后
+type UploadImageRequest struct {
+	AltText   string `form:"alt_text"`
+	SortOrder *int   `form:"sort_order"`
+}
+
+// UpdateImageRequest 更新商品图片请求
+type UpdateImageRequest struct {
+	AltText   *string `json:"alt_text"`
+	SortOrder *int    `json:"sort_order"`
+}
+
+// ListImagesResponse 商品图片列表响应
+type ListImagesResponse struct {
+	Images []ImageResponse `json:"images"`
+}
@@ -0,0 +1,143 @@
+package model
+
+import (
+	"time"
+
+	"github.com/innovationmech/simple-cli/internal/queryspec"
+)
+
+// AdjustmentType 库存调整类型
+type AdjustmentType string
+
+const (
+	// AdjustmentReceive 入库（采购到货、退货入库）
+	AdjustmentReceive AdjustmentType = "receive"
+	// AdjustmentCorrection 盘点修正，数量可正可负
+	AdjustmentCorrection AdjustmentType = "correction"
+	// AdjustmentDamage 损耗出库
+	AdjustmentDamage AdjustmentType = "damage"
+	// AdjustmentReservation 占用库存（下单）
+	AdjustmentReservation AdjustmentType = "reservation"
+	// AdjustmentRelease 释放占用的库存（取消订单）
+	AdjustmentRelease AdjustmentType = "release"
+)
+
+// Inbound 该类型的调整是否增加库存，盘点修正的方向由数量决定
+func (t AdjustmentType) Inbound() bool {
+	return t == AdjustmentReceive || t == AdjustmentRelease
+}
+
+// InventoryAdjustment 库存调整记录
+// 每一次库存变化都追加一条记录，Delta 为变化量，StockAfter 为调整后的库存
+type InventoryAdjustment struct {
+	ID         uint           `json:"id" gorm:"primaryKey"`
+	ProductID  string         `json:"product_id" gorm:"index"`
+	SKUID      string         `json:"sku_id,omitempty" gorm:"column:sku_id;index"`
+	Type       AdjustmentType `json:"type"`
+	Delta      int            `json:"delta"`
+	StockAfter int            `json:"stock_after"`
+	Reason     string         `json:"reason"`
+	// Reference 关联的业务单据，如订单 ID、退货申请 ID
+	Reference string    `json:"reference,omitempty"`
+	Actor     string    `json:"actor"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// StockAlertStatus 低库存预警状态
+type StockAlertStatus string
+
+const (
+	StockAlertOpen     StockAlertStatus = "open"
+	StockAlertResolved StockAlertStatus = "resolved"
+)
+
+// StockAlert 低库存预警
+// 库存降至阈值及以下时产生，补货高于阈值后自动解除；同一商品规格同时最多一条未解除的预警
+type StockAlert struct {
+	ID         uint             `json:"id" gorm:"primaryKey"`
+	ProductID  string           `json:"product_id" gorm:"index"`
+	SKUID      string           `json:"sku_id,omitempty" gorm:"column:sku_id;index"`
+	Threshold  int              `json:"threshold"`
+	Stock      int              `json:"stock"`
+	Status     StockAlertStatus `json:"status" gorm:"index"`
+	ResolvedAt *time.Time       `json:"resolved_at"`
+	CreatedAt  time.Time        `json:"created_at"`
+	UpdatedAt  time.Time        `json:"updated_at"`
+}
+
+// InventoryURI 库存路径参数
+type InventoryURI struct {
+	ProductID string `uri:"id" binding:"required"`
+}
+
+// GetInventoryRequest 库存调整记录请求，SKUID 非空时只返回该规格的记录
+type GetInventoryRequest struct {
+	SKUID    string `form:"sku_id"`
+	Page     int    `form:"page" binding:"gte=0"`
+	PageSize int    `form:"page_size" binding:"gte=0,lte=100"`
+}
+
+// InventoryResponse 商品库存及调整记录
+type InventoryResponse struct {
+	ProductID         string                 `json:"product_id"`
+	Stock             int                    `json:"stock"`
+	LowStockThreshold *int                   `json:"low_stock_threshold"`
+	Adjustments       []*InventoryAdjustment `json:"adjustments"`
+	queryspec.PageInfo
+}
+
+// AdjustInventoryRequest 库存调整请求
+// Quantity 为调整数量：入库、释放为增加，损耗、占用为减少，盘点修正按正负号增减
+type AdjustInventoryRequest struct {
+	Type     AdjustmentType `json:"type" binding:"required,oneof=receive correction damage reservation release"`
+	SKUID    string         `json:"sku_id"`
+	Quantity int            `json:"quantity" binding:"required"`
+	Reason   string         `json:"reason"`
+	Actor    string         `json:"actor"`
+}
+
+// SetThresholdRequest 设置低库存阈值请求，LowStockThreshold 为 null 表示关闭预警
+type SetThresholdRequest struct {
+	LowStockThreshold *int `json:"low_stock_threshold" binding:"omitempty,gte=0"`
+}
+
+// ListStockAlertsRequest 低库存预警列表请求，Status 为空时返回全部
+type ListStockAlertsRequest struct {
+	Status   StockAlertStatus `form:"status" binding:"omitempty,oneof=open resolved"`
+	Page     int              `form:"page" binding:"gte=0"`
+	PageSize int              `form:"page_size" binding:"gte=0,lte=100"`
+}
+
+// ListStockAlertsResponse 低库存预警列表响应
+type ListStockAlertsResponse struct {
+	Alerts []*StockAlert `json:"alerts"`
+	queryspec.PageInfo
+}
+
+// InventoryQuerySchema 库存调整记录可排序、过滤与选择的字段
+var InventoryQuerySchema = &queryspec.Schema{
+	Fields: []queryspec.Field{
+		{Name: "type", Column: "inventory_adjustments.type", Type: queryspec.String, Sortable: true, Filterable: true},
+		{Name: "delta", Column: "inventory_adjustments.delta", Type: queryspec.Number, Sortable: true, Filterable: true},
+		{Name: "reference", Column: "inventory_adjustments.reference", Type: queryspec.String, Filterable: true},
+		{Name: "actor", Column: "inventory_adjustments.actor", Type: queryspec.String, Filterable: true},
+		{Name: "created_at", Column: "inventory_adjustments.created_at", Type: queryspec.Time, Sortable: true, Filterable: true},
+	},
+	Selectable:     queryspec.JSONFields(InventoryAdjustment{}),
+	DefaultSort:    []queryspec.Sort{{Field: "created_at", Desc: true}},
+	TieBreaker:     "inventory_adjustments.id",
+	TieBreakerType: queryspec.Number,
+}
+
+// StockAlertQuerySchema 低库存预警可排序、过滤与选择的字段
+var StockAlertQuerySchema = &queryspec.Schema{
+	Fields: []queryspec.Field{
+		{Name: "product_id", Column: "stock_alerts.product_id", Type: queryspec.String, Filterable: true},
+		{Name: "stock", Column: "stock_alerts.stock", Type: queryspec.Number, Sortable: true, Filterable: true},
+		{Name: "created_at", Column: "stock_alerts.created_at", Type: queryspec.Time, Sortable: true, Filterable: true},
+	},
+	Selectable:     queryspec.JSONFields(StockAlert{}),
+	DefaultSort:    []queryspec.Sort{{Field: "created_at", Desc: true}},
+	TieBreaker:     "stock_alerts.id",
+	TieBreakerType: queryspec.Number,
+}
@@ -1,6 +1,10 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"github.com/innovationmech/simple-cli/internal/queryspec"
+)
 
 // OrderStatus 订单状态
 type OrderStatus string
@@ -11,31 +15,149 @@ const (
 	OrderStatusShipped   OrderStatus = "shipped"
 	OrderStatusCompleted OrderStatus = "completed"
 	OrderStatusCancelled OrderStatus = "cancelled"
+	// OrderStatusReturned 已完成订单的商品全部退货并退款
+	OrderStatusReturned OrderStatus = "returned"
 )
 
+// Values 订单的全部状态
+func (OrderStatus) Values() []string {
+	return []string{
+		string(OrderStatusPending),
+		string(OrderStatusPaid),
+		string(OrderStatusShipped),
+		string(OrderStatusCompleted),
+		string(OrderStatusCancelled),
+		string(OrderStatusReturned),
+	}
+}
+
+// Valid 是否为已定义的订单状态
+func (s OrderStatus) Valid() bool {
+	return validEnum(string(s), s.Values())
+}
+
+// UnmarshalJSON 拒绝未定义的订单状态
+func (s *OrderStatus) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalEnum(data, *s)
+	if err != nil {
+		return err
+	}
+	*s = OrderStatus(v)
+	return nil
+}
+
 // Order 订单数据模型
 type Order struct {
-	ID          string      `json:"id" gorm:"primaryKey"`
-	UserID      string      `json:"user_id" gorm:"index"`
-	ProductID   string      `json:"product_id" gorm:"index"`
-	Quantity    int         `json:"quantity"`
-	TotalAmount float64     `json:"total_amount"`
-	Status      OrderStatus `json:"status"`
-	CreatedAt   time.Time   `json:"created_at"`
-	UpdatedAt   time.Time   `json:"updated_at"`
+	ID     string      `json:"id" gorm:"primaryKey"`
+	UserID string      `json:"user_id" gorm:"index"`
+	Items  []OrderItem `json:"items" gorm:"foreignKey:OrderID"`
+	// 价格构成：TotalAmount = SubtotalAmount - DiscountAmount + ShippingAmount + TaxAmount
+	SubtotalAmount float64         `json:"subtotal_amount"`
+	DiscountAmount float64         `json:"discount_amount"`
+	Discounts      []OrderDiscount `json:"discounts" gorm:"foreignKey:OrderID"`
+	ShippingAmount float64         `json:"shipping_amount"`
+	TaxAmount      float64         `json:"tax_amount"`
+	TotalAmount    float64         `json:"total_amount"`
+	Status         OrderStatus     `json:"status"`
+	// CouponCodes 下单时提交的优惠码，仅用于计算优惠，不持久化
+	CouponCodes []string `json:"-" gorm:"-"`
+	// AddressID 下单时选择的地址簿条目，ShippingAddress 为其不可变快照
+	AddressID       string          `json:"address_id"`
+	ShippingAddress ShippingAddress `json:"shipping_address" gorm:"embedded;embeddedPrefix:shipping_"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	// Version 版本号，每次更新加一，用于乐观并发控制
+	Version int `json:"version" gorm:"not null;default:1"`
 }
 
-// CreateOrderRequest 创建订单请求
-type CreateOrderRequest struct {
-	UserID    string `json:"user_id" binding:"required"`
+// TotalQuantity 订单商品总件数
+func (o *Order) TotalQuantity() int {
+	total := 0
+	for _, item := range o.Items {
+		total += item.Quantity
+	}
+	return total
+}
+
+// OrderItem 订单商品明细
+// 商品名称、规格、单价与税类为下单时的快照；DiscountAmount 为分摊到该行的优惠
+type OrderItem struct {
+	ID             uint              `json:"-" gorm:"primaryKey"`
+	OrderID        string            `json:"-" gorm:"index"`
+	ProductID      string            `json:"product_id" gorm:"index"`
+	SKUID          string            `json:"sku_id,omitempty" gorm:"column:sku_id;index"`
+	SKUCode        string            `json:"sku_code,omitempty" gorm:"column:sku_code"`
+	Attributes     map[string]string `json:"attributes,omitempty" gorm:"serializer:json"`
+	ProductName    string            `json:"product_name"`
+	UnitPrice      float64           `json:"unit_price"`
+	Quantity       int               `json:"quantity"`
+	Amount         float64           `json:"amount"`
+	DiscountAmount float64           `json:"discount_amount"`
+	TaxClass       string            `json:"tax_class"`
+	TaxRate        float64           `json:"tax_rate"`
+	TaxAmount      float64           `json:"tax_amount"`
+}
+
+// RefundableAmount 退回指定数量商品时应退的金额（扣除分摊优惠并含税）
+func (i *OrderItem) RefundableAmount(quantity int) float64 {
+	if i.Quantity <= 0 {
+		return 0
+	}
+	return (i.Amount - i.DiscountAmount + i.TaxAmount) * float64(quantity) / float64(i.Quantity)
+}
+
+// OrderItemRequest 订单商品明细请求
+// 有规格的商品需通过 SKUID 指定规格
+type OrderItemRequest struct {
 	ProductID string `json:"product_id" binding:"required"`
+	SKUID     string `json:"sku_id"`
 	Quantity  int    `json:"quantity" binding:"required,gt=0"`
 }
 
+// CreateOrderRequest 创建订单请求
+// 可通过 Items 一次购买多个商品；ProductID/Quantity 为单商品下单的简写形式
+type CreateOrderRequest struct {
+	UserID    string             `json:"user_id" binding:"required"`
+	ProductID string             `json:"product_id"`
+	SKUID     string             `json:"sku_id"`
+	Quantity  int                `json:"quantity" binding:"gte=0"`
+	Items     []OrderItemRequest `json:"items" binding:"omitempty,dive"`
+	// AddressID 收货地址 ID，为空时使用用户的默认地址
+	AddressID string `json:"address_id"`
+	// CouponCodes 使用的优惠码，可叠加多张
+	CouponCodes []string `json:"coupon_codes"`
+}
+
+// OrderItems 返回规范化后的商品明细
+func (r *CreateOrderRequest) OrderItems() []OrderItemRequest {
+	if len(r.Items) > 0 {
+		return r.Items
+	}
+	if r.ProductID != "" && r.Quantity > 0 {
+		return []OrderItemRequest{{ProductID: r.ProductID, SKUID: r.SKUID, Quantity: r.Quantity}}
+	}
+	return nil
+}
+
 // CreateOrderResponse 创建订单响应
 type CreateOrderResponse struct {
-	ID          string  `json:"id"`
-	TotalAmount float64 `json:"total_amount"`
+	ID             string  `json:"id"`
+	SubtotalAmount float64 `json:"subtotal_amount"`
+	DiscountAmount float64 `json:"discount_amount"`
+	ShippingAmount float64 `json:"shipping_amount"`
+	TaxAmount      float64 `json:"tax_amount"`
+	TotalAmount    float64 `json:"total_amount"`
+}
+
+// QuoteOrderResponse 订单试算响应，与 CreateOrderRequest 使用相同的请求体
+type QuoteOrderResponse struct {
+	Items          []OrderItem     `json:"items"`
+	SubtotalAmount float64         `json:"subtotal_amount"`
+	DiscountAmount float64         `json:"discount_amount"`
+	Discounts      []OrderDiscount `json:"discounts"`
+	ShippingAmount float64         `json:"shipping_amount"`
+	TaxAmount      float64         `json:"tax_amount"`
+	TotalAmount    float64         `json:"total_amount"`
 }
 
 // GetOrderRequest 获取订单请求
@@ -45,25 +167,39 @@ type GetOrderRequest struct {
 
 // GetOrderResponse 获取订单响应
 type GetOrderResponse struct {
-	ID          string      `json:"id"`
-	UserID      string      `json:"user_id"`
-	ProductID   string      `json:"product_id"`
-	Quantity    int         `json:"quantity"`
-	TotalAmount float64     `json:"total_amount"`
-	Status      OrderStatus `json:"status"`
-	CreatedAt   time.Time   `json:"created_at"`
+	ID             string          `json:"id"`
+	UserID         string          `json:"user_id"`
+	Items          []OrderItem     `json:"items"`
+	SubtotalAmount float64         `json:"subtotal_amount"`
+	DiscountAmount float64         `json:"discount_amount"`
+	Discounts      []OrderDiscount `json:"discounts"`
+	ShippingAmount float64         `json:"shipping_amount"`
+	TaxAmount      float64         `json:"tax_amount"`
+	TotalAmount    float64         `json:"total_amount"`
+	Status         OrderStatus     `json:"status"`
+	// Deprecated: ProductID 与 Quantity 为单商品订单时期的字段，保留以兼容旧客户端，请改用 Items；
+	// ProductID 为第一行商品，Quantity 为订单商品总件数
+	ProductID string `json:"product_id"`
+	Quantity  int    `json:"quantity"`
+	// ShippingAddress 下单时的收货地址快照，未指定地址时为空
+	ShippingAddress *ShippingAddress `json:"shipping_address,omitempty"`
+	CreatedAt       time.Time        `json:"created_at"`
+	// Version 版本号，与响应头 ETag 一致，更新状态时通过 If-Match 提交
+	Version int `json:"version"`
 }
 
-// UpdateOrderStatusRequest 更新订单状态请求
+// UpdateOrderStatusRequest 更新订单状态请求，须通过 If-Match 请求头提交订单的 ETag
+// ID 来自路径参数，路由保证其非空；先绑定 JSON 再绑定 URI，避免校验未填充的字段
 type UpdateOrderStatusRequest struct {
-	ID     string      `uri:"id" binding:"required"`
-	Status OrderStatus `json:"status" binding:"required"`
+	ID     string      `uri:"id"`
+	Status OrderStatus `json:"status" binding:"required,enum"`
 }
 
 // UpdateOrderStatusResponse 更新订单状态响应
 type UpdateOrderStatusResponse struct {
-	ID     string      `json:"id"`
-	Status OrderStatus `json:"status"`
+	ID      string      `json:"id"`
+	Status  OrderStatus `json:"status"`
+	Version int         `json:"version"`
 }
 
 // ListOrdersRequest 订单列表请求
@@ -76,7 +212,23 @@ type ListOrdersRequest struct {
 // ListOrdersResponse 订单列表响应
 type ListOrdersResponse struct {
 	Orders []GetOrderResponse `json:"orders"`
-	Total  int64              `json:"total"`
+	queryspec.PageInfo
+}
+
+// OrderQuerySchema 订单列表可排序、过滤与选择的字段
+var OrderQuerySchema = &queryspec.Schema{
+	Fields: []queryspec.Field{
+		{Name: "id", Column: "orders.id", Type: queryspec.String, Filterable: true},
+		{Name: "status", Column: "orders.status", Type: queryspec.String, Sortable: true, Filterable: true},
+		{Name: "total_amount", Column: "orders.total_amount", Type: queryspec.Number, Sortable: true, Filterable: true},
+		{Name: "subtotal_amount", Column: "orders.subtotal_amount", Type: queryspec.Number, Sortable: true, Filterable: true},
+		{Name: "discount_amount", Column: "orders.discount_amount", Type: queryspec.Number, Sortable: true, Filterable: true},
+		{Name: "created_at", Column: "orders.created_at", Type: queryspec.Time, Sortable: true, Filterable: true},
+		{Name: "updated_at", Column: "orders.updated_at", Type: queryspec.Time, Sortable: true, Filterable: true},
+	},
+	Selectable:  queryspec.JSONFields(GetOrderResponse{}),
+	DefaultSort: []queryspec.Sort{{Field: "created_at", Desc: true}},
+	TieBreaker:  "orders.id",
 }
 
 // CancelOrderRequest 取消订单请求
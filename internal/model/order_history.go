@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+// OrderEvent 订单历史事件类型
+type OrderEvent string
+
+const (
+	OrderEventCreated         OrderEvent = "created"
+	OrderEventStatusChanged   OrderEvent = "status_changed"
+	OrderEventCancelled       OrderEvent = "cancelled"
+	OrderEventReturnRequested OrderEvent = "return_requested"
+	OrderEventReturnApproved  OrderEvent = "return_approved"
+	OrderEventReturnRejected  OrderEvent = "return_rejected"
+	OrderEventReturnReceived  OrderEvent = "return_received"
+	OrderEventReturnRefunded  OrderEvent = "return_refunded"
+)
+
+// OrderHistory 订单历史记录
+// 记录订单的每一次状态变更及售后事件，按时间顺序追加，不可修改
+type OrderHistory struct {
+	ID         uint        `json:"id" gorm:"primaryKey"`
+	OrderID    string      `json:"order_id" gorm:"index"`
+	Event      OrderEvent  `json:"event"`
+	FromStatus OrderStatus `json:"from_status"`
+	ToStatus   OrderStatus `json:"to_status"`
+	Note       string      `json:"note"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+// GetOrderHistoryResponse 订单历史响应
+type GetOrderHistoryResponse struct {
+	OrderID string          `json:"order_id"`
+	History []*OrderHistory `json:"history"`
+}
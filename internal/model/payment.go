@@ -1,6 +1,11 @@
 package model
 
-import "time"
+import (
+	"math"
+	"time"
+
+	"github.com/innovationmech/simple-cli/internal/queryspec"
+)
 
 // PaymentStatus 支付状态
 type PaymentStatus string
@@ -13,6 +18,32 @@ const (
 	PaymentStatusCancelled PaymentStatus = "cancelled"
 )
 
+// Values 支付的全部状态
+func (PaymentStatus) Values() []string {
+	return []string{
+		string(PaymentStatusPending),
+		string(PaymentStatusSuccess),
+		string(PaymentStatusFailed),
+		string(PaymentStatusRefunded),
+		string(PaymentStatusCancelled),
+	}
+}
+
+// Valid 是否为已定义的支付状态
+func (s PaymentStatus) Valid() bool {
+	return validEnum(string(s), s.Values())
+}
+
+// UnmarshalJSON 拒绝未定义的支付状态
+func (s *PaymentStatus) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalEnum(data, *s)
+	if err != nil {
+		return err
+	}
+	*s = PaymentStatus(v)
+	return nil
+}
+
 // PaymentMethod 支付方式
 type PaymentMethod string
 
@@ -23,18 +54,51 @@ const (
 	PaymentMethodBalance    PaymentMethod = "balance"
 )
 
+// Values 全部支付方式
+func (PaymentMethod) Values() []string {
+	return []string{
+		string(PaymentMethodAlipay),
+		string(PaymentMethodWechat),
+		string(PaymentMethodCreditCard),
+		string(PaymentMethodBalance),
+	}
+}
+
+// Valid 是否为支持的支付方式
+func (m PaymentMethod) Valid() bool {
+	return validEnum(string(m), m.Values())
+}
+
+// UnmarshalJSON 拒绝不支持的支付方式
+func (m *PaymentMethod) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalEnum(data, *m)
+	if err != nil {
+		return err
+	}
+	*m = PaymentMethod(v)
+	return nil
+}
+
 // Payment 支付记录数据模型
 type Payment struct {
-	ID            string        `json:"id" gorm:"primaryKey"`
-	OrderID       string        `json:"order_id" gorm:"index"`
-	UserID        string        `json:"user_id" gorm:"index"`
-	Amount        float64       `json:"amount"`
-	Method        PaymentMethod `json:"method"`
-	Status        PaymentStatus `json:"status"`
-	TransactionID string        `json:"transaction_id"` // 第三方交易号
-	PaidAt        *time.Time    `json:"paid_at"`
-	CreatedAt     time.Time     `json:"created_at"`
-	UpdatedAt     time.Time     `json:"updated_at"`
+	ID             string        `json:"id" gorm:"primaryKey"`
+	OrderID        string        `json:"order_id" gorm:"index"`
+	UserID         string        `json:"user_id" gorm:"index"`
+	Amount         float64       `json:"amount"`
+	RefundedAmount float64       `json:"refunded_amount" gorm:"not null;default:0"` // 累计已退款金额
+	Method         PaymentMethod `json:"method"`
+	Status         PaymentStatus `json:"status"`
+	TransactionID  string        `json:"transaction_id"` // 第三方交易号
+	PaidAt         *time.Time    `json:"paid_at"`
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+	// Version 版本号，每次更新加一，用于乐观并发控制
+	Version int `json:"version" gorm:"not null;default:1"`
+}
+
+// RefundableAmount 尚可退款的金额
+func (p *Payment) RefundableAmount() float64 {
+	return math.Round((p.Amount-p.RefundedAmount)*100) / 100
 }
 
 // CreatePaymentRequest 创建支付请求
@@ -42,7 +106,7 @@ type CreatePaymentRequest struct {
 	OrderID string        `json:"order_id" binding:"required"`
 	UserID  string        `json:"user_id" binding:"required"`
 	Amount  float64       `json:"amount" binding:"required,gt=0"`
-	Method  PaymentMethod `json:"method" binding:"required"`
+	Method  PaymentMethod `json:"method" binding:"required,enum"`
 }
 
 // CreatePaymentResponse 创建支付响应
@@ -58,15 +122,17 @@ type GetPaymentRequest struct {
 
 // GetPaymentResponse 获取支付详情响应
 type GetPaymentResponse struct {
-	ID            string        `json:"id"`
-	OrderID       string        `json:"order_id"`
-	UserID        string        `json:"user_id"`
-	Amount        float64       `json:"amount"`
-	Method        PaymentMethod `json:"method"`
-	Status        PaymentStatus `json:"status"`
-	TransactionID string        `json:"transaction_id"`
-	PaidAt        *time.Time    `json:"paid_at"`
-	CreatedAt     time.Time     `json:"created_at"`
+	ID             string        `json:"id"`
+	OrderID        string        `json:"order_id"`
+	UserID         string        `json:"user_id"`
+	Amount         float64       `json:"amount"`
+	RefundedAmount float64       `json:"refunded_amount"`
+	Method         PaymentMethod `json:"method"`
+	Status         PaymentStatus `json:"status"`
+	TransactionID  string        `json:"transaction_id"`
+	PaidAt         *time.Time    `json:"paid_at"`
+	CreatedAt      time.Time     `json:"created_at"`
+	Version        int           `json:"version"`
 }
 
 // PaymentCallbackRequest 支付回调请求（模拟）
@@ -105,5 +171,20 @@ type ListPaymentsRequest struct {
 // ListPaymentsResponse 支付记录列表响应
 type ListPaymentsResponse struct {
 	Payments []GetPaymentResponse `json:"payments"`
-	Total    int64                `json:"total"`
+	queryspec.PageInfo
+}
+
+// PaymentQuerySchema 支付记录列表可排序、过滤与选择的字段
+var PaymentQuerySchema = &queryspec.Schema{
+	Fields: []queryspec.Field{
+		{Name: "id", Column: "payments.id", Type: queryspec.String, Filterable: true},
+		{Name: "status", Column: "payments.status", Type: queryspec.String, Sortable: true, Filterable: true},
+		{Name: "method", Column: "payments.method", Type: queryspec.String, Sortable: true, Filterable: true},
+		{Name: "amount", Column: "payments.amount", Type: queryspec.Number, Sortable: true, Filterable: true},
+		{Name: "paid_at", Column: "payments.paid_at", Type: queryspec.Time, Sortable: true, Filterable: true, Nullable: true},
+		{Name: "created_at", Column: "payments.created_at", Type: queryspec.Time, Sortable: true, Filterable: true},
+	},
+	Selectable:  queryspec.JSONFields(GetPaymentResponse{}),
+	DefaultSort: []queryspec.Sort{{Field: "created_at", Desc: true}},
+	TieBreaker:  "payments.id",
 }
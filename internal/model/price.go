@@ -0,0 +1,125 @@
+package model
+
+import "time"
+
+// PriceKind 价格记录类型
+type PriceKind string
+
+const (
+	// PriceKindBase 基础价，自 EffectiveFrom 起生效，直到被更晚生效的基础价取代
+	PriceKindBase PriceKind = "base"
+	// PriceKindSale 促销价，仅在 [EffectiveFrom, EffectiveUntil) 区间内覆盖基础价
+	PriceKindSale PriceKind = "sale"
+)
+
+// ProductPrice 商品价格记录
+// 每一次价格变更（含预约的未来调价与促销价）追加一条记录；SKUID 为空时作用于商品价格，
+// 未单独定价的 SKU 继承商品价格
+type ProductPrice struct {
+	ID             uint       `json:"id" gorm:"primaryKey"`
+	ProductID      string     `json:"product_id" gorm:"index"`
+	SKUID          string     `json:"sku_id,omitempty" gorm:"column:sku_id;index"`
+	Kind           PriceKind  `json:"kind"`
+	Price          float64    `json:"price"`
+	EffectiveFrom  time.Time  `json:"effective_from" gorm:"index"`
+	EffectiveUntil *time.Time `json:"effective_until,omitempty"`
+	// Actor 操作人，由请求方提供，未提供时为空
+	Actor     string    `json:"actor"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ActiveAt 价格记录在 at 时刻是否生效中
+func (p *ProductPrice) ActiveAt(at time.Time) bool {
+	if p.EffectiveFrom.After(at) {
+		return false
+	}
+	return p.EffectiveUntil == nil || p.EffectiveUntil.After(at)
+}
+
+// PriceAt 返回商品（或指定规格）在 at 时刻的基础价与生效中的促销价（无促销时为 nil）
+// 依赖预加载的 Prices；没有价格记录时使用商品或 SKU 上保存的价格
+func (p *Product) PriceAt(sku *SKU, at time.Time) (float64, *ProductPrice) {
+	base := p.Price
+	if entry := p.latestPrice(PriceKindBase, "", at); entry != nil {
+		base = entry.Price
+	}
+	inherits := true
+	if sku != nil {
+		if sku.Price != nil {
+			base, inherits = *sku.Price, false
+		}
+		if entry := p.latestPrice(PriceKindBase, sku.ID, at); entry != nil {
+			base, inherits = entry.Price, false
+		}
+	}
+
+	var sale *ProductPrice
+	if sku != nil {
+		sale = p.latestPrice(PriceKindSale, sku.ID, at)
+	}
+	if sale == nil && inherits {
+		sale = p.latestPrice(PriceKindSale, "", at)
+	}
+	return base, sale
+}
+
+// latestPrice 返回指定类型与作用范围内，at 时刻生效且生效时间最晚的价格记录
+func (p *Product) latestPrice(kind PriceKind, skuID string, at time.Time) *ProductPrice {
+	var latest *ProductPrice
+	for i := range p.Prices {
+		entry := &p.Prices[i]
+		if entry.Kind != kind || entry.SKUID != skuID || !entry.ActiveAt(at) {
+			continue
+		}
+		if latest == nil || entry.EffectiveFrom.After(latest.EffectiveFrom) ||
+			(entry.EffectiveFrom.Equal(latest.EffectiveFrom) && entry.ID > latest.ID) {
+			latest = entry
+		}
+	}
+	return latest
+}
+
+// ProductPriceURI 价格记录路径参数
+type ProductPriceURI struct {
+	ProductID string `uri:"id" binding:"required"`
+	PriceID   uint   `uri:"price_id"`
+}
+
+// SchedulePriceRequest 预约调价或促销价请求
+// EffectiveFrom 为空或早于当前时间时立即生效；促销价必须指定 EffectiveUntil
+type SchedulePriceRequest struct {
+	Kind           PriceKind  `json:"kind" binding:"required,oneof=base sale"`
+	SKUID          string     `json:"sku_id"`
+	Price          *float64   `json:"price" binding:"required,gte=0"`
+	EffectiveFrom  *time.Time `json:"effective_from"`
+	EffectiveUntil *time.Time `json:"effective_until"`
+	Actor          string     `json:"actor"`
+}
+
+// ListPricesRequest 价格历史请求，SKUID 非空时只返回该规格的价格记录
+type ListPricesRequest struct {
+	SKUID string `form:"sku_id"`
+}
+
+// CurrentPrice 当前生效的价格
+type CurrentPrice struct {
+	Price      float64    `json:"price"`
+	SalePrice  *float64   `json:"sale_price,omitempty"`
+	SaleEndsAt *time.Time `json:"sale_ends_at,omitempty"`
+}
+
+// Effective 返回实际售价，促销期间为促销价
+func (c CurrentPrice) Effective() float64 {
+	if c.SalePrice != nil {
+		return *c.SalePrice
+	}
+	return c.Price
+}
+
+// ListPricesResponse 价格历史响应，History 按生效时间倒序排列
+type ListPricesResponse struct {
+	ProductID string          `json:"product_id"`
+	SKUID     string          `json:"sku_id,omitempty"`
+	Current   CurrentPrice    `json:"current"`
+	History   []*ProductPrice `json:"history"`
+}
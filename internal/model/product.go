@@ -1,24 +1,53 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"github.com/innovationmech/simple-cli/internal/queryspec"
+	"gorm.io/gorm"
+)
 
 // Product 商品数据模型
 type Product struct {
-	ID          string    `json:"id" gorm:"primaryKey"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Price       float64   `json:"price"`
-	Stock       int       `json:"stock"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          string  `json:"id" gorm:"primaryKey"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Price       float64 `json:"price"`
+	Stock       int     `json:"stock"`
+	// LowStockThreshold 低库存阈值，库存（有规格时为各 SKU 库存）降至该值及以下时产生预警，为空表示不预警
+	LowStockThreshold *int `json:"low_stock_threshold"`
+	// TaxClass 税类，决定计价时使用的税率，为空时使用配置的默认税类
+	TaxClass string `json:"tax_class"`
+	// Categories 商品所属分类，一个商品可属于多个分类
+	Categories []Category `json:"categories" gorm:"many2many:product_categories"`
+	// SKUs 商品规格，为空表示商品不区分规格，直接使用 Price 与 Stock
+	SKUs []SKU `json:"skus" gorm:"foreignKey:ProductID"`
+	// Images 商品图片，按 SortOrder 排序
+	Images []ProductImage `json:"images" gorm:"foreignKey:ProductID"`
+	// Prices 尚未结束的价格记录（含预约调价与促销价），用于计算当前售价
+	Prices []ProductPrice `json:"-" gorm:"foreignKey:ProductID"`
+	// ChangedBy 本次修改的操作人，仅用于记录价格历史与库存调整，不持久化
+	ChangedBy string `json:"-" gorm:"-"`
+	// EffectivePrice 当前售价（含生效中的预约调价与促销价），只在商品列表查询中由数据库计算，用于按价格排序与过滤
+	EffectivePrice float64   `json:"-" gorm:"->;-:migration"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	// Version 版本号，每次更新加一，用于乐观并发控制
+	Version int `json:"version" gorm:"not null;default:1"`
+	// DeletedAt 软删除时间，默认查询不返回已删除的商品；已下单的商品删除后订单仍可追溯
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
 // CreateProductRequest 创建商品请求
 type CreateProductRequest struct {
-	Name        string  `json:"name" binding:"required"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price" binding:"required,gt=0"`
-	Stock       int     `json:"stock" binding:"gte=0"`
+	Name        string   `json:"name" binding:"required"`
+	Description string   `json:"description"`
+	Price       *float64 `json:"price" binding:"required,gte=0"`
+	Stock       int      `json:"stock" binding:"gte=0"`
+	TaxClass    string   `json:"tax_class"`
+	CategoryIDs []string `json:"category_ids"`
+	// Actor 操作人，记录在价格历史与库存调整日志中
+	Actor string `json:"actor"`
 }
 
 // CreateProductResponse 创建商品响应
@@ -37,21 +66,43 @@ type GetProductResponse struct {
 	Name        string  `json:"name"`
 	Description string  `json:"description"`
 	Price       float64 `json:"price"`
-	Stock       int     `json:"stock"`
+	// SalePrice 生效中的促销价，SaleEndsAt 为促销结束时间
+	SalePrice  *float64          `json:"sale_price,omitempty"`
+	SaleEndsAt *time.Time        `json:"sale_ends_at,omitempty"`
+	Stock      int               `json:"stock"`
+	TaxClass   string            `json:"tax_class"`
+	Categories []CategorySummary `json:"categories"`
+	// Options 与 Variants 组成规格矩阵，无规格商品为空
+	Options  []VariantOption   `json:"options"`
+	Variants []VariantResponse `json:"variants"`
+	// Images 商品图片及缩略图地址
+	Images []ImageResponse `json:"images"`
+	// DeletedAt 删除时间，仅在 include_deleted=true 的列表中出现
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// Version 版本号，与响应头 ETag 一致，更新时通过 If-Match 提交
+	Version int `json:"version"`
 }
 
-// UpdateProductRequest 更新商品请求
+// UpdateProductRequest 更新商品请求，须通过 If-Match 请求头提交商品的 ETag
+// ID 来自路径参数，路由保证其非空；先绑定 JSON 再绑定 URI，避免校验未填充的字段
+// 未提供的字段保持不变
 type UpdateProductRequest struct {
-	ID          string  `uri:"id" binding:"required"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price" binding:"gt=0"`
-	Stock       int     `json:"stock" binding:"gte=0"`
+	ID          string   `uri:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Price       *float64 `json:"price" binding:"omitempty,gte=0"`
+	Stock       *int     `json:"stock" binding:"omitempty,gte=0"`
+	TaxClass    string   `json:"tax_class"`
+	// CategoryIDs 为 nil 时不修改分类，传空数组表示移除所有分类
+	CategoryIDs *[]string `json:"category_ids"`
+	// Actor 操作人，记录在价格历史与库存调整日志中
+	Actor string `json:"actor"`
 }
 
 // UpdateProductResponse 更新商品响应
 type UpdateProductResponse struct {
-	ID string `json:"id"`
+	ID      string `json:"id"`
+	Version int    `json:"version"`
 }
 
 // DeleteProductRequest 删除商品请求
@@ -64,14 +115,53 @@ type DeleteProductResponse struct {
 	ID string `json:"id"`
 }
 
+// RestoreProductRequest 恢复已删除商品请求
+type RestoreProductRequest struct {
+	ID string `uri:"id" binding:"required"`
+}
+
 // ListProductsRequest 商品列表请求
 type ListProductsRequest struct {
 	Page     int `form:"page" binding:"gte=0"`
 	PageSize int `form:"page_size" binding:"gte=0,lte=100"`
+	// Category 分类 ID 或 slug，结果包含其所有子分类下的商品
+	Category string `form:"category"`
+	// IncludeDeleted 管理端使用，结果包含已软删除的商品
+	IncludeDeleted bool `form:"include_deleted"`
+}
+
+// ProductFilter 商品列表过滤条件
+// Category 为调用方传入的分类 ID 或 slug，CategoryIDs 为服务层展开后的分类子树
+type ProductFilter struct {
+	Category    string
+	CategoryIDs []string
+	// MinPrice/MaxPrice 售价区间，有规格的商品任一 SKU 售价落在区间内即命中
+	MinPrice *float64
+	MaxPrice *float64
+	// InStock 仅返回有库存的商品
+	InStock bool
+	// IncludeDeleted 包含已软删除的商品
+	IncludeDeleted bool
 }
 
 // ListProductsResponse 商品列表响应
 type ListProductsResponse struct {
 	Products []GetProductResponse `json:"products"`
-	Total    int64                `json:"total"`
+	queryspec.PageInfo
+}
+
+// ProductQuerySchema 商品列表可排序、过滤与选择的字段
+var ProductQuerySchema = &queryspec.Schema{
+	Fields: []queryspec.Field{
+		{Name: "id", Column: "products.id", Type: queryspec.String, Filterable: true},
+		{Name: "name", Column: "products.name", Type: queryspec.String, Sortable: true, Filterable: true},
+		{Name: "price", Column: "products.effective_price", Type: queryspec.Number, Sortable: true, Filterable: true},
+		{Name: "stock", Column: "products.stock", Type: queryspec.Number, Sortable: true, Filterable: true},
+		{Name: "tax_class", Column: "products.tax_class", Type: queryspec.String, Filterable: true},
+		{Name: "created_at", Column: "products.created_at", Type: queryspec.Time, Sortable: true, Filterable: true},
+		{Name: "updated_at", Column: "products.updated_at", Type: queryspec.Time, Sortable: true, Filterable: true},
+	},
+	Selectable:  queryspec.JSONFields(GetProductResponse{}),
+	DefaultSort: []queryspec.Sort{{Field: "created_at"}},
+	TieBreaker:  "products.id",
 }
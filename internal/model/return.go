@@ -0,0 +1,114 @@
+package model
+
+import (
+	"time"
+
+	"github.com/innovationmech/simple-cli/internal/queryspec"
+)
+
+// ReturnStatus 退货申请状态
+// 流转：requested → approved → received → refunded；requested → rejected
+type ReturnStatus string
+
+const (
+	ReturnStatusRequested ReturnStatus = "requested"
+	ReturnStatusApproved  ReturnStatus = "approved"
+	ReturnStatusRejected  ReturnStatus = "rejected"
+	ReturnStatusReceived  ReturnStatus = "received"
+	ReturnStatusRefunded  ReturnStatus = "refunded"
+)
+
+// ReturnRequest 退货申请（RMA）数据模型
+// 每个处理步骤都记录独立的时间戳，便于追踪售后进度
+type ReturnRequest struct {
+	ID           string       `json:"id" gorm:"primaryKey"`
+	OrderID      string       `json:"order_id" gorm:"index"`
+	UserID       string       `json:"user_id" gorm:"index"`
+	Status       ReturnStatus `json:"status" gorm:"index"`
+	Reason       string       `json:"reason"`
+	ReviewNote   string       `json:"review_note"`
+	RefundAmount float64      `json:"refund_amount"`
+	PaymentID    string       `json:"payment_id"` // 执行退款的支付记录
+	Items        []ReturnItem `json:"items" gorm:"foreignKey:ReturnID"`
+	ApprovedAt   *time.Time   `json:"approved_at"`
+	RejectedAt   *time.Time   `json:"rejected_at"`
+	ReceivedAt   *time.Time   `json:"received_at"`
+	RefundedAt   *time.Time   `json:"refunded_at"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
+
+// ReturnItem 退货商品明细
+type ReturnItem struct {
+	ID        uint   `json:"-" gorm:"primaryKey"`
+	ReturnID  string `json:"-" gorm:"index"`
+	ProductID string `json:"product_id"`
+	SKUID     string `json:"sku_id,omitempty" gorm:"column:sku_id"`
+	Quantity  int    `json:"quantity"`
+}
+
+// ReturnItemRequest 退货商品明细请求
+type ReturnItemRequest struct {
+	ProductID string `json:"product_id" binding:"required"`
+	SKUID     string `json:"sku_id"`
+	Quantity  int    `json:"quantity" binding:"required,gt=0"`
+}
+
+// CreateReturnRequest 创建退货申请请求
+type CreateReturnRequest struct {
+	OrderID string              `json:"order_id" binding:"required"`
+	UserID  string              `json:"user_id" binding:"required"`
+	Reason  string              `json:"reason" binding:"required"`
+	Items   []ReturnItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// CreateReturnResponse 创建退货申请响应
+type CreateReturnResponse struct {
+	ID           string       `json:"id"`
+	Status       ReturnStatus `json:"status"`
+	RefundAmount float64      `json:"refund_amount"`
+}
+
+// GetReturnRequest 获取退货申请请求
+type GetReturnRequest struct {
+	ID string `uri:"id" binding:"required"`
+}
+
+// ReviewReturnRequest 审核退货申请请求（批准或拒绝）
+type ReviewReturnRequest struct {
+	Note string `json:"note"`
+}
+
+// UpdateReturnResponse 退货申请状态变更响应
+type UpdateReturnResponse struct {
+	ID     string       `json:"id"`
+	Status ReturnStatus `json:"status"`
+}
+
+// ListReturnsRequest 退货申请列表请求
+type ListReturnsRequest struct {
+	OrderID  string       `form:"order_id"`
+	UserID   string       `form:"user_id"`
+	Status   ReturnStatus `form:"status"`
+	Page     int          `form:"page" binding:"gte=0"`
+	PageSize int          `form:"page_size" binding:"gte=0,lte=100"`
+}
+
+// ListReturnsResponse 退货申请列表响应
+type ListReturnsResponse struct {
+	Returns []*ReturnRequest `json:"returns"`
+	queryspec.PageInfo
+}
+
+// ReturnQuerySchema 退货申请列表可排序、过滤与选择的字段
+var ReturnQuerySchema = &queryspec.Schema{
+	Fields: []queryspec.Field{
+		{Name: "status", Column: "return_requests.status", Type: queryspec.String, Sortable: true, Filterable: true},
+		{Name: "refund_amount", Column: "return_requests.refund_amount", Type: queryspec.Number, Sortable: true, Filterable: true},
+		{Name: "created_at", Column: "return_requests.created_at", Type: queryspec.Time, Sortable: true, Filterable: true},
+		{Name: "updated_at", Column: "return_requests.updated_at", Type: queryspec.Time, Sortable: true, Filterable: true},
+	},
+	Selectable:  queryspec.JSONFields(ReturnRequest{}),
+	DefaultSort: []queryspec.Sort{{Field: "created_at", Desc: true}},
+	TieBreaker:  "return_requests.id",
+}
@@ -0,0 +1,41 @@
+package model
+
+// SearchProductsRequest 商品全文搜索请求
+// Q 按空白与标点分词，所有词都需命中，每个词按前缀匹配
+type SearchProductsRequest struct {
+	Q        string   `form:"q" binding:"required"`
+	Category string   `form:"category"`
+	MinPrice *float64 `form:"min_price" binding:"omitempty,gte=0"`
+	MaxPrice *float64 `form:"max_price" binding:"omitempty,gte=0"`
+	InStock  bool     `form:"in_stock"`
+	Page     int      `form:"page" binding:"gte=0"`
+	PageSize int      `form:"page_size" binding:"gte=0,lte=100"`
+}
+
+// ProductSearchHit 商品搜索命中结果
+// Score 越大相关度越高；高亮文本中命中的词以 <mark></mark> 包裹
+type ProductSearchHit struct {
+	Product              *Product
+	Score                float64
+	NameHighlight        string
+	DescriptionHighlight string
+}
+
+// SearchHighlights 搜索结果高亮片段
+type SearchHighlights struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// SearchProductResult 单条商品搜索结果
+type SearchProductResult struct {
+	GetProductResponse
+	Score      float64          `json:"score"`
+	Highlights SearchHighlights `json:"highlights"`
+}
+
+// SearchProductsResponse 商品搜索响应，按相关度降序排列
+type SearchProductsResponse struct {
+	Products []SearchProductResult `json:"products"`
+	Total    int64                 `json:"total"`
+}
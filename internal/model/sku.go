@@ -0,0 +1,171 @@
+package model
+
+import (
+	"sort"
+	"time"
+
+	"github.com/innovationmech/simple-cli/internal/domain"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrSKUNotFound 指定的 SKU 不属于该商品，或商品不区分规格
+	ErrSKUNotFound = domain.Keyed(domain.CodeValidation, "sku.not_found", "sku not found")
+	// ErrSKURequired 有规格的商品未指定 SKU
+	ErrSKURequired = domain.Keyed(domain.CodeValidation, "sku.required", "sku is required for products with variants")
+)
+
+// SKU 商品规格（变体）数据模型
+// Attributes 为规格属性（如 size=M、color=red），同一商品的所有 SKU 使用相同的属性名
+// Price 为空时使用商品价格
+type SKU struct {
+	ID        string `json:"id" gorm:"primaryKey"`
+	ProductID string `json:"product_id" gorm:"index"`
+	// Code 在未删除的 SKU 中唯一，已删除 SKU 的编码可以重新使用
+	Code       string            `json:"code" gorm:"uniqueIndex:idx_skus_code_active,where:deleted_at IS NULL"`
+	Attributes map[string]string `json:"attributes" gorm:"serializer:json"`
+	Price      *float64          `json:"price"`
+	Stock      int               `json:"stock"`
+	// ChangedBy 本次修改的操作人，仅用于记录价格历史与库存调整，不持久化
+	ChangedBy string    `json:"-" gorm:"-"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// DeletedAt 软删除时间，已删除的 SKU 不再出售，其价格历史、库存调整与预警记录仍保留
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// HasVariants 商品是否按 SKU 销售
+func (p *Product) HasVariants() bool {
+	return len(p.SKUs) > 0
+}
+
+// SelectSKU 根据 SKU ID 选取商品规格
+// 有规格的商品必须指定 SKU，无规格的商品不能指定 SKU；无规格商品返回 nil
+func (p *Product) SelectSKU(skuID string) (*SKU, error) {
+	if !p.HasVariants() {
+		if skuID != "" {
+			return nil, ErrSKUNotFound
+		}
+		return nil, nil
+	}
+	if skuID == "" {
+		return nil, ErrSKURequired
+	}
+	for i := range p.SKUs {
+		if p.SKUs[i].ID == skuID {
+			return &p.SKUs[i], nil
+		}
+	}
+	return nil, ErrSKUNotFound
+}
+
+// UnitPrice 返回商品（或指定规格）当前的实际售价，促销期间为促销价
+func (p *Product) UnitPrice(sku *SKU) float64 {
+	return p.UnitPriceAt(sku, time.Now())
+}
+
+// UnitPriceAt 返回商品（或指定规格）在 at 时刻的实际售价
+func (p *Product) UnitPriceAt(sku *SKU, at time.Time) float64 {
+	return p.CurrentPrice(sku, at).Effective()
+}
+
+// CurrentPrice 返回商品（或指定规格）在 at 时刻的基础价与促销价
+func (p *Product) CurrentPrice(sku *SKU, at time.Time) CurrentPrice {
+	base, sale := p.PriceAt(sku, at)
+	current := CurrentPrice{Price: base}
+	if sale != nil {
+		price := sale.Price
+		current.SalePrice = &price
+		current.SaleEndsAt = sale.EffectiveUntil
+	}
+	return current
+}
+
+// AvailableStock 返回商品（或指定规格）的可售库存
+// 有规格的商品未指定 SKU 时返回所有 SKU 库存之和
+func (p *Product) AvailableStock(sku *SKU) int {
+	if sku != nil {
+		return sku.Stock
+	}
+	if !p.HasVariants() {
+		return p.Stock
+	}
+	total := 0
+	for _, s := range p.SKUs {
+		total += s.Stock
+	}
+	return total
+}
+
+// VariantOptions 汇总商品规格矩阵的属性名及其可选值
+// 属性名按字母序排列，属性值按 SKU 顺序去重
+func (p *Product) VariantOptions() []VariantOption {
+	values := make(map[string][]string)
+	seen := make(map[string]bool)
+	for _, sku := range p.SKUs {
+		for name, value := range sku.Attributes {
+			if key := name + "\x00" + value; !seen[key] {
+				seen[key] = true
+				values[name] = append(values[name], value)
+			}
+		}
+	}
+
+	options := make([]VariantOption, 0, len(values))
+	for name, v := range values {
+		options = append(options, VariantOption{Name: name, Values: v})
+	}
+	sort.Slice(options, func(i, j int) bool { return options[i].Name < options[j].Name })
+	return options
+}
+
+// VariantOption 规格属性及其可选值
+type VariantOption struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+// VariantResponse 规格矩阵中的单个 SKU
+type VariantResponse struct {
+	ID         string            `json:"id"`
+	Code       string            `json:"code"`
+	Attributes map[string]string `json:"attributes"`
+	Price      float64           `json:"price"`
+	SalePrice  *float64          `json:"sale_price,omitempty"`
+	SaleEndsAt *time.Time        `json:"sale_ends_at,omitempty"`
+	Stock      int               `json:"stock"`
+}
+
+// SKUURI SKU 路径参数
+type SKUURI struct {
+	ProductID string `uri:"id" binding:"required"`
+	SKUID     string `uri:"sku_id"`
+}
+
+// CreateSKURequest 创建 SKU 请求
+type CreateSKURequest struct {
+	Code       string            `json:"code" binding:"required"`
+	Attributes map[string]string `json:"attributes" binding:"required,min=1"`
+	Price      *float64          `json:"price" binding:"omitempty,gte=0"`
+	Stock      int               `json:"stock" binding:"gte=0"`
+	Actor      string            `json:"actor"`
+}
+
+// CreateSKUResponse 创建 SKU 响应
+type CreateSKUResponse struct {
+	ID string `json:"id"`
+}
+
+// UpdateSKURequest 更新 SKU 请求，未提供的字段保持不变
+type UpdateSKURequest struct {
+	Code       string            `json:"code"`
+	Attributes map[string]string `json:"attributes"`
+	Price      *float64          `json:"price" binding:"omitempty,gte=0"`
+	Stock      *int              `json:"stock" binding:"omitempty,gte=0"`
+	Actor      string            `json:"actor"`
+}
+
+// UpdateSKUResponse 更新 SKU 响应
+type UpdateSKUResponse struct {
+	ID string `json:"id"`
+}
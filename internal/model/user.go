@@ -1,6 +1,10 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type User struct {
 	ID        string    `json:"id"`
@@ -8,6 +12,8 @@ type User struct {
 	Email     string    `json:"email"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	// DeletedAt 软删除时间，默认查询不返回已删除的用户
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
 type CreateUserRequest struct {
@@ -20,7 +26,7 @@ type CreateUserResponse struct {
 }
 
 type GetUserRequest struct {
-	ID string `json:"id"`
+	ID string `uri:"id" binding:"required"`
 }
 
 type GetUserResponse struct {
@@ -40,7 +46,12 @@ type UpdateUserResponse struct {
 }
 
 type DeleteUserRequest struct {
-	ID string `json:"id"`
+	ID string `uri:"id" binding:"required"`
+}
+
+// RestoreUserRequest 恢复已删除用户请求
+type RestoreUserRequest struct {
+	ID string `uri:"id" binding:"required"`
 }
 
 type DeleteUserResponse struct {
@@ -0,0 +1,313 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"github.com/innovationmech/simple-cli/internal/queryspec"
+	"github.com/innovationmech/simple-cli/internal/types"
+)
+
+// Route 路由的文档描述，Method 与 Path 需与注册路由时一致
+// Params、Query、Body、Response 传入对应结构体的零值，仅用于反射其类型
+type Route struct {
+	Method  string
+	Path    string
+	Summary string
+	// Params 路径参数结构体（uri 标签）
+	Params interface{}
+	// Query 查询参数结构体（form 标签）
+	Query interface{}
+	// List 列表接口的查询白名单，生成 sort、filter、fields 与游标参数
+	List *queryspec.Schema
+	// Body JSON 请求体结构体
+	Body interface{}
+	// BodyOptional 请求体可以省略
+	BodyOptional bool
+	// Upload multipart/form-data 上传的文件字段名，Query 结构体中的字段作为其余表单字段
+	Upload string
+	// Response types.ApiResponse 中 data 的类型，为空表示响应不含 data
+	Response interface{}
+	// Raw 为 true 时 Response 直接作为响应体，不包装在 ApiResponse 中
+	Raw bool
+	// ContentType 不使用 ApiResponse 而直接返回文件内容时的内容类型
+	ContentType string
+	// Status 成功时的状态码，默认 200
+	Status int
+	// IfMatch 需要 If-Match 请求头的乐观并发更新
+	IfMatch bool
+	// ETag 成功响应带有 ETag 响应头
+	ETag bool
+	// Deprecated 已弃用的路由，注册时应同时挂载 middleware.Deprecated
+	Deprecated bool
+}
+
+// maxIdempotencyKeyLength Idempotency-Key 请求头的最大长度
+var maxIdempotencyKeyLength = 255
+
+// Build 根据已注册的路由生成文档
+// 在 routes 中有描述的路由生成完整的参数与请求/响应结构，其余路由只包含路径参数
+func Build(info Info, registered []gin.RouteInfo, routes []Route) *Document {
+	described := make(map[string]Route, len(routes))
+	for _, r := range routes {
+		described[r.Method+" "+r.Path] = r
+	}
+
+	registry := newSchemaRegistry()
+	envelope := registry.schemaOf(reflect.TypeOf(types.ApiResponse{}))
+	problem := registry.schemaOf(reflect.TypeOf(types.Problem{}))
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]*PathItem),
+	}
+
+	sorted := append([]gin.RouteInfo(nil), registered...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Path != sorted[j].Path {
+			return sorted[i].Path < sorted[j].Path
+		}
+		return sorted[i].Method < sorted[j].Method
+	})
+
+	tags := make(map[string]bool)
+	operationIDs := make(map[string]int)
+	for _, ri := range sorted {
+		route, ok := described[ri.Method+" "+ri.Path]
+		if !ok {
+			route = Route{Method: ri.Method, Path: ri.Path}
+		}
+
+		op := &Operation{
+			Summary:    route.Summary,
+			Responses:  make(map[string]*Response),
+			Deprecated: route.Deprecated,
+		}
+		if tag := tagOf(ri.Path); tag != "" {
+			op.Tags = []string{tag}
+			tags[tag] = true
+		}
+		// 同一处理函数注册在多个路径时以序号区分
+		op.OperationID = operationID(ri.Handler)
+		if n := operationIDs[op.OperationID]; n > 0 {
+			op.OperationID += strconv.Itoa(n + 1)
+		}
+		operationIDs[operationID(ri.Handler)]++
+
+		op.Parameters = append(op.Parameters, pathParams(registry, ri.Path, route.Params)...)
+		if route.Upload == "" && route.Query != nil {
+			op.Parameters = append(op.Parameters, structParams(registry, route.Query, "form", "query")...)
+		}
+		if route.List != nil {
+			op.Parameters = append(op.Parameters, listParams(route.List)...)
+		}
+		switch ri.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+			op.Parameters = append(op.Parameters, &Parameter{
+				Name:        "Idempotency-Key",
+				In:          "header",
+				Description: "客户端生成的唯一键，重试时携带相同的键可避免重复执行，并原样返回首次请求的响应",
+				Schema:      &Schema{Type: "string", MaxLength: &maxIdempotencyKeyLength},
+			})
+		}
+		if route.IfMatch {
+			op.Parameters = append(op.Parameters, &Parameter{
+				Name:        "If-Match",
+				In:          "header",
+				Description: "读取资源时返回的 ETag",
+				Required:    true,
+				Schema:      &Schema{Type: "string"},
+			})
+		}
+
+		switch {
+		case route.Body != nil:
+			op.RequestBody = &RequestBody{
+				Required: !route.BodyOptional,
+				Content: map[string]*MediaType{
+					"application/json": {Schema: registry.schemaOf(reflect.TypeOf(route.Body))},
+				},
+			}
+		case route.Upload != "":
+			form := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+			if route.Query != nil {
+				form = registry.objectOfTag(reflect.TypeOf(route.Query), "form")
+			}
+			form.Properties[route.Upload] = &Schema{Type: "string", Format: "binary"}
+			form.Required = append(form.Required, route.Upload)
+			op.RequestBody = &RequestBody{
+				Required: true,
+				Content:  map[string]*MediaType{"multipart/form-data": {Schema: form}},
+			}
+		}
+
+		status := route.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		success := &Response{Description: http.StatusText(status)}
+		switch {
+		case route.ContentType != "":
+			success.Content = map[string]*MediaType{
+				route.ContentType: {Schema: &Schema{Type: "string", Format: "binary"}},
+			}
+		case route.Raw:
+			success.Content = map[string]*MediaType{
+				"application/json": {Schema: registry.schemaOf(reflect.TypeOf(route.Response))},
+			}
+		case route.Response != nil:
+			success.Content = map[string]*MediaType{
+				"application/json": {Schema: &Schema{AllOf: []*Schema{
+					envelope,
+					{
+						Type:       "object",
+						Properties: map[string]*Schema{"data": registry.schemaOf(reflect.TypeOf(route.Response))},
+					},
+				}}},
+			}
+		default:
+			success.Content = map[string]*MediaType{"application/json": {Schema: envelope}}
+		}
+		if route.ETag || route.IfMatch {
+			success.Headers = map[string]*Header{
+				"ETag": {Description: "资源当前版本", Schema: &Schema{Type: "string"}},
+			}
+		}
+		op.Responses[strconv.Itoa(status)] = success
+		op.Responses["default"] = &Response{
+			Description: "错误，reason 为领域错误码",
+			Content: map[string]*MediaType{
+				"application/json":       {Schema: envelope},
+				types.ProblemContentType: {Schema: problem},
+			},
+		}
+
+		path := openAPIPath(ri.Path)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = &PathItem{}
+			doc.Paths[path] = item
+		}
+		(*item)[strings.ToLower(ri.Method)] = op
+	}
+
+	for tag := range tags {
+		doc.Tags = append(doc.Tags, Tag{Name: tag})
+	}
+	sort.Slice(doc.Tags, func(i, j int) bool { return doc.Tags[i].Name < doc.Tags[j].Name })
+	doc.Components.Schemas = registry.schemas
+	return doc
+}
+
+// pathParams 生成路径参数，结构体中没有声明的参数按字符串处理
+func pathParams(r *schemaRegistry, path string, params interface{}) []*Parameter {
+	declared := make(map[string]*Parameter)
+	if params != nil {
+		for _, p := range structParams(r, params, "uri", "path") {
+			declared[p.Name] = p
+		}
+	}
+
+	var result []*Parameter
+	for _, segment := range strings.Split(path, "/") {
+		if len(segment) < 2 || (segment[0] != ':' && segment[0] != '*') {
+			continue
+		}
+		name := segment[1:]
+		p, ok := declared[name]
+		if !ok {
+			p = &Parameter{Name: name, In: "path", Schema: &Schema{Type: "string"}}
+		}
+		// 路径参数总是必填
+		p.Required = true
+		result = append(result, p)
+	}
+	return result
+}
+
+// structParams 将结构体字段按指定标签生成参数
+func structParams(r *schemaRegistry, v interface{}, tag, in string) []*Parameter {
+	var params []*Parameter
+	for _, f := range fields(reflect.TypeOf(v), tag) {
+		if f.Tag.Get(tag) == "" {
+			continue
+		}
+		schema := r.schemaOf(f.Type)
+		required := applyBinding(schema, f.Type, f.Tag.Get("binding"))
+		params = append(params, &Parameter{Name: f.name, In: in, Required: required, Schema: schema})
+	}
+	return params
+}
+
+// listParams 生成列表接口通用的排序、过滤、字段选择与游标参数
+func listParams(schema *queryspec.Schema) []*Parameter {
+	explode := true
+	return []*Parameter{
+		{
+			Name:        "sort",
+			In:          "query",
+			Description: "逗号分隔的排序字段，- 前缀表示降序。可排序字段：" + strings.Join(schema.Sortable(), ", "),
+			Schema:      &Schema{Type: "string"},
+		},
+		{
+			Name:        "filter",
+			In:          "query",
+			Description: "filter[字段][操作符]=值，操作符为 eq、ne、gt、gte、lt、lte、in、like。可过滤字段：" + strings.Join(schema.Filterable(), ", "),
+			Style:       "deepObject",
+			Explode:     &explode,
+			Schema:      &Schema{Type: "object", AdditionalProps: &Schema{Type: "object", AdditionalProps: &Schema{Type: "string"}}},
+		},
+		{
+			Name:        "fields",
+			In:          "query",
+			Description: "逗号分隔的响应字段",
+			Schema:      &Schema{Type: "string"},
+		},
+		{
+			Name:        "cursor",
+			In:          "query",
+			Description: "上次响应中的 next_cursor 或 prev_cursor",
+			Schema:      &Schema{Type: "string"},
+		},
+		{
+			Name:        "include_total",
+			In:          "query",
+			Description: "为 false 时不返回 total",
+			Schema:      &Schema{Type: "boolean"},
+		},
+	}
+}
+
+// openAPIPath 将 gin 路径参数 :id、*key 转换为 {id}、{key}
+func openAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if len(s) > 1 && (s[0] == ':' || s[0] == '*') {
+			segments[i] = "{" + s[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// tagOf 以路径的第一段作为分组
+func tagOf(path string) string {
+	return strings.Split(strings.TrimPrefix(path, "/"), "/")[0]
+}
+
+// operationID 由处理函数名生成，如 product.(*ProductHandler).CreateProduct-fm → createProduct
+func operationID(handler string) string {
+	name := strings.TrimSuffix(handler, "-fm")
+	name = name[strings.LastIndex(name, ".")+1:]
+	if name == "" {
+		return ""
+	}
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
@@ -0,0 +1,278 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	enumType      = reflect.TypeOf((*enum)(nil)).Elem()
+)
+
+// enum 取值有限的字符串类型，与 model.Enum 一致
+type enum interface {
+	Values() []string
+}
+
+// schemaRegistry 由 Go 类型生成 Schema，具名结构体登记为可复用组件
+type schemaRegistry struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+// schemaOf 返回类型对应的 Schema，具名结构体返回组件引用
+func (r *schemaRegistry) schemaOf(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t.Kind() == reflect.String && t.Implements(enumType):
+		s := &Schema{Type: "string"}
+		for _, v := range reflect.Zero(t).Interface().(enum).Values() {
+			s.Enum = append(s.Enum, v)
+		}
+		return s
+	case t.Implements(marshalerType) || reflect.PtrTo(t).Implements(marshalerType):
+		// 自定义序列化的类型无法从结构推断
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: r.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProps: r.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.objectOf(t)
+		}
+		return r.ref(t)
+	}
+	// interface{} 等任意值
+	return &Schema{}
+}
+
+// ref 登记具名结构体并返回其引用
+func (r *schemaRegistry) ref(t reflect.Type) *Schema {
+	name, ok := r.names[t]
+	if !ok {
+		name = t.Name()
+		if _, taken := r.schemas[name]; taken {
+			// 不同包的同名类型以包名区分
+			name = pkgName(t) + name
+		}
+		r.names[t] = name
+		// 先占位，避免自引用的类型无限递归
+		r.schemas[name] = &Schema{}
+		*r.schemas[name] = *r.objectOf(t)
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// objectOf 按 json 标签生成结构体的对象 Schema，binding 标签转换为约束
+func (r *schemaRegistry) objectOf(t reflect.Type) *Schema {
+	return r.objectOfTag(t, "json")
+}
+
+// objectOfTag 按指定标签生成对象 Schema
+func (r *schemaRegistry) objectOfTag(t reflect.Type, tag string) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for _, f := range fields(t, tag) {
+		prop := r.schemaOf(f.Type)
+		if applyBinding(prop, f.Type, f.Tag.Get("binding")) {
+			s.Required = append(s.Required, f.name)
+		}
+		s.Properties[f.name] = prop
+	}
+	return s
+}
+
+// namedField 带有序列化名称的结构体字段
+type namedField struct {
+	reflect.StructField
+	name string
+}
+
+// fields 返回结构体按 tag 序列化的字段，展开匿名嵌入的结构体
+func fields(t reflect.Type, tag string) []namedField {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	var result []namedField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get(tag), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				result = append(result, fields(ft, tag)...)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		// 请求结构中只绑定路径或查询参数的字段不属于请求体
+		if tag == "json" && f.Tag.Get("json") == "" && (f.Tag.Get("uri") != "" || f.Tag.Get("form") != "") {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		result = append(result, namedField{StructField: f, name: name})
+	}
+	return result
+}
+
+// applyBinding 将 binding 标签中的校验规则写入 Schema，返回字段是否必填
+// dive 之后的规则作用于数组元素或 map 的值
+func applyBinding(s *Schema, t reflect.Type, tag string) bool {
+	if tag == "" {
+		return false
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	required := false
+	rules := strings.Split(tag, ",")
+	for i, rule := range rules {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			required = true
+		case "dive":
+			elem := s.Items
+			if elem == nil {
+				elem = s.AdditionalProps
+			}
+			if elem != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map) {
+				applyBinding(elem, t.Elem(), strings.Join(rules[i+1:], ","))
+			}
+			return required
+		case "oneof":
+			for _, v := range strings.Fields(param) {
+				s.Enum = append(s.Enum, enumValue(t, v))
+			}
+		case "email":
+			s.Format = "email"
+		case "uuid":
+			s.Format = "uuid"
+		case "url":
+			s.Format = "uri"
+		case "min", "gte", "gt", "max", "lte", "lt", "len":
+			applyBound(s, t, name, param)
+		}
+	}
+	return required
+}
+
+// applyBound 按类型将大小约束写为数值范围、长度或元素个数
+func applyBound(s *Schema, t reflect.Type, rule, param string) {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	lower := rule == "min" || rule == "gte" || rule == "len"
+	upper := rule == "max" || rule == "lte" || rule == "len"
+
+	switch t.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		size := int(n)
+		if rule == "gt" {
+			size, lower = size+1, true
+		}
+		if rule == "lt" {
+			size, upper = size-1, true
+		}
+		minPtr, maxPtr := &s.MinLength, &s.MaxLength
+		switch t.Kind() {
+		case reflect.Slice, reflect.Array:
+			minPtr, maxPtr = &s.MinItems, &s.MaxItems
+		case reflect.Map:
+			minPtr, maxPtr = &s.MinProperties, &s.MaxProperties
+		}
+		if lower {
+			*minPtr = &size
+		}
+		if upper {
+			*maxPtr = &size
+		}
+	default:
+		switch rule {
+		case "gt":
+			s.ExclusiveMinimum = &n
+		case "lt":
+			s.ExclusiveMaximum = &n
+		}
+		if lower {
+			s.Minimum = &n
+		}
+		if upper {
+			s.Maximum = &n
+		}
+	}
+}
+
+// enumValue 按字段类型转换 oneof 中的取值
+func enumValue(t reflect.Type, v string) interface{} {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return n
+		}
+	case reflect.Float32, reflect.Float64:
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return n
+		}
+	}
+	return v
+}
+
+// pkgName 返回类型所在包的名称，首字母大写
+func pkgName(t reflect.Type) string {
+	path := t.PkgPath()
+	name := path[strings.LastIndex(path, "/")+1:]
+	if name == "" {
+		return ""
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
@@ -0,0 +1,429 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Violation 请求或响应与文档不符之处
+type Violation struct {
+	// Field 字段路径，如 items[0].quantity；请求体整体出错时为 body
+	Field string
+	// Rule 未满足的 Schema 关键字，如 type、enum、required、additionalProperties
+	Rule string
+	// Param 规则的参数，如期望的类型、可选值或边界
+	Param string
+}
+
+// Message 英文说明
+func (v Violation) Message() string {
+	switch v.Rule {
+	case "required":
+		return v.Field + " is required"
+	case "type":
+		return v.Field + " must be of type " + v.Param
+	case "json":
+		return "request body must be valid JSON"
+	case "enum":
+		return v.Field + " must be one of: " + v.Param
+	case "additionalProperties":
+		return v.Field + " is not a recognized field"
+	case "format":
+		return v.Field + " must be a valid " + v.Param
+	}
+	return fmt.Sprintf("%s must satisfy %s %s", v.Field, v.Rule, v.Param)
+}
+
+// ValidationError 校验失败的全部不符之处
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.Message()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Validator 按 OpenAPI 文档校验请求与响应
+// 文档在所有路由注册后才能生成，因此先创建校验器、再通过 Load 载入文档
+type Validator struct {
+	doc atomic.Pointer[Document]
+}
+
+// NewValidator 创建尚未载入文档的校验器，载入前不做任何校验
+func NewValidator() *Validator {
+	return &Validator{}
+}
+
+// Load 载入文档
+func (v *Validator) Load(doc *Document) {
+	v.doc.Store(doc)
+}
+
+// Operation 查找 gin 路由对应的接口描述，未载入文档或路由未描述时返回 nil
+func (v *Validator) Operation(method, ginPath string) *Operation {
+	doc := v.doc.Load()
+	if doc == nil {
+		return nil
+	}
+	// 文档路径相对于接口地址前缀，未带前缀的旧路径别名按原路径查找
+	for _, server := range doc.Servers {
+		if path, ok := strings.CutPrefix(ginPath, server.URL+"/"); ok {
+			ginPath = "/" + path
+			break
+		}
+	}
+	item, ok := doc.Paths[openAPIPath(ginPath)]
+	if !ok {
+		return nil
+	}
+	return (*item)[strings.ToLower(method)]
+}
+
+// ValidateRequest 校验查询参数与 JSON 请求体，body 为已读取的请求体
+// 未声明的请求体字段视为错误；路径参数与请求头由处理器自行校验
+func (v *Validator) ValidateRequest(op *Operation, r *http.Request, body []byte) []Violation {
+	var violations []Violation
+	query := r.URL.Query()
+	for _, p := range op.Parameters {
+		if p.In != "query" || p.Style == "deepObject" {
+			continue
+		}
+		values, ok := query[p.Name]
+		if !ok || len(values) == 0 {
+			if p.Required {
+				violations = append(violations, Violation{Field: p.Name, Rule: "required"})
+			}
+			continue
+		}
+		value, ok := coerceParam(p.Schema, values[0])
+		if !ok {
+			violations = append(violations, Violation{Field: p.Name, Rule: "type", Param: p.Schema.Type})
+			continue
+		}
+		v.validate(p.Schema, value, p.Name, false, &violations)
+	}
+
+	if op.RequestBody == nil {
+		return violations
+	}
+	// 处理器绑定 JSON 时不看 Content-Type，因此无论声明的类型如何都按 JSON 校验；
+	// 内容类型是否可接受由 AcceptsContentType 单独判断
+	media, ok := op.RequestBody.Content["application/json"]
+	if !ok {
+		return violations
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		if op.RequestBody.Required {
+			violations = append(violations, Violation{Field: "body", Rule: "required"})
+		}
+		return violations
+	}
+	value, err := decodeJSON(body)
+	if err != nil {
+		return append(violations, Violation{Field: "body", Rule: "json"})
+	}
+	v.validate(media.Schema, value, "", true, &violations)
+	return violations
+}
+
+// AcceptsContentType 请求的内容类型是否为操作声明的请求体类型之一，没有请求体的操作不做限制
+// 声明了 application/json 的操作同时接受 +json 后缀的类型
+func (op *Operation) AcceptsContentType(contentType string) bool {
+	if op.RequestBody == nil {
+		return true
+	}
+	if _, ok := op.RequestBody.Content["application/json"]; ok && isJSON(contentType) {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	_, ok := op.RequestBody.Content[mediaType]
+	return ok
+}
+
+// ValidateResponse 校验 JSON 响应体，状态码没有单独描述时按 default 响应校验
+func (v *Validator) ValidateResponse(op *Operation, status int, contentType string, body []byte) []Violation {
+	resp, ok := op.Responses[strconv.Itoa(status)]
+	if !ok {
+		if resp, ok = op.Responses["default"]; !ok {
+			return nil
+		}
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	media, ok := resp.Content[mediaType]
+	if !ok || media.Schema == nil || !isJSON(contentType) {
+		return nil
+	}
+	value, err := decodeJSON(body)
+	if err != nil {
+		return []Violation{{Field: "body", Rule: "json"}}
+	}
+	var violations []Violation
+	v.validate(media.Schema, value, "", true, &violations)
+	return violations
+}
+
+// validate 按 Schema 校验已解码的 JSON 值
+// strict 为 true 时对象中出现未声明的字段视为错误；null 总是允许，必填由 required 约束
+func (v *Validator) validate(s *Schema, value interface{}, path string, strict bool, violations *[]Violation) {
+	s = v.resolve(s)
+	if s == nil || value == nil {
+		return
+	}
+
+	if len(s.AllOf) > 0 {
+		for _, sub := range s.AllOf {
+			v.validate(sub, value, path, false, violations)
+		}
+		if obj, ok := value.(map[string]interface{}); ok && strict {
+			v.checkUnknown(s, obj, path, violations)
+		}
+		return
+	}
+
+	if s.Type != "" && !matchesType(s.Type, value) {
+		*violations = append(*violations, Violation{Field: fieldName(path), Rule: "type", Param: s.Type})
+		return
+	}
+	if len(s.Enum) > 0 && !inEnum(s.Enum, value) {
+		*violations = append(*violations, Violation{Field: fieldName(path), Rule: "enum", Param: joinEnum(s.Enum)})
+	}
+
+	switch val := value.(type) {
+	case json.Number:
+		v.checkNumber(s, val, path, violations)
+	case string:
+		n := len([]rune(val))
+		if s.MinLength != nil && n < *s.MinLength {
+			*violations = append(*violations, Violation{Field: fieldName(path), Rule: "minLength", Param: strconv.Itoa(*s.MinLength)})
+		}
+		if s.MaxLength != nil && n > *s.MaxLength {
+			*violations = append(*violations, Violation{Field: fieldName(path), Rule: "maxLength", Param: strconv.Itoa(*s.MaxLength)})
+		}
+		if s.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339, val); err != nil {
+				*violations = append(*violations, Violation{Field: fieldName(path), Rule: "format", Param: s.Format})
+			}
+		}
+	case []interface{}:
+		if s.MinItems != nil && len(val) < *s.MinItems {
+			*violations = append(*violations, Violation{Field: fieldName(path), Rule: "minItems", Param: strconv.Itoa(*s.MinItems)})
+		}
+		if s.MaxItems != nil && len(val) > *s.MaxItems {
+			*violations = append(*violations, Violation{Field: fieldName(path), Rule: "maxItems", Param: strconv.Itoa(*s.MaxItems)})
+		}
+		for i, item := range val {
+			v.validate(s.Items, item, fmt.Sprintf("%s[%d]", path, i), strict, violations)
+		}
+	case map[string]interface{}:
+		v.checkObject(s, val, path, strict, violations)
+	}
+}
+
+// checkNumber 校验数值范围
+func (v *Validator) checkNumber(s *Schema, n json.Number, path string, violations *[]Violation) {
+	f, err := n.Float64()
+	if err != nil {
+		return
+	}
+	bounds := []struct {
+		rule  string
+		limit *float64
+		fails func(limit float64) bool
+	}{
+		{"minimum", s.Minimum, func(l float64) bool { return f < l }},
+		{"maximum", s.Maximum, func(l float64) bool { return f > l }},
+		{"exclusiveMinimum", s.ExclusiveMinimum, func(l float64) bool { return f <= l }},
+		{"exclusiveMaximum", s.ExclusiveMaximum, func(l float64) bool { return f >= l }},
+	}
+	for _, b := range bounds {
+		if b.limit != nil && b.fails(*b.limit) {
+			*violations = append(*violations, Violation{
+				Field: fieldName(path),
+				Rule:  b.rule,
+				Param: strconv.FormatFloat(*b.limit, 'f', -1, 64),
+			})
+		}
+	}
+}
+
+// checkObject 校验对象的必填字段、各字段的值以及未声明的字段
+func (v *Validator) checkObject(s *Schema, obj map[string]interface{}, path string, strict bool, violations *[]Violation) {
+	for _, name := range s.Required {
+		if _, ok := obj[name]; !ok {
+			*violations = append(*violations, Violation{Field: join(path, name), Rule: "required"})
+		}
+	}
+	if s.MinProperties != nil && len(obj) < *s.MinProperties {
+		*violations = append(*violations, Violation{Field: fieldName(path), Rule: "minProperties", Param: strconv.Itoa(*s.MinProperties)})
+	}
+
+	for _, name := range sortedKeys(obj) {
+		if prop, ok := s.Properties[name]; ok {
+			v.validate(prop, obj[name], join(path, name), strict, violations)
+		} else if s.AdditionalProps != nil {
+			v.validate(s.AdditionalProps, obj[name], join(path, name), strict, violations)
+		}
+	}
+	if strict && s.AdditionalProps == nil && s.Properties != nil {
+		v.checkUnknown(s, obj, path, violations)
+	}
+}
+
+// checkUnknown 报告 Schema（含 allOf 各部分）中都未声明的字段
+func (v *Validator) checkUnknown(s *Schema, obj map[string]interface{}, path string, violations *[]Violation) {
+	known := make(map[string]bool)
+	v.collectProperties(s, known)
+	for _, name := range sortedKeys(obj) {
+		if !known[name] {
+			*violations = append(*violations, Violation{Field: join(path, name), Rule: "additionalProperties"})
+		}
+	}
+}
+
+func (v *Validator) collectProperties(s *Schema, known map[string]bool) {
+	s = v.resolve(s)
+	if s == nil {
+		return
+	}
+	for name := range s.Properties {
+		known[name] = true
+	}
+	for _, sub := range s.AllOf {
+		v.collectProperties(sub, known)
+	}
+}
+
+// resolve 解析组件引用
+func (v *Validator) resolve(s *Schema) *Schema {
+	for s != nil && s.Ref != "" {
+		doc := v.doc.Load()
+		if doc == nil {
+			return nil
+		}
+		s = doc.Components.Schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+	}
+	return s
+}
+
+// coerceParam 将查询参数字符串按 Schema 类型转换为 JSON 值
+func coerceParam(s *Schema, raw string) (interface{}, bool) {
+	switch s.Type {
+	case "integer":
+		if _, err := strconv.ParseInt(raw, 10, 64); err != nil {
+			return nil, false
+		}
+		return json.Number(raw), true
+	case "number":
+		if _, err := strconv.ParseFloat(raw, 64); err != nil {
+			return nil, false
+		}
+		return json.Number(raw), true
+	case "boolean":
+		b, err := strconv.ParseBool(raw)
+		return b, err == nil
+	}
+	return raw, true
+}
+
+// matchesType 判断值是否符合 JSON Schema 类型
+func matchesType(typ string, value interface{}) bool {
+	switch val := value.(type) {
+	case bool:
+		return typ == "boolean"
+	case string:
+		return typ == "string"
+	case json.Number:
+		if typ == "number" {
+			return true
+		}
+		if typ == "integer" {
+			f, err := val.Float64()
+			return err == nil && f == float64(int64(f))
+		}
+		return false
+	case []interface{}:
+		return typ == "array"
+	case map[string]interface{}:
+		return typ == "object"
+	}
+	return false
+}
+
+// inEnum 判断值是否为可选值之一，数值按大小比较
+func inEnum(enum []interface{}, value interface{}) bool {
+	for _, e := range enum {
+		if fmt.Sprint(e) == fmt.Sprint(value) {
+			return true
+		}
+		if n, ok := value.(json.Number); ok {
+			if f, err := n.Float64(); err == nil {
+				if ef, err := strconv.ParseFloat(fmt.Sprint(e), 64); err == nil && ef == f {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func joinEnum(enum []interface{}) string {
+	values := make([]string, len(enum))
+	for i, e := range enum {
+		values[i] = fmt.Sprint(e)
+	}
+	return strings.Join(values, ", ")
+}
+
+func decodeJSON(body []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"))
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// fieldName 请求体根节点以 body 表示
+func fieldName(path string) string {
+	if path == "" {
+		return "body"
+	}
+	return path
+}
+
+func sortedKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,64 @@
+package pricing
+
+import (
+	"context"
+	"math"
+
+	"github.com/innovationmech/simple-cli/internal/config"
+	"github.com/innovationmech/simple-cli/internal/interfaces"
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/repository"
+)
+
+// Step 计价步骤
+// 每个步骤读取并补充订单上的金额字段，按加入 Pipeline 的顺序依次执行
+type Step interface {
+	Name() string
+	Apply(ctx context.Context, order *model.Order) error
+}
+
+// Pipeline 订单计价流水线
+type Pipeline struct {
+	steps []Step
+}
+
+// NewPipeline 使用给定步骤创建计价流水线
+func NewPipeline(steps ...Step) *Pipeline {
+	return &Pipeline{steps: steps}
+}
+
+// NewDefaultPipeline 创建默认计价流水线：商品小计 → 优惠 → 运费 → 税费
+// 运费与税率从配置项 pricing 读取，此函数将作为 Wire Provider 使用
+func NewDefaultPipeline(productRepo repository.ProductRepository, promotionSrv interfaces.PromotionService) *Pipeline {
+	cfg := config.Pricing()
+	return NewPipeline(
+		&SubtotalStep{ProductRepo: productRepo},
+		&DiscountStep{PromotionService: promotionSrv},
+		&ShippingStep{Config: cfg.Shipping},
+		&TaxStep{Config: cfg.Tax},
+	)
+}
+
+// Price 依次执行所有步骤并计算订单应付金额
+// 只计算金额，不扣减库存也不核销优惠券，可用于下单前的试算
+func (p *Pipeline) Price(ctx context.Context, order *model.Order) error {
+	order.SubtotalAmount = 0
+	order.DiscountAmount = 0
+	order.Discounts = nil
+	order.ShippingAmount = 0
+	order.TaxAmount = 0
+
+	for _, step := range p.steps {
+		if err := step.Apply(ctx, order); err != nil {
+			return err
+		}
+	}
+
+	order.TotalAmount = Round(order.SubtotalAmount - order.DiscountAmount + order.ShippingAmount + order.TaxAmount)
+	return nil
+}
+
+// Round 金额按四舍五入保留两位小数
+func Round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
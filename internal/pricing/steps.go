@@ -0,0 +1,157 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/innovationmech/simple-cli/internal/config"
+	"github.com/innovationmech/simple-cli/internal/domain"
+	"github.com/innovationmech/simple-cli/internal/interfaces"
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/repository"
+	"gorm.io/gorm"
+)
+
+// SubtotalStep 按下单时刻商品（或 SKU）的售价（含促销价）计算每行金额与商品小计，并校验库存
+type SubtotalStep struct {
+	ProductRepo repository.ProductRepository
+}
+
+func (s *SubtotalStep) Name() string { return "subtotal" }
+
+func (s *SubtotalStep) Apply(ctx context.Context, order *model.Order) error {
+	now := time.Now()
+	// 同一商品（或 SKU）可能出现在多行中，库存按合计数量校验
+	requested := make(map[string]int)
+	for _, item := range order.Items {
+		requested[item.ProductID+"/"+item.SKUID] += item.Quantity
+	}
+	for i := range order.Items {
+		item := &order.Items[i]
+		product, err := s.ProductRepo.GetProduct(ctx, item.ProductID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.Errorf(domain.CodeNotFound, "product %s not found", item.ProductID)
+			}
+			return err
+		}
+
+		sku, err := product.SelectSKU(item.SKUID)
+		if err != nil {
+			return err
+		}
+
+		// 检查库存
+		if product.AvailableStock(sku) < requested[item.ProductID+"/"+item.SKUID] {
+			return repository.ErrInsufficientStock
+		}
+
+		item.ProductName = product.Name
+		if sku != nil {
+			item.SKUCode = sku.Code
+			item.Attributes = sku.Attributes
+		}
+		item.UnitPrice = product.UnitPriceAt(sku, now)
+		item.TaxClass = product.TaxClass
+		item.Amount = Round(item.UnitPrice * float64(item.Quantity))
+		order.SubtotalAmount += item.Amount
+	}
+	order.SubtotalAmount = Round(order.SubtotalAmount)
+	return nil
+}
+
+// DiscountStep 计算优惠券优惠，并将每张券的优惠分摊到其适用的商品行
+type DiscountStep struct {
+	PromotionService interfaces.PromotionService
+}
+
+func (s *DiscountStep) Name() string { return "discount" }
+
+func (s *DiscountStep) Apply(ctx context.Context, order *model.Order) error {
+	for i := range order.Items {
+		order.Items[i].DiscountAmount = 0
+	}
+
+	discounts, err := s.PromotionService.CalculateDiscounts(ctx, order.UserID, order.Items, order.CouponCodes)
+	if err != nil {
+		return err
+	}
+	order.Discounts = discounts
+	for _, d := range discounts {
+		order.DiscountAmount += d.Amount
+		allocateDiscount(order.Items, d)
+	}
+	order.DiscountAmount = Round(order.DiscountAmount)
+	return nil
+}
+
+// allocateDiscount 按商品行金额比例分摊一张券的优惠，尾差计入最后一行
+func allocateDiscount(items []model.OrderItem, discount model.OrderDiscount) {
+	targets := make(map[string]bool, len(discount.ProductIDs))
+	for _, id := range discount.ProductIDs {
+		targets[id] = true
+	}
+
+	var eligible []int
+	base := 0.0
+	for i, item := range items {
+		if len(targets) == 0 || targets[item.ProductID] {
+			eligible = append(eligible, i)
+			base += item.Amount
+		}
+	}
+	if base <= 0 {
+		return
+	}
+
+	remaining := discount.Amount
+	for n, i := range eligible {
+		share := remaining
+		if n < len(eligible)-1 {
+			share = Round(discount.Amount * items[i].Amount / base)
+			remaining -= share
+		}
+		items[i].DiscountAmount = Round(items[i].DiscountAmount + share)
+	}
+}
+
+// ShippingStep 按收货地区计算运费，优惠后金额达到包邮门槛时免运费
+type ShippingStep struct {
+	Config config.ShippingConfig
+}
+
+func (s *ShippingStep) Name() string { return "shipping" }
+
+func (s *ShippingStep) Apply(ctx context.Context, order *model.Order) error {
+	net := order.SubtotalAmount - order.DiscountAmount
+	if s.Config.FreeThreshold > 0 && net >= s.Config.FreeThreshold {
+		order.ShippingAmount = 0
+		return nil
+	}
+	order.ShippingAmount = Round(s.Config.ShippingFee(order.ShippingAddress.Province))
+	return nil
+}
+
+// TaxStep 按收货地区与商品税类计算税费
+// 税基为商品行扣除分摊优惠后的金额，税费逐行四舍五入后汇总；运费不计税
+type TaxStep struct {
+	Config config.TaxConfig
+}
+
+func (s *TaxStep) Name() string { return "tax" }
+
+func (s *TaxStep) Apply(ctx context.Context, order *model.Order) error {
+	region := order.ShippingAddress.Province
+	for i := range order.Items {
+		item := &order.Items[i]
+		if item.TaxClass == "" {
+			item.TaxClass = s.Config.DefaultClass
+		}
+		item.TaxRate = s.Config.TaxRate(region, item.TaxClass)
+		item.TaxAmount = Round((item.Amount - item.DiscountAmount) * item.TaxRate)
+		order.TaxAmount += item.TaxAmount
+	}
+	order.TaxAmount = Round(order.TaxAmount)
+	return nil
+}
@@ -0,0 +1,139 @@
+package queryspec
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	cursorKeyMu sync.RWMutex
+	// cursorKey 游标签名密钥，未配置时进程启动后随机生成，重启后旧游标失效
+	cursorKey = randomKey()
+)
+
+// SetCursorSecret 设置游标签名密钥，多实例部署时各实例需使用相同的密钥
+func SetCursorSecret(secret string) {
+	if secret == "" {
+		return
+	}
+	cursorKeyMu.Lock()
+	defer cursorKeyMu.Unlock()
+	cursorKey = []byte(secret)
+}
+
+func randomKey() []byte {
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+	return key
+}
+
+// Cursor 游标，记录分页边界行的排序键
+// Backward 为 true 时表示取该行之前的一页
+type Cursor struct {
+	Values   []interface{}
+	Backward bool
+}
+
+// cursorPayload 游标的序列化结构，Sort 用于拒绝与当前排序不一致的游标
+type cursorPayload struct {
+	Sort     string        `json:"s"`
+	Values   []interface{} `json:"v"`
+	Backward bool          `json:"b,omitempty"`
+}
+
+// encodeCursor 生成签名的游标：base64(payload).base64(hmac)
+func encodeCursor(sortKey string, values []interface{}, backward bool) string {
+	raw, _ := json.Marshal(cursorPayload{Sort: sortKey, Values: values, Backward: backward})
+	body := base64.RawURLEncoding.EncodeToString(raw)
+	return body + "." + base64.RawURLEncoding.EncodeToString(sign(body))
+}
+
+// decodeCursor 校验签名并解析游标，取值按排序字段类型还原
+func decodeCursor(token, sortKey string, columns []orderColumn) (*Cursor, error) {
+	body, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, errorf("invalid cursor")
+	}
+	got, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(got, sign(body)) {
+		return nil, errorf("invalid cursor")
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(body)
+	if err != nil {
+		return nil, errorf("invalid cursor")
+	}
+	var payload cursorPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, errorf("invalid cursor")
+	}
+	if payload.Sort != sortKey {
+		return nil, errorf("cursor was issued for sort %q and cannot be used with sort %q", payload.Sort, sortKey)
+	}
+	if len(payload.Values) != len(columns) {
+		return nil, errorf("invalid cursor")
+	}
+
+	cursor := &Cursor{Backward: payload.Backward}
+	for i, v := range payload.Values {
+		value, err := columns[i].typ.fromCursor(v)
+		if err != nil {
+			return nil, errorf("invalid cursor")
+		}
+		cursor.Values = append(cursor.Values, value)
+	}
+	return cursor, nil
+}
+
+func sign(body string) []byte {
+	cursorKeyMu.RLock()
+	defer cursorKeyMu.RUnlock()
+	mac := hmac.New(sha256.New, cursorKey)
+	mac.Write([]byte(body))
+	return mac.Sum(nil)[:16]
+}
+
+// toCursor 将排序键转换为可 JSON 序列化的值，时间使用纳秒精度的 RFC 3339
+func toCursor(v interface{}) interface{} {
+	switch t := v.(type) {
+	case time.Time:
+		return t.Format(time.RFC3339Nano)
+	case *time.Time:
+		if t == nil {
+			return nil
+		}
+		return t.Format(time.RFC3339Nano)
+	default:
+		return v
+	}
+}
+
+// fromCursor 将游标中的值还原为字段类型对应的值
+func (t Type) fromCursor(v interface{}) (interface{}, error) {
+	switch t {
+	case Time:
+		s, ok := v.(string)
+		if !ok {
+			return nil, errorf("invalid time")
+		}
+		return time.Parse(time.RFC3339Nano, s)
+	case Number:
+		if f, ok := v.(float64); ok {
+			return f, nil
+		}
+	case Bool:
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+	default:
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+	}
+	return nil, errorf("invalid value")
+}
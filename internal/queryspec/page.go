@@ -0,0 +1,194 @@
+package queryspec
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// defaultLimit 未指定每页数量时的默认值
+const defaultLimit = 10
+
+// PageInfo 分页信息，嵌入各列表响应
+// Total 在 include_total=false 时省略；NextCursor/PrevCursor 为空表示没有下一页/上一页
+type PageInfo struct {
+	Total      *int64 `json:"total,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// orderColumn 参与排序的列，最后一列总是 TieBreaker
+type orderColumn struct {
+	column string
+	desc   bool
+	typ    Type
+}
+
+// SetPage 设置页码模式的分页参数；游标模式下 page 被忽略，只使用 pageSize
+func (s *Spec) SetPage(page, pageSize int) {
+	if pageSize <= 0 {
+		pageSize = defaultLimit
+	}
+	if page <= 0 {
+		page = 1
+	}
+	s.Limit = pageSize
+	s.Offset = (page - 1) * pageSize
+}
+
+// Find 执行分页查询并将结果写入 dest（指向切片的指针）
+// 页码模式使用 OFFSET；游标模式按排序键做 keyset 查询，不受前面数据增删的影响。
+// 两种模式都会在有后续数据时返回 next_cursor，便于从页码模式切换到游标模式
+func (s *Spec) Find(db *gorm.DB, dest interface{}) (*PageInfo, error) {
+	info := &PageInfo{}
+	if !s.SkipTotal {
+		var total int64
+		if err := db.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+			return nil, err
+		}
+		info.Total = &total
+	}
+
+	limit := s.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	columns := s.orderColumns()
+	backward := s.Cursor != nil && s.Cursor.Backward
+
+	query := db
+	if s.Cursor != nil {
+		cond, args := keyset(columns, s.Cursor.Values, backward)
+		query = query.Where(cond, args...)
+	} else if s.Offset > 0 {
+		query = query.Offset(s.Offset)
+	}
+	for _, c := range columns {
+		if c.desc != backward {
+			query = query.Order(c.column + " DESC")
+		} else {
+			query = query.Order(c.column + " ASC")
+		}
+	}
+	if err := query.Limit(limit + 1).Find(dest).Error; err != nil {
+		return nil, err
+	}
+
+	rows := reflect.ValueOf(dest).Elem()
+	hasMore := rows.Len() > limit
+	if hasMore {
+		rows.Set(rows.Slice(0, limit))
+	}
+	if backward {
+		reverse(rows)
+	}
+	if rows.Len() == 0 {
+		return info, nil
+	}
+
+	hasNext := hasMore || backward
+	hasPrev := (backward && hasMore) || (!backward && (s.Cursor != nil || s.Offset > 0))
+	if hasNext || hasPrev {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(dest); err != nil {
+			return nil, err
+		}
+		sortKey := s.sortKey()
+		if hasNext {
+			values, err := rowValues(stmt, columns, rows.Index(rows.Len()-1))
+			if err != nil {
+				return nil, err
+			}
+			info.NextCursor = encodeCursor(sortKey, values, false)
+		}
+		if hasPrev {
+			values, err := rowValues(stmt, columns, rows.Index(0))
+			if err != nil {
+				return nil, err
+			}
+			info.PrevCursor = encodeCursor(sortKey, values, true)
+		}
+	}
+	return info, nil
+}
+
+// orderColumns 返回实际生效的排序列（用户排序或默认排序，加上 TieBreaker）
+func (s *Spec) orderColumns() []orderColumn {
+	sorts := s.Sorts
+	if len(sorts) == 0 {
+		sorts = s.schema.DefaultSort
+	}
+	columns := make([]orderColumn, 0, len(sorts)+1)
+	for _, order := range sorts {
+		field, _ := s.schema.field(order.Field)
+		columns = append(columns, orderColumn{column: field.Column, desc: order.Desc, typ: field.Type})
+	}
+	return append(columns, orderColumn{column: s.schema.TieBreaker, typ: s.schema.TieBreakerType})
+}
+
+// sortKey 排序的规范表示，写入游标以校验游标与排序一致
+func (s *Spec) sortKey() string {
+	sorts := s.Sorts
+	if len(sorts) == 0 {
+		sorts = s.schema.DefaultSort
+	}
+	parts := make([]string, 0, len(sorts))
+	for _, order := range sorts {
+		if order.Desc {
+			parts = append(parts, "-"+order.Field)
+		} else {
+			parts = append(parts, order.Field)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// keyset 生成“位于游标行之后”的条件，例如排序 (a ASC, id ASC) 时为
+// (a > ?) OR (a = ? AND id > ?)；backward 为 true 时取游标行之前的数据
+func keyset(columns []orderColumn, values []interface{}, backward bool) (string, []interface{}) {
+	var ors []string
+	var args []interface{}
+	for i, c := range columns {
+		var ands []string
+		for j := 0; j < i; j++ {
+			ands = append(ands, columns[j].column+" = ?")
+			args = append(args, values[j])
+		}
+		op := ">"
+		if c.desc != backward {
+			op = "<"
+		}
+		ands = append(ands, c.column+" "+op+" ?")
+		args = append(args, values[i])
+		ors = append(ors, "("+strings.Join(ands, " AND ")+")")
+	}
+	return "(" + strings.Join(ors, " OR ") + ")", args
+}
+
+// rowValues 读取一行记录在各排序列上的取值
+func rowValues(stmt *gorm.Statement, columns []orderColumn, row reflect.Value) ([]interface{}, error) {
+	values := make([]interface{}, 0, len(columns))
+	for _, c := range columns {
+		name := c.column
+		if i := strings.LastIndex(name, "."); i >= 0 {
+			name = name[i+1:]
+		}
+		field := stmt.Schema.LookUpField(name)
+		if field == nil {
+			return nil, errorf("unknown sort column %q", c.column)
+		}
+		value, _ := field.ValueOf(context.Background(), reflect.Indirect(row))
+		values = append(values, toCursor(value))
+	}
+	return values, nil
+}
+
+func reverse(rows reflect.Value) {
+	for i, j := 0, rows.Len()-1; i < j; i, j = i+1, j-1 {
+		a, b := rows.Index(i).Interface(), rows.Index(j).Interface()
+		rows.Index(i).Set(reflect.ValueOf(b))
+		rows.Index(j).Set(reflect.ValueOf(a))
+	}
+}
@@ -0,0 +1,38 @@
+package queryspec
+
+import "encoding/json"
+
+// Project 按 fields 裁剪列表响应中 listKey 对应数组的每个元素
+// 未指定 fields 时原样返回 data
+func (s *Spec) Project(data interface{}, listKey string) interface{} {
+	if s == nil || len(s.Fields) == 0 {
+		return data
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return data
+	}
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return data
+	}
+	var items []map[string]json.RawMessage
+	if err := json.Unmarshal(envelope[listKey], &items); err != nil {
+		return data
+	}
+
+	projected := make([]map[string]json.RawMessage, 0, len(items))
+	for _, item := range items {
+		picked := make(map[string]json.RawMessage, len(s.Fields))
+		for _, field := range s.Fields {
+			if v, ok := item[field]; ok {
+				picked[field] = v
+			}
+		}
+		projected = append(projected, picked)
+	}
+	if envelope[listKey], err = json.Marshal(projected); err != nil {
+		return data
+	}
+	return envelope
+}
@@ -0,0 +1,105 @@
+package queryspec
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Field 白名单中的字段定义
+// Name 为查询参数中使用的名称，Column 为对应的数据库列（建议带表名前缀，避免联表时歧义）
+type Field struct {
+	Name       string
+	Column     string
+	Type       Type
+	Sortable   bool
+	Filterable bool
+	// Nullable 列可能为 NULL，按该列排序时不支持游标分页
+	Nullable bool
+}
+
+// Schema 实体的查询白名单
+// Selectable 为 fields 参数可选择的响应字段，通常由 JSONFields 从响应结构生成
+type Schema struct {
+	Fields      []Field
+	Selectable  []string
+	DefaultSort []Sort
+	// TieBreaker 排序的最后一列，应为主键以保证分页稳定
+	TieBreaker string
+	// TieBreakerType TieBreaker 列的类型，默认为 String，自增主键应设为 Number
+	TieBreakerType Type
+}
+
+func (s *Schema) field(name string) (Field, bool) {
+	for _, f := range s.Fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+// Sortable 返回可排序的字段名
+func (s *Schema) Sortable() []string {
+	var names []string
+	for _, f := range s.Fields {
+		if f.Sortable {
+			names = append(names, f.Name)
+		}
+	}
+	return names
+}
+
+// Filterable 返回可过滤的字段名
+func (s *Schema) Filterable() []string {
+	var names []string
+	for _, f := range s.Fields {
+		if f.Filterable {
+			names = append(names, f.Name)
+		}
+	}
+	return names
+}
+
+func (s *Schema) selectable(name string) bool {
+	for _, f := range s.Selectable {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+// JSONFields 返回结构体的顶层 JSON 字段名（包含匿名嵌入结构体的字段）
+func JSONFields(v interface{}) []string {
+	seen := map[string]bool{}
+	collectJSONFields(reflect.TypeOf(v), seen)
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func collectJSONFields(t reflect.Type, seen map[string]bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if f.Anonymous && tag == "" {
+			collectJSONFields(f.Type, seen)
+			continue
+		}
+		if !f.IsExported() || tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if name == "" {
+			name = f.Name
+		}
+		seen[name] = true
+	}
+}
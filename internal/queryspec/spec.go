@@ -0,0 +1,216 @@
+package queryspec
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// maxSorts 单次查询最多使用的排序字段数
+const maxSorts = 3
+
+// filterKey 匹配 filter[field] 与 filter[field][op] 两种参数名
+var filterKey = regexp.MustCompile(`^filter\[([a-z_]+)\](?:\[([a-z]+)\])?$`)
+
+// Spec 列表查询规格，由查询参数解析并经 Schema 校验
+//
+//	sort=-created_at,price         多字段排序，- 前缀表示降序
+//	filter[price][gte]=10          按字段与操作符过滤，省略操作符时为 eq
+//	filter[status][in]=paid,shipped
+//	fields=id,name                 只返回指定字段
+//	cursor=<token>                 游标分页，取值为上次响应的 next_cursor 或 prev_cursor
+//	include_total=false            不统计总数
+type Spec struct {
+	Sorts   []Sort
+	Filters []Filter
+	Fields  []string
+
+	// 分页：Offset/Limit 为页码模式，Cursor 非空时为游标模式并忽略 Offset
+	Offset int
+	Limit  int
+	Cursor *Cursor
+	// SkipTotal 为 true 时不执行 COUNT 查询
+	SkipTotal bool
+
+	schema *Schema
+}
+
+// Sort 排序条件
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// Filter 过滤条件，Values 为按字段类型转换后的值
+type Filter struct {
+	Field  string
+	Op     Operator
+	Values []interface{}
+}
+
+// Error 查询规格不合法，调用方应返回 400
+type Error struct {
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func errorf(format string, args ...interface{}) error {
+	return &Error{Message: fmt.Sprintf(format, args...)}
+}
+
+// Parse 从查询参数中解析排序、过滤与字段选择，并按 schema 白名单校验
+// 与列表专用参数（page、user_id 等）共存，未识别的其他参数会被忽略
+func Parse(values url.Values, schema *Schema) (*Spec, error) {
+	spec := &Spec{schema: schema}
+
+	if raw := values.Get("sort"); raw != "" {
+		sorts, err := parseSort(raw, schema)
+		if err != nil {
+			return nil, err
+		}
+		spec.Sorts = sorts
+	}
+
+	// 按参数名排序，保证多个非法参数时报错稳定
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		if strings.HasPrefix(key, "filter") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		vals := values[key]
+		m := filterKey.FindStringSubmatch(key)
+		if m == nil {
+			return nil, errorf("invalid filter parameter %q, expected filter[field] or filter[field][op]", key)
+		}
+		filter, err := parseFilter(m[1], m[2], vals, schema)
+		if err != nil {
+			return nil, err
+		}
+		spec.Filters = append(spec.Filters, filter)
+	}
+
+	if raw := values.Get("fields"); raw != "" {
+		fields, err := parseFields(raw, schema)
+		if err != nil {
+			return nil, err
+		}
+		spec.Fields = fields
+	}
+
+	if raw := values.Get("include_total"); raw != "" {
+		include, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, errorf("invalid include_total %q, must be true or false", raw)
+		}
+		spec.SkipTotal = !include
+	}
+
+	if token := values.Get("cursor"); token != "" {
+		columns := spec.orderColumns()
+		for _, order := range spec.Sorts {
+			if field, _ := schema.field(order.Field); field.Nullable {
+				return nil, errorf("cursor pagination does not support sorting by nullable field %q", order.Field)
+			}
+		}
+		cursor, err := decodeCursor(token, spec.sortKey(), columns)
+		if err != nil {
+			return nil, err
+		}
+		spec.Cursor = cursor
+	}
+	return spec, nil
+}
+
+// Default 返回仅包含默认排序的查询规格，供内部调用使用
+func (s *Schema) Default() *Spec {
+	return &Spec{schema: s}
+}
+
+func parseSort(raw string, schema *Schema) ([]Sort, error) {
+	var sorts []Sort
+	seen := map[string]bool{}
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		desc := strings.HasPrefix(part, "-")
+		name := strings.TrimPrefix(part, "-")
+		field, ok := schema.field(name)
+		if !ok || !field.Sortable {
+			return nil, errorf("cannot sort by %q, sortable fields: %s", name, strings.Join(schema.Sortable(), ", "))
+		}
+		if seen[name] {
+			return nil, errorf("duplicate sort field %q", name)
+		}
+		seen[name] = true
+		sorts = append(sorts, Sort{Field: name, Desc: desc})
+	}
+	if len(sorts) > maxSorts {
+		return nil, errorf("at most %d sort fields are allowed", maxSorts)
+	}
+	return sorts, nil
+}
+
+func parseFilter(name, op string, vals []string, schema *Schema) (Filter, error) {
+	field, ok := schema.field(name)
+	if !ok || !field.Filterable {
+		return Filter{}, errorf("cannot filter by %q, filterable fields: %s", name, strings.Join(schema.Filterable(), ", "))
+	}
+	if op == "" {
+		op = string(OpEq)
+	}
+	operator := Operator(op)
+	if !field.Type.allows(operator) {
+		return Filter{}, errorf("operator %q is not supported for %q, supported operators: %s", op, name, field.Type.operatorList())
+	}
+	if len(vals) != 1 {
+		return Filter{}, errorf("filter[%s][%s] must be given once", name, op)
+	}
+
+	raws := []string{vals[0]}
+	if operator == OpIn {
+		raws = strings.Split(vals[0], ",")
+	}
+	filter := Filter{Field: name, Op: operator}
+	for _, raw := range raws {
+		value, err := field.Type.convert(strings.TrimSpace(raw))
+		if err != nil {
+			return Filter{}, errorf("invalid value %q for filter[%s][%s]: %s", raw, name, op, err.Error())
+		}
+		filter.Values = append(filter.Values, value)
+	}
+	return filter, nil
+}
+
+func parseFields(raw string, schema *Schema) ([]string, error) {
+	var fields []string
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if !schema.selectable(name) {
+			return nil, errorf("unknown field %q, available fields: %s", name, strings.Join(schema.Selectable, ", "))
+		}
+		fields = append(fields, name)
+	}
+	return fields, nil
+}
+
+// ApplyFilters 返回追加过滤条件的查询，应在统计总数之前调用
+func (s *Spec) ApplyFilters(db *gorm.DB) *gorm.DB {
+	if s == nil {
+		return db
+	}
+	for _, f := range s.Filters {
+		field, _ := s.schema.field(f.Field)
+		db = f.Op.apply(db, field.Column, f.Values)
+	}
+	return db
+}
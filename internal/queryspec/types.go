@@ -0,0 +1,116 @@
+package queryspec
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Type 字段类型，决定可用的操作符与取值的解析方式
+type Type int
+
+const (
+	String Type = iota
+	Number
+	Time
+	Bool
+)
+
+// Operator 过滤操作符
+type Operator string
+
+const (
+	OpEq   Operator = "eq"
+	OpNe   Operator = "ne"
+	OpGt   Operator = "gt"
+	OpGte  Operator = "gte"
+	OpLt   Operator = "lt"
+	OpLte  Operator = "lte"
+	OpIn   Operator = "in"
+	OpLike Operator = "like"
+)
+
+// operators 各字段类型支持的操作符
+var operators = map[Type][]Operator{
+	String: {OpEq, OpNe, OpIn, OpLike},
+	Number: {OpEq, OpNe, OpGt, OpGte, OpLt, OpLte, OpIn},
+	Time:   {OpEq, OpGt, OpGte, OpLt, OpLte},
+	Bool:   {OpEq},
+}
+
+// sqlOperators 比较操作符对应的 SQL
+var sqlOperators = map[Operator]string{
+	OpEq:  "=",
+	OpNe:  "<>",
+	OpGt:  ">",
+	OpGte: ">=",
+	OpLt:  "<",
+	OpLte: "<=",
+}
+
+func (t Type) allows(op Operator) bool {
+	for _, o := range operators[t] {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (t Type) operatorList() string {
+	names := make([]string, 0, len(operators[t]))
+	for _, o := range operators[t] {
+		names = append(names, string(o))
+	}
+	return strings.Join(names, ", ")
+}
+
+// convert 将查询参数中的字符串转换为字段类型对应的值
+func (t Type) convert(raw string) (interface{}, error) {
+	switch t {
+	case Number:
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, errors.New("must be a number")
+		}
+		return v, nil
+	case Time:
+		if v, err := time.Parse(time.RFC3339, raw); err == nil {
+			return v, nil
+		}
+		if v, err := time.ParseInLocation("2006-01-02", raw, time.Local); err == nil {
+			return v, nil
+		}
+		return nil, errors.New("must be an RFC 3339 time or a YYYY-MM-DD date")
+	case Bool:
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, errors.New("must be true or false")
+		}
+		return v, nil
+	default:
+		if raw == "" {
+			return nil, errors.New("must not be empty")
+		}
+		return raw, nil
+	}
+}
+
+func (op Operator) apply(db *gorm.DB, column string, values []interface{}) *gorm.DB {
+	switch op {
+	case OpIn:
+		return db.Where(column+" IN ?", values)
+	case OpLike:
+		return db.Where(column+` LIKE ? ESCAPE '\'`, "%"+escapeLike(values[0].(string))+"%")
+	default:
+		return db.Where(column+" "+sqlOperators[op]+" ?", values[0])
+	}
+}
+
+// escapeLike 转义 LIKE 模式中的通配符
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
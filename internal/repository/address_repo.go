@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+	"gorm.io/gorm"
+)
+
+// AddressRepository 收货地址数据访问接口
+type AddressRepository interface {
+	CreateAddress(ctx context.Context, address *model.Address) error
+	GetAddress(ctx context.Context, id string) (*model.Address, error)
+	UpdateAddress(ctx context.Context, address *model.Address) error
+	DeleteAddress(ctx context.Context, id string) error
+	ListAddressesByUser(ctx context.Context, userID string) ([]*model.Address, error)
+	GetDefaultAddress(ctx context.Context, userID string) (*model.Address, error)
+}
+
+type addressRepository struct {
+	db *gorm.DB
+}
+
+// NewAddressRepository 创建收货地址仓储实例
+func NewAddressRepository(db *gorm.DB) AddressRepository {
+	return &addressRepository{db: db}
+}
+
+// CreateAddress 创建地址
+// 若新地址为默认地址，在同一事务中清除该用户其他地址的默认标记
+func (r *addressRepository) CreateAddress(ctx context.Context, address *model.Address) error {
+	return dbWithContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if address.IsDefault {
+			if err := clearDefaultAddress(tx, address.UserID, address.ID); err != nil {
+				return err
+			}
+		}
+		return tx.Create(address).Error
+	})
+}
+
+func (r *addressRepository) GetAddress(ctx context.Context, id string) (*model.Address, error) {
+	var address model.Address
+	if err := dbWithContext(ctx, r.db).Where("id = ?", id).First(&address).Error; err != nil {
+		return nil, err
+	}
+	return &address, nil
+}
+
+// UpdateAddress 更新地址
+// 若地址被设为默认地址，在同一事务中清除该用户其他地址的默认标记
+func (r *addressRepository) UpdateAddress(ctx context.Context, address *model.Address) error {
+	return dbWithContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if address.IsDefault {
+			if err := clearDefaultAddress(tx, address.UserID, address.ID); err != nil {
+				return err
+			}
+		}
+		return tx.Save(address).Error
+	})
+}
+
+func (r *addressRepository) DeleteAddress(ctx context.Context, id string) error {
+	return dbWithContext(ctx, r.db).Delete(&model.Address{}, "id = ?", id).Error
+}
+
+func (r *addressRepository) ListAddressesByUser(ctx context.Context, userID string) ([]*model.Address, error) {
+	var addresses []*model.Address
+	// 默认地址排在最前，其余按创建时间倒序
+	if err := dbWithContext(ctx, r.db).
+		Where("user_id = ?", userID).
+		Order("is_default DESC").
+		Order("created_at DESC").
+		Find(&addresses).Error; err != nil {
+		return nil, err
+	}
+	return addresses, nil
+}
+
+func (r *addressRepository) GetDefaultAddress(ctx context.Context, userID string) (*model.Address, error) {
+	var address model.Address
+	if err := dbWithContext(ctx, r.db).Where("user_id = ? AND is_default = ?", userID, true).First(&address).Error; err != nil {
+		return nil, err
+	}
+	return &address, nil
+}
+
+// clearDefaultAddress 清除用户除 exceptID 外所有地址的默认标记
+func clearDefaultAddress(tx *gorm.DB, userID, exceptID string) error {
+	return tx.Model(&model.Address{}).
+		Where("user_id = ? AND id <> ? AND is_default = ?", userID, exceptID, true).
+		Update("is_default", false).Error
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CartRepository 购物车数据访问接口
+type CartRepository interface {
+	CreateCart(ctx context.Context, cart *model.Cart) error
+	GetCartByUser(ctx context.Context, userID string) (*model.Cart, error)
+	SetItem(ctx context.Context, cartID, productID, skuID string, quantity int) error
+	RemoveItem(ctx context.Context, cartID, productID, skuID string) error
+	ClearCart(ctx context.Context, cartID string) error
+}
+
+type cartRepository struct {
+	db *gorm.DB
+}
+
+// NewCartRepository 创建购物车仓储实例
+func NewCartRepository(db *gorm.DB) CartRepository {
+	return &cartRepository{db: db}
+}
+
+func (r *cartRepository) CreateCart(ctx context.Context, cart *model.Cart) error {
+	return dbWithContext(ctx, r.db).Create(cart).Error
+}
+
+func (r *cartRepository) GetCartByUser(ctx context.Context, userID string) (*model.Cart, error) {
+	var cart model.Cart
+	if err := dbWithContext(ctx, r.db).
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Where("user_id = ?", userID).
+		First(&cart).Error; err != nil {
+		return nil, err
+	}
+	return &cart, nil
+}
+
+// SetItem 设置购物车商品行数量（不存在则新增），并刷新购物车的修改时间
+func (r *cartRepository) SetItem(ctx context.Context, cartID, productID, skuID string, quantity int) error {
+	return dbWithContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		item := &model.CartItem{CartID: cartID, ProductID: productID, SKUID: skuID, Quantity: quantity}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "cart_id"}, {Name: "product_id"}, {Name: "sku_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"quantity", "updated_at"}),
+		}).Create(item).Error; err != nil {
+			return err
+		}
+		return touchCart(tx, cartID)
+	})
+}
+
+func (r *cartRepository) RemoveItem(ctx context.Context, cartID, productID, skuID string) error {
+	return dbWithContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("cart_id = ? AND product_id = ? AND sku_id = ?", cartID, productID, skuID).Delete(&model.CartItem{}).Error; err != nil {
+			return err
+		}
+		return touchCart(tx, cartID)
+	})
+}
+
+func (r *cartRepository) ClearCart(ctx context.Context, cartID string) error {
+	return dbWithContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("cart_id = ?", cartID).Delete(&model.CartItem{}).Error; err != nil {
+			return err
+		}
+		return touchCart(tx, cartID)
+	})
+}
+
+// touchCart 刷新购物车修改时间，重新计算闲置过期
+func touchCart(tx *gorm.DB, cartID string) error {
+	return tx.Model(&model.Cart{}).Where("id = ?", cartID).Update("updated_at", time.Now()).Error
+}
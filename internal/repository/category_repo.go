@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+	"gorm.io/gorm"
+)
+
+// CategoryRepository 商品分类数据访问接口
+type CategoryRepository interface {
+	CreateCategory(ctx context.Context, category *model.Category) error
+	GetCategory(ctx context.Context, id string) (*model.Category, error)
+	GetCategoryBySlug(ctx context.Context, slug string) (*model.Category, error)
+	UpdateCategory(ctx context.Context, category *model.Category) error
+	DeleteCategories(ctx context.Context, ids []string) error
+	ListCategories(ctx context.Context) ([]*model.Category, error)
+	// ListDescendantIDs 返回分类自身及其所有后代分类的 ID
+	ListDescendantIDs(ctx context.Context, id string) ([]string, error)
+	CountChildren(ctx context.Context, id string) (int64, error)
+	CountProducts(ctx context.Context, categoryIDs []string) (int64, error)
+	ListProductIDs(ctx context.Context, categoryIDs []string) ([]string, error)
+}
+
+type categoryRepository struct {
+	db *gorm.DB
+}
+
+// NewCategoryRepository 创建商品分类仓储实例
+func NewCategoryRepository(db *gorm.DB) CategoryRepository {
+	return &categoryRepository{db: db}
+}
+
+func (r *categoryRepository) CreateCategory(ctx context.Context, category *model.Category) error {
+	return dbWithContext(ctx, r.db).Create(category).Error
+}
+
+func (r *categoryRepository) GetCategory(ctx context.Context, id string) (*model.Category, error) {
+	var category model.Category
+	if err := dbWithContext(ctx, r.db).Where("id = ?", id).First(&category).Error; err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (r *categoryRepository) GetCategoryBySlug(ctx context.Context, slug string) (*model.Category, error) {
+	var category model.Category
+	if err := dbWithContext(ctx, r.db).Where("slug = ?", slug).First(&category).Error; err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (r *categoryRepository) UpdateCategory(ctx context.Context, category *model.Category) error {
+	return dbWithContext(ctx, r.db).Save(category).Error
+}
+
+// DeleteCategories 删除分类并解除其与商品的关联
+func (r *categoryRepository) DeleteCategories(ctx context.Context, ids []string) error {
+	return dbWithContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("category_id IN ?", ids).Delete(&model.ProductCategory{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id IN ?", ids).Delete(&model.Category{}).Error
+	})
+}
+
+func (r *categoryRepository) ListCategories(ctx context.Context) ([]*model.Category, error) {
+	var categories []*model.Category
+	if err := dbWithContext(ctx, r.db).
+		Order("sort_order ASC").
+		Order("name ASC").
+		Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// ListDescendantIDs 使用递归 CTE 查询整棵子树
+func (r *categoryRepository) ListDescendantIDs(ctx context.Context, id string) ([]string, error) {
+	var ids []string
+	err := dbWithContext(ctx, r.db).Raw(`
+		WITH RECURSIVE subtree(id) AS (
+			SELECT id FROM categories WHERE id = ?
+			UNION
+			SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+		)
+		SELECT id FROM subtree`, id).Scan(&ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *categoryRepository) CountChildren(ctx context.Context, id string) (int64, error) {
+	var count int64
+	err := dbWithContext(ctx, r.db).Model(&model.Category{}).Where("parent_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+func (r *categoryRepository) CountProducts(ctx context.Context, categoryIDs []string) (int64, error) {
+	var count int64
+	err := dbWithContext(ctx, r.db).Model(&model.ProductCategory{}).
+		Where("category_id IN ?", categoryIDs).
+		Distinct("product_id").
+		Count(&count).Error
+	return count, err
+}
+
+func (r *categoryRepository) ListProductIDs(ctx context.Context, categoryIDs []string) ([]string, error) {
+	var ids []string
+	err := dbWithContext(ctx, r.db).Model(&model.ProductCategory{}).
+		Where("category_id IN ?", categoryIDs).
+		Distinct().
+		Pluck("product_id", &ids).Error
+	return ids, err
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/innovationmech/simple-cli/internal/domain"
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/queryspec"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrCouponExhausted 优惠券全局使用次数已用完
+	ErrCouponExhausted = domain.Keyed(domain.CodeUnprocessable, "coupon.exhausted", "coupon usage limit reached")
+	// ErrCouponUserLimit 用户使用该优惠券的次数已达上限
+	ErrCouponUserLimit = domain.Keyed(domain.CodeUnprocessable, "coupon.user_limit", "coupon per-user limit reached")
+	// ErrCouponUnavailable 优惠券已停用或不在有效期内
+	ErrCouponUnavailable = domain.Keyed(domain.CodeUnprocessable, "coupon.unavailable", "coupon is not active or outside its validity period")
+)
+
+// CouponRepository 优惠券数据访问接口
+type CouponRepository interface {
+	CreateCoupon(ctx context.Context, coupon *model.Coupon) error
+	GetCoupon(ctx context.Context, id string) (*model.Coupon, error)
+	GetCouponByCode(ctx context.Context, code string) (*model.Coupon, error)
+	UpdateCoupon(ctx context.Context, coupon *model.Coupon) error
+	DeleteCoupon(ctx context.Context, id string) error
+	ListCoupons(ctx context.Context, spec *queryspec.Spec) ([]*model.Coupon, *queryspec.PageInfo, error)
+	GetUsageCount(ctx context.Context, couponID, userID string) (int, error)
+	Redeem(ctx context.Context, coupon *model.Coupon, userID string) error
+	Release(ctx context.Context, couponID, userID string) error
+}
+
+type couponRepository struct {
+	db *gorm.DB
+}
+
+// NewCouponRepository 创建优惠券仓储实例
+func NewCouponRepository(db *gorm.DB) CouponRepository {
+	return &couponRepository{db: db}
+}
+
+func (r *couponRepository) CreateCoupon(ctx context.Context, coupon *model.Coupon) error {
+	return dbWithContext(ctx, r.db).Create(coupon).Error
+}
+
+func (r *couponRepository) GetCoupon(ctx context.Context, id string) (*model.Coupon, error) {
+	var coupon model.Coupon
+	if err := dbWithContext(ctx, r.db).Preload("Scopes").Where("id = ?", id).First(&coupon).Error; err != nil {
+		return nil, err
+	}
+	return &coupon, nil
+}
+
+func (r *couponRepository) GetCouponByCode(ctx context.Context, code string) (*model.Coupon, error) {
+	var coupon model.Coupon
+	if err := dbWithContext(ctx, r.db).Preload("Scopes").Where("code = ?", code).First(&coupon).Error; err != nil {
+		return nil, err
+	}
+	return &coupon, nil
+}
+
+// UpdateCoupon 更新优惠券主记录
+// 不覆盖 used_count，使用次数只能通过 Redeem/Release 原子地修改
+func (r *couponRepository) UpdateCoupon(ctx context.Context, coupon *model.Coupon) error {
+	return dbWithContext(ctx, r.db).Omit("Scopes", "UsedCount").Save(coupon).Error
+}
+
+func (r *couponRepository) DeleteCoupon(ctx context.Context, id string) error {
+	return dbWithContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("coupon_id = ?", id).Delete(&model.CouponScope{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.Coupon{}, "id = ?", id).Error
+	})
+}
+
+func (r *couponRepository) ListCoupons(ctx context.Context, spec *queryspec.Spec) ([]*model.Coupon, *queryspec.PageInfo, error) {
+	var coupons []*model.Coupon
+
+	query := spec.ApplyFilters(dbWithContext(ctx, r.db).Model(&model.Coupon{}))
+	page, err := spec.Find(query.Preload("Scopes"), &coupons)
+	if err != nil {
+		return nil, nil, err
+	}
+	return coupons, page, nil
+}
+
+func (r *couponRepository) GetUsageCount(ctx context.Context, couponID, userID string) (int, error) {
+	var usage model.CouponUsage
+	err := dbWithContext(ctx, r.db).Where("coupon_id = ? AND user_id = ?", couponID, userID).First(&usage).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return usage.Count, nil
+}
+
+// Redeem 原子地核销一次优惠券
+// 全局次数与用户次数均通过带条件的 UPDATE 递增，并发下也不会超出限制；
+// 启用状态与有效期同样在 UPDATE 条件中校验，计价后被停用或过期的优惠券不会被核销
+func (r *couponRepository) Redeem(ctx context.Context, coupon *model.Coupon, userID string) error {
+	return dbWithContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Coupon{}).
+			Where("id = ? AND (usage_limit = 0 OR used_count < usage_limit)", coupon.ID).
+			Scopes(validCoupons).
+			Update("used_count", gorm.Expr("used_count + 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			var valid int64
+			if err := tx.Model(&model.Coupon{}).Where("id = ?", coupon.ID).Scopes(validCoupons).Count(&valid).Error; err != nil {
+				return err
+			}
+			if valid == 0 {
+				return ErrCouponUnavailable
+			}
+			return ErrCouponExhausted
+		}
+
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&model.CouponUsage{CouponID: coupon.ID, UserID: userID}).Error; err != nil {
+			return err
+		}
+		result = tx.Model(&model.CouponUsage{}).
+			Where("coupon_id = ? AND user_id = ? AND (? = 0 OR count < ?)", coupon.ID, userID, coupon.PerUserLimit, coupon.PerUserLimit).
+			Update("count", gorm.Expr("count + 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrCouponUserLimit
+		}
+		return nil
+	})
+}
+
+// Release 归还一次优惠券使用次数（如订单取消）
+func (r *couponRepository) Release(ctx context.Context, couponID, userID string) error {
+	return dbWithContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Coupon{}).
+			Where("id = ? AND used_count > 0", couponID).
+			Update("used_count", gorm.Expr("used_count - 1")).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.CouponUsage{}).
+			Where("coupon_id = ? AND user_id = ? AND count > 0", couponID, userID).
+			Update("count", gorm.Expr("count - 1")).Error
+	})
+}
+
+// validCoupons 只匹配已启用且在有效期内的优惠券，未设置的起止时间视为不限
+func validCoupons(db *gorm.DB) *gorm.DB {
+	now := time.Now()
+	return db.Where("active = ? AND (starts_at IS NULL OR starts_at <= ?) AND (ends_at IS NULL OR ends_at >= ?)", true, now, now)
+}
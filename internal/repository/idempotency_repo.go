@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IdempotencyRepository 幂等键数据访问接口
+type IdempotencyRepository interface {
+	// Claim 以处理中状态登记幂等键并设置 ClaimedAt，成功时返回 nil；
+	// 幂等键已被占用且未过期时返回已有记录，过期记录先删除再重新登记；
+	// 处理中超过 lease 的记录视为首次请求已中断，相同请求可以重新占用
+	Claim(ctx context.Context, record *model.IdempotencyRecord, lease time.Duration) (*model.IdempotencyRecord, error)
+	// Complete 保存首次请求的响应，幂等键已被其他请求重新占用时不做修改
+	Complete(ctx context.Context, claim *model.IdempotencyRecord, statusCode int, contentType string, body []byte) error
+	// Release 删除处理中的幂等键，首次请求失败后客户端可以重试；幂等键已被其他请求重新占用时不做修改
+	Release(ctx context.Context, claim *model.IdempotencyRecord) error
+	// PurgeExpired 删除在 before 之前过期的幂等键，返回删除的数量
+	PurgeExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+type idempotencyRepository struct {
+	db *gorm.DB
+}
+
+// NewIdempotencyRepository 创建幂等键仓储实例
+func NewIdempotencyRepository(db *gorm.DB) IdempotencyRepository {
+	return &idempotencyRepository{db: db}
+}
+
+func (r *idempotencyRepository) Claim(ctx context.Context, record *model.IdempotencyRecord, lease time.Duration) (*model.IdempotencyRecord, error) {
+	// 截断到微秒，与数据库保存的精度一致，Complete 与 Release 按占用时间识别本次占用
+	now := time.Now().Truncate(time.Microsecond)
+	record.ClaimedAt = now
+	var existing *model.IdempotencyRecord
+	err := dbWithContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND idempotency_key = ? AND expires_at < ?", record.UserID, record.Key, now).
+			Delete(&model.IdempotencyRecord{}).Error; err != nil {
+			return err
+		}
+		// 并发的相同请求只有一个能插入成功
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+		if result.Error != nil || result.RowsAffected > 0 {
+			return result.Error
+		}
+		// 首次请求进程崩溃时来不及释放幂等键，占用超时后由相同请求接管，并发接管时只有一个能成功
+		result = tx.Model(&model.IdempotencyRecord{}).
+			Where("user_id = ? AND idempotency_key = ? AND request_hash = ? AND status_code = 0", record.UserID, record.Key, record.RequestHash).
+			Where("claimed_at IS NULL OR claimed_at < ?", now.Add(-lease)).
+			Updates(map[string]interface{}{
+				"claimed_at": record.ClaimedAt,
+				"expires_at": record.ExpiresAt,
+			})
+		if result.Error != nil || result.RowsAffected > 0 {
+			return result.Error
+		}
+		existing = &model.IdempotencyRecord{}
+		return tx.Where("user_id = ? AND idempotency_key = ?", record.UserID, record.Key).First(existing).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+func (r *idempotencyRepository) Complete(ctx context.Context, claim *model.IdempotencyRecord, statusCode int, contentType string, body []byte) error {
+	return dbWithContext(ctx, r.db).Model(&model.IdempotencyRecord{}).
+		Where("user_id = ? AND idempotency_key = ? AND claimed_at = ?", claim.UserID, claim.Key, claim.ClaimedAt).
+		Updates(map[string]interface{}{
+			"status_code":  statusCode,
+			"content_type": contentType,
+			"body":         body,
+		}).Error
+}
+
+func (r *idempotencyRepository) Release(ctx context.Context, claim *model.IdempotencyRecord) error {
+	return dbWithContext(ctx, r.db).
+		Where("user_id = ? AND idempotency_key = ? AND status_code = 0 AND claimed_at = ?", claim.UserID, claim.Key, claim.ClaimedAt).
+		Delete(&model.IdempotencyRecord{}).Error
+}
+
+func (r *idempotencyRepository) PurgeExpired(ctx context.Context, before time.Time) (int64, error) {
+	result := dbWithContext(ctx, r.db).Where("expires_at < ?", before).Delete(&model.IdempotencyRecord{})
+	return result.RowsAffected, result.Error
+}
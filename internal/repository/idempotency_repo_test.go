@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/testutil"
+)
+
+func TestClaimReclaimsStaleInFlightKey(t *testing.T) {
+	repo := NewIdempotencyRepository(testutil.NewDB(t))
+	ctx := context.Background()
+	newClaim := func(hash string) *model.IdempotencyRecord {
+		return &model.IdempotencyRecord{UserID: "u1", Key: "k1", RequestHash: hash, ExpiresAt: time.Now().Add(time.Hour)}
+	}
+
+	first := newClaim("h1")
+	if existing, err := repo.Claim(ctx, first, time.Hour); err != nil || existing != nil {
+		t.Fatalf("first Claim = %v, %v; want claimed", existing, err)
+	}
+	if existing, err := repo.Claim(ctx, newClaim("h1"), time.Hour); err != nil || existing == nil || !existing.InFlight() {
+		t.Fatalf("Claim within lease = %v, %v; want in-flight record", existing, err)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	// 占用超时后，不同的请求仍不能使用该幂等键
+	if existing, err := repo.Claim(ctx, newClaim("h2"), time.Millisecond); err != nil || existing == nil {
+		t.Fatalf("Claim with different request = %v, %v; want existing record", existing, err)
+	}
+	second := newClaim("h1")
+	if existing, err := repo.Claim(ctx, second, time.Millisecond); err != nil || existing != nil {
+		t.Fatalf("Claim after lease = %v, %v; want reclaimed", existing, err)
+	}
+
+	// 被接管的首次请求迟到的释放与保存不影响新的占用
+	if err := repo.Release(ctx, first); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Complete(ctx, first, 500, "text/plain", []byte("stale")); err != nil {
+		t.Fatal(err)
+	}
+	existing, err := repo.Claim(ctx, newClaim("h1"), time.Hour)
+	if err != nil || existing == nil || !existing.InFlight() {
+		t.Fatalf("Claim after stale release = %v, %v; want in-flight record", existing, err)
+	}
+
+	if err := repo.Complete(ctx, second, 201, "application/json", []byte(`{}`)); err != nil {
+		t.Fatal(err)
+	}
+	existing, err = repo.Claim(ctx, newClaim("h1"), time.Millisecond)
+	if err != nil || existing == nil || existing.StatusCode != 201 || string(existing.Body) != `{}` {
+		t.Fatalf("Claim after complete = %+v, %v; want saved response", existing, err)
+	}
+}
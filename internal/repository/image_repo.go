@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+	"gorm.io/gorm"
+)
+
+// ImageRepository 商品图片数据访问接口
+type ImageRepository interface {
+	CreateImage(ctx context.Context, image *model.ProductImage) error
+	GetImage(ctx context.Context, id string) (*model.ProductImage, error)
+	UpdateImage(ctx context.Context, image *model.ProductImage) error
+	DeleteImage(ctx context.Context, id string) error
+	ListImagesByProduct(ctx context.Context, productID string) ([]*model.ProductImage, error)
+}
+
+type imageRepository struct {
+	db *gorm.DB
+}
+
+// NewImageRepository 创建商品图片仓储实例
+func NewImageRepository(db *gorm.DB) ImageRepository {
+	return &imageRepository{db: db}
+}
+
+func (r *imageRepository) CreateImage(ctx context.Context, image *model.ProductImage) error {
+	return dbWithContext(ctx, r.db).Create(image).Error
+}
+
+func (r *imageRepository) GetImage(ctx context.Context, id string) (*model.ProductImage, error) {
+	var image model.ProductImage
+	if err := dbWithContext(ctx, r.db).Where("id = ?", id).First(&image).Error; err != nil {
+		return nil, err
+	}
+	return &image, nil
+}
+
+func (r *imageRepository) UpdateImage(ctx context.Context, image *model.ProductImage) error {
+	return dbWithContext(ctx, r.db).Save(image).Error
+}
+
+func (r *imageRepository) DeleteImage(ctx context.Context, id string) error {
+	return dbWithContext(ctx, r.db).Delete(&model.ProductImage{}, "id = ?", id).Error
+}
+
+func (r *imageRepository) ListImagesByProduct(ctx context.Context, productID string) ([]*model.ProductImage, error) {
+	var images []*model.ProductImage
+	if err := dbWithContext(ctx, r.db).
+		Where("product_id = ?", productID).
+		Order(imageOrder).
+		Find(&images).Error; err != nil {
+		return nil, err
+	}
+	return images, nil
+}
+
+// imageOrder 商品图片按排序字段升序，相同时按上传时间
+const imageOrder = "sort_order ASC, created_at ASC"
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/queryspec"
+	"gorm.io/gorm"
+)
+
+// InventoryRepository 库存调整日志与低库存预警数据访问接口
+// 库存的增减通过 ProductRepository.AdjustStock 完成，调整日志在同一事务中写入
+type InventoryRepository interface {
+	// SetThreshold 设置商品低库存阈值，并按当前库存开启或解除预警
+	SetThreshold(ctx context.Context, productID string, threshold *int) error
+	// ListAdjustments 获取商品的库存调整记录，skuID 非空时只返回该 SKU 的记录
+	ListAdjustments(ctx context.Context, productID, skuID string, spec *queryspec.Spec) ([]*model.InventoryAdjustment, *queryspec.PageInfo, error)
+	// ListAlerts 获取低库存预警，status 为空时返回全部
+	ListAlerts(ctx context.Context, status model.StockAlertStatus, spec *queryspec.Spec) ([]*model.StockAlert, *queryspec.PageInfo, error)
+}
+
+type inventoryRepository struct {
+	db *gorm.DB
+}
+
+// NewInventoryRepository 创建库存仓储实例
+func NewInventoryRepository(db *gorm.DB) InventoryRepository {
+	return &inventoryRepository{db: db}
+}
+
+func (r *inventoryRepository) SetThreshold(ctx context.Context, productID string, threshold *int) error {
+	return dbWithContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Product{}).Where("id = ?", productID).Updates(map[string]interface{}{
+			"low_stock_threshold": threshold,
+			"version":             gorm.Expr("version + 1"),
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		// 有规格的商品按各 SKU 的库存判断
+		var skus []model.SKU
+		if err := tx.Select("id", "stock").Where("product_id = ?", productID).Find(&skus).Error; err != nil {
+			return err
+		}
+		if len(skus) == 0 {
+			var product model.Product
+			if err := tx.Select("stock").Where("id = ?", productID).First(&product).Error; err != nil {
+				return err
+			}
+			return syncStockAlert(tx, productID, "", threshold, product.Stock)
+		}
+		for _, sku := range skus {
+			if err := syncStockAlert(tx, productID, sku.ID, threshold, sku.Stock); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *inventoryRepository) ListAdjustments(ctx context.Context, productID, skuID string, spec *queryspec.Spec) ([]*model.InventoryAdjustment, *queryspec.PageInfo, error) {
+	var adjustments []*model.InventoryAdjustment
+	query := dbWithContext(ctx, r.db).Model(&model.InventoryAdjustment{}).Where("product_id = ?", productID)
+	if skuID != "" {
+		query = query.Where("sku_id = ?", skuID)
+	}
+	page, err := spec.Find(spec.ApplyFilters(query), &adjustments)
+	if err != nil {
+		return nil, nil, err
+	}
+	return adjustments, page, nil
+}
+
+func (r *inventoryRepository) ListAlerts(ctx context.Context, status model.StockAlertStatus, spec *queryspec.Spec) ([]*model.StockAlert, *queryspec.PageInfo, error) {
+	var alerts []*model.StockAlert
+	query := dbWithContext(ctx, r.db).Model(&model.StockAlert{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	page, err := spec.Find(spec.ApplyFilters(query), &alerts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return alerts, page, nil
+}
+
+// applyAdjustment 原子地调整库存，写入调整日志并更新低库存预警
+func applyAdjustment(tx *gorm.DB, adj *model.InventoryAdjustment) error {
+	// 已软删除的商品与 SKU 仍可能因取消订单、退货入库而归还库存
+	stockRow := func() *gorm.DB {
+		if adj.SKUID != "" {
+			return tx.Unscoped().Model(&model.SKU{}).Where("id = ? AND product_id = ?", adj.SKUID, adj.ProductID)
+		}
+		return tx.Unscoped().Model(&model.Product{}).Where("id = ?", adj.ProductID)
+	}
+
+	query := stockRow()
+	if adj.Delta < 0 {
+		query = query.Where("stock >= ?", -adj.Delta)
+	}
+	result := query.Update("stock", gorm.Expr("stock + ?", adj.Delta))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInsufficientStock
+	}
+	if err := stockRow().Select("stock").Scan(&adj.StockAfter).Error; err != nil {
+		return err
+	}
+	if err := tx.Create(adj).Error; err != nil {
+		return err
+	}
+
+	var product model.Product
+	if err := tx.Unscoped().Select("low_stock_threshold").Where("id = ?", adj.ProductID).First(&product).Error; err != nil {
+		return err
+	}
+	return syncStockAlert(tx, adj.ProductID, adj.SKUID, product.LowStockThreshold, adj.StockAfter)
+}
+
+// recordInitialStock 记录新建商品或 SKU 的初始库存
+func recordInitialStock(tx *gorm.DB, productID, skuID string, stock int, actor string) error {
+	if stock == 0 {
+		return nil
+	}
+	return tx.Create(&model.InventoryAdjustment{
+		ProductID:  productID,
+		SKUID:      skuID,
+		Type:       model.AdjustmentReceive,
+		Delta:      stock,
+		StockAfter: stock,
+		Reason:     "initial stock",
+		Actor:      actor,
+	}).Error
+}
+
+// stockCorrection 通过更新商品或 SKU 修改库存时对应的盘点修正
+func stockCorrection(productID, skuID string, delta int, actor string) *model.InventoryAdjustment {
+	return &model.InventoryAdjustment{
+		ProductID: productID,
+		SKUID:     skuID,
+		Type:      model.AdjustmentCorrection,
+		Delta:     delta,
+		Reason:    "stock updated",
+		Actor:     actor,
+	}
+}
+
+// syncStockAlert 按当前库存开启或解除低库存预警
+// 库存不高于阈值且没有未解除的预警时产生新预警；高于阈值或阈值关闭时解除预警
+func syncStockAlert(tx *gorm.DB, productID, skuID string, threshold *int, stock int) error {
+	var open model.StockAlert
+	err := tx.Where("product_id = ? AND sku_id = ? AND status = ?", productID, skuID, model.StockAlertOpen).First(&open).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	exists := err == nil
+
+	switch {
+	case threshold != nil && stock <= *threshold && exists:
+		open.Stock = stock
+		open.Threshold = *threshold
+		return tx.Save(&open).Error
+	case threshold != nil && stock <= *threshold:
+		return tx.Create(&model.StockAlert{
+			ProductID: productID,
+			SKUID:     skuID,
+			Threshold: *threshold,
+			Stock:     stock,
+			Status:    model.StockAlertOpen,
+		}).Error
+	case exists:
+		now := time.Now()
+		open.Stock = stock
+		open.Status = model.StockAlertResolved
+		open.ResolvedAt = &now
+		return tx.Save(&open).Error
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/queryspec"
 	"gorm.io/gorm"
 )
 
@@ -12,7 +13,9 @@ type OrderRepository interface {
 	CreateOrder(ctx context.Context, order *model.Order) error
 	GetOrder(ctx context.Context, id string) (*model.Order, error)
 	UpdateOrder(ctx context.Context, order *model.Order) error
-	ListOrdersByUser(ctx context.Context, userID string, offset, limit int) ([]*model.Order, int64, error)
+	ListOrdersByUser(ctx context.Context, userID string, spec *queryspec.Spec) ([]*model.Order, *queryspec.PageInfo, error)
+	AddHistory(ctx context.Context, history *model.OrderHistory) error
+	ListHistory(ctx context.Context, orderID string) ([]*model.OrderHistory, error)
 }
 
 type orderRepository struct {
@@ -26,39 +29,47 @@ func NewOrderRepository(db *gorm.DB) OrderRepository {
 }
 
 func (r *orderRepository) CreateOrder(ctx context.Context, order *model.Order) error {
-	return r.db.WithContext(ctx).Create(order).Error
+	return dbWithContext(ctx, r.db).Create(order).Error
 }
 
 func (r *orderRepository) GetOrder(ctx context.Context, id string) (*model.Order, error) {
 	var order model.Order
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&order).Error; err != nil {
+	if err := dbWithContext(ctx, r.db).Preload("Items").Preload("Discounts").Where("id = ?", id).First(&order).Error; err != nil {
 		return nil, err
 	}
 	return &order, nil
 }
 
+// UpdateOrder 更新订单主记录（商品与优惠明细创建后不可修改）
+// 以 order.Version 为期望版本号，订单已被修改时返回 ErrVersionConflict
 func (r *orderRepository) UpdateOrder(ctx context.Context, order *model.Order) error {
-	return r.db.WithContext(ctx).Save(order).Error
+	return updateVersioned(dbWithContext(ctx, r.db), order, &order.Version, "Items", "Discounts", "CreatedAt")
 }
 
-func (r *orderRepository) ListOrdersByUser(ctx context.Context, userID string, offset, limit int) ([]*model.Order, int64, error) {
+func (r *orderRepository) ListOrdersByUser(ctx context.Context, userID string, spec *queryspec.Spec) ([]*model.Order, *queryspec.PageInfo, error) {
 	var orders []*model.Order
-	var total int64
 
-	query := r.db.WithContext(ctx).Model(&model.Order{})
+	query := dbWithContext(ctx, r.db).Model(&model.Order{})
 	if userID != "" {
 		query = query.Where("user_id = ?", userID)
 	}
+	query = spec.ApplyFilters(query)
 
-	// 获取总数
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
+	page, err := spec.Find(query.Preload("Items").Preload("Discounts"), &orders)
+	if err != nil {
+		return nil, nil, err
 	}
+	return orders, page, nil
+}
 
-	// 获取分页数据
-	if err := query.Offset(offset).Limit(limit).Order("created_at DESC").Find(&orders).Error; err != nil {
-		return nil, 0, err
-	}
+func (r *orderRepository) AddHistory(ctx context.Context, history *model.OrderHistory) error {
+	return dbWithContext(ctx, r.db).Create(history).Error
+}
 
-	return orders, total, nil
+func (r *orderRepository) ListHistory(ctx context.Context, orderID string) ([]*model.OrderHistory, error) {
+	var history []*model.OrderHistory
+	if err := dbWithContext(ctx, r.db).Where("order_id = ?", orderID).Order("id ASC").Find(&history).Error; err != nil {
+		return nil, err
+	}
+	return history, nil
 }
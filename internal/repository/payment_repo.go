@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/queryspec"
 	"gorm.io/gorm"
 )
 
@@ -12,7 +13,7 @@ type PaymentRepository interface {
 	CreatePayment(ctx context.Context, payment *model.Payment) error
 	GetPayment(ctx context.Context, id string) (*model.Payment, error)
 	UpdatePayment(ctx context.Context, payment *model.Payment) error
-	ListPayments(ctx context.Context, userID, orderID string, offset, limit int) ([]*model.Payment, int64, error)
+	ListPayments(ctx context.Context, userID, orderID string, spec *queryspec.Spec) ([]*model.Payment, *queryspec.PageInfo, error)
 }
 
 type paymentRepository struct {
@@ -26,42 +27,37 @@ func NewPaymentRepository(db *gorm.DB) PaymentRepository {
 }
 
 func (r *paymentRepository) CreatePayment(ctx context.Context, payment *model.Payment) error {
-	return r.db.WithContext(ctx).Create(payment).Error
+	return dbWithContext(ctx, r.db).Create(payment).Error
 }
 
 func (r *paymentRepository) GetPayment(ctx context.Context, id string) (*model.Payment, error) {
 	var payment model.Payment
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&payment).Error; err != nil {
+	if err := dbWithContext(ctx, r.db).Where("id = ?", id).First(&payment).Error; err != nil {
 		return nil, err
 	}
 	return &payment, nil
 }
 
+// UpdatePayment 更新支付记录，以 payment.Version 为期望版本号，已被修改时返回 ErrVersionConflict
 func (r *paymentRepository) UpdatePayment(ctx context.Context, payment *model.Payment) error {
-	return r.db.WithContext(ctx).Save(payment).Error
+	return updateVersioned(dbWithContext(ctx, r.db), payment, &payment.Version, "CreatedAt")
 }
 
-func (r *paymentRepository) ListPayments(ctx context.Context, userID, orderID string, offset, limit int) ([]*model.Payment, int64, error) {
+func (r *paymentRepository) ListPayments(ctx context.Context, userID, orderID string, spec *queryspec.Spec) ([]*model.Payment, *queryspec.PageInfo, error) {
 	var payments []*model.Payment
-	var total int64
 
-	query := r.db.WithContext(ctx).Model(&model.Payment{})
+	query := dbWithContext(ctx, r.db).Model(&model.Payment{})
 	if userID != "" {
 		query = query.Where("user_id = ?", userID)
 	}
 	if orderID != "" {
 		query = query.Where("order_id = ?", orderID)
 	}
+	query = spec.ApplyFilters(query)
 
-	// 获取总数
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
+	page, err := spec.Find(query, &payments)
+	if err != nil {
+		return nil, nil, err
 	}
-
-	// 获取分页数据
-	if err := query.Offset(offset).Limit(limit).Order("created_at DESC").Find(&payments).Error; err != nil {
-		return nil, 0, err
-	}
-
-	return payments, total, nil
+	return payments, page, nil
 }
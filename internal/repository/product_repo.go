@@ -2,22 +2,58 @@ package repository
 
 import (
 	"context"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/innovationmech/simple-cli/internal/domain"
 	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/queryspec"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrInsufficientStock 扣减库存时库存不足
+var ErrInsufficientStock = domain.Keyed(domain.CodeInsufficientStock, "stock.insufficient", "insufficient stock")
+
 // ProductRepository 商品数据访问接口
 type ProductRepository interface {
 	CreateProduct(ctx context.Context, product *model.Product) error
 	GetProduct(ctx context.Context, id string) (*model.Product, error)
 	UpdateProduct(ctx context.Context, product *model.Product) error
+	// DeleteProduct 软删除商品，SKU、分类关联、价格与库存记录保留以便恢复
 	DeleteProduct(ctx context.Context, id string) error
-	ListProducts(ctx context.Context, offset, limit int) ([]*model.Product, int64, error)
+	// RestoreProduct 恢复已软删除的商品
+	RestoreProduct(ctx context.Context, id string) error
+	// ListDeletedProductIDs 返回在 before 之前软删除的商品 ID
+	ListDeletedProductIDs(ctx context.Context, before time.Time) ([]string, error)
+	// PurgeProduct 彻底删除已软删除的商品及其 SKU、分类关联、价格与库存记录
+	PurgeProduct(ctx context.Context, id string) error
+	ListProducts(ctx context.Context, filter model.ProductFilter, spec *queryspec.Spec) ([]*model.Product, *queryspec.PageInfo, error)
+	// SearchProducts 按名称与描述全文搜索商品，结果按相关度降序排列
+	SearchProducts(ctx context.Context, query string, filter model.ProductFilter, offset, limit int) ([]*model.ProductSearchHit, int64, error)
+	// AdjustStock 按 adj.Delta 调整库存并记录调整日志，adj.SKUID 非空时调整该 SKU 的库存
+	AdjustStock(ctx context.Context, adj *model.InventoryAdjustment) error
+
+	CreateSKU(ctx context.Context, sku *model.SKU) error
+	GetSKU(ctx context.Context, id string) (*model.SKU, error)
+	GetSKUByCode(ctx context.Context, code string) (*model.SKU, error)
+	UpdateSKU(ctx context.Context, sku *model.SKU) error
+	DeleteSKU(ctx context.Context, id string) error
+
+	// 价格记录，商品与 SKU 的价格变更由 Create/Update 方法自动记录
+	CreatePrice(ctx context.Context, price *model.ProductPrice) error
+	GetPrice(ctx context.Context, id uint) (*model.ProductPrice, error)
+	DeletePrice(ctx context.Context, id uint) error
+	// ListPrices 按生效时间倒序返回价格记录，skuID 非空时只返回该 SKU 的记录
+	ListPrices(ctx context.Context, productID, skuID string) ([]*model.ProductPrice, error)
 }
 
 type productRepository struct {
 	db *gorm.DB
+
+	searchOnce sync.Once
+	search     searchMode
 }
 
 // NewProductRepository 创建商品仓储实例
@@ -25,39 +61,345 @@ func NewProductRepository(db *gorm.DB) ProductRepository {
 	return &productRepository{db: db}
 }
 
+// CreateProduct 创建商品，按 Categories 写入分类关联并记录初始价格与初始库存
 func (r *productRepository) CreateProduct(ctx context.Context, product *model.Product) error {
-	return r.db.WithContext(ctx).Create(product).Error
+	return dbWithContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Categories", "SKUs", "Images", "Prices").Create(product).Error; err != nil {
+			return err
+		}
+		if err := recordPrice(tx, product.ID, "", product.Price, product.ChangedBy); err != nil {
+			return err
+		}
+		if err := recordInitialStock(tx, product.ID, "", product.Stock, product.ChangedBy); err != nil {
+			return err
+		}
+		return replaceProductCategories(tx, product)
+	})
 }
 
 func (r *productRepository) GetProduct(ctx context.Context, id string) (*model.Product, error) {
 	var product model.Product
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&product).Error; err != nil {
+	if err := dbWithContext(ctx, r.db).Preload("Categories").Preload("SKUs", orderSKUs).Preload("Images", orderImages).Preload("Prices", activePrices).Where("id = ?", id).First(&product).Error; err != nil {
 		return nil, err
 	}
 	return &product, nil
 }
 
+// UpdateProduct 更新商品，分类关联与 Categories 保持一致
+// 以 product.Version 为期望版本号，商品已被修改时返回 ErrVersionConflict；
+// 价格变化时记录价格历史；库存变化按盘点修正调整，不直接覆盖库存
 func (r *productRepository) UpdateProduct(ctx context.Context, product *model.Product) error {
-	return r.db.WithContext(ctx).Save(product).Error
+	return dbWithContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var stored model.Product
+		if err := tx.Select("price", "stock").Where("id = ?", product.ID).First(&stored).Error; err != nil {
+			return err
+		}
+		if err := updateVersioned(tx, product, &product.Version, "Categories", "SKUs", "Images", "Prices", "Stock", "LowStockThreshold", "DeletedAt", "CreatedAt"); err != nil {
+			return err
+		}
+		if stored.Price != product.Price {
+			if err := recordPrice(tx, product.ID, "", product.Price, product.ChangedBy); err != nil {
+				return err
+			}
+		}
+		if stored.Stock != product.Stock {
+			if err := applyAdjustment(tx, stockCorrection(product.ID, "", product.Stock-stored.Stock, product.ChangedBy)); err != nil {
+				return err
+			}
+		}
+		return replaceProductCategories(tx, product)
+	})
 }
 
 func (r *productRepository) DeleteProduct(ctx context.Context, id string) error {
-	return r.db.WithContext(ctx).Delete(&model.Product{}, "id = ?", id).Error
+	result := dbWithContext(ctx, r.db).Delete(&model.Product{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *productRepository) RestoreProduct(ctx context.Context, id string) error {
+	result := dbWithContext(ctx, r.db).Unscoped().Model(&model.Product{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Updates(map[string]interface{}{"deleted_at": nil, "version": gorm.Expr("version + 1")})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *productRepository) ListDeletedProductIDs(ctx context.Context, before time.Time) ([]string, error) {
+	var ids []string
+	err := dbWithContext(ctx, r.db).Unscoped().Model(&model.Product{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+func (r *productRepository) PurgeProduct(ctx context.Context, id string) error {
+	return dbWithContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("product_id = ?", id).Delete(&model.ProductCategory{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("product_id = ?", id).Delete(&model.SKU{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("product_id = ?", id).Delete(&model.ProductPrice{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("product_id = ?", id).Delete(&model.InventoryAdjustment{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("product_id = ?", id).Delete(&model.StockAlert{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&model.Product{}, "id = ? AND deleted_at IS NOT NULL", id).Error
+	})
 }
 
-func (r *productRepository) ListProducts(ctx context.Context, offset, limit int) ([]*model.Product, int64, error) {
+func (r *productRepository) ListProducts(ctx context.Context, filter model.ProductFilter, spec *queryspec.Spec) ([]*model.Product, *queryspec.PageInfo, error) {
 	var products []*model.Product
-	var total int64
 
-	// 获取总数
-	if err := r.db.WithContext(ctx).Model(&model.Product{}).Count(&total).Error; err != nil {
-		return nil, 0, err
+	db := dbWithContext(ctx, r.db)
+	if filter.IncludeDeleted {
+		// Unscoped 返回的实例会被后续链式调用修改，需开启新会话供派生表与外层查询分别使用
+		db = db.Unscoped().Session(&gorm.Session{})
 	}
+	// 以附带当前售价的派生表代替 products，price 字段的排序、过滤与游标均基于 effective_price
+	current := db.Model(&model.Product{}).Select("products.*, ? AS effective_price", effectivePrice(time.Now(), ""))
+	query := spec.ApplyFilters(r.applyFilter(ctx, db.Model(&model.Product{}).Table("(?) AS products", current), filter))
+	page, err := spec.Find(query.Preload("Categories").Preload("SKUs", orderSKUs).Preload("Images", orderImages).Preload("Prices", activePrices), &products)
+	if err != nil {
+		return nil, nil, err
+	}
+	return products, page, nil
+}
 
-	// 获取分页数据
-	if err := r.db.WithContext(ctx).Offset(offset).Limit(limit).Find(&products).Error; err != nil {
-		return nil, 0, err
+// applyFilter 在商品查询上追加分类、价格区间与库存过滤条件
+func (r *productRepository) applyFilter(ctx context.Context, query *gorm.DB, filter model.ProductFilter) *gorm.DB {
+	if len(filter.CategoryIDs) > 0 {
+		query = query.Where("products.id IN (?)", dbWithContext(ctx, r.db).
+			Model(&model.ProductCategory{}).
+			Select("product_id").
+			Where("category_id IN ?", filter.CategoryIDs))
+	}
+	if filter.MinPrice != nil || filter.MaxPrice != nil {
+		// 无规格商品比较商品售价，有规格商品比较各 SKU 售价；售价含生效中的预约调价与促销价
+		now := time.Now()
+		productCond, productArgs := priceRange(effectivePrice(now, ""), filter)
+		skuCond, skuArgs := priceRange(effectivePrice(now, "skus"), filter)
+		query = query.Where("((NOT EXISTS (SELECT 1 FROM skus WHERE skus.product_id = products.id AND skus.deleted_at IS NULL) AND "+productCond+")"+
+			" OR EXISTS (SELECT 1 FROM skus WHERE skus.product_id = products.id AND skus.deleted_at IS NULL AND "+skuCond+"))",
+			append(productArgs, skuArgs...)...)
+	}
+	if filter.InStock {
+		query = query.Where("((NOT EXISTS (SELECT 1 FROM skus WHERE skus.product_id = products.id AND skus.deleted_at IS NULL) AND products.stock > 0)" +
+			" OR EXISTS (SELECT 1 FROM skus WHERE skus.product_id = products.id AND skus.deleted_at IS NULL AND skus.stock > 0))")
 	}
+	return query
+}
+
+// priceRange 生成价格区间条件
+func priceRange(price clause.Expr, filter model.ProductFilter) (string, []interface{}) {
+	var conds []string
+	var args []interface{}
+	if filter.MinPrice != nil {
+		conds = append(conds, "? >= ?")
+		args = append(args, price, *filter.MinPrice)
+	}
+	if filter.MaxPrice != nil {
+		conds = append(conds, "? <= ?")
+		args = append(args, price, *filter.MaxPrice)
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+// effectivePrice 返回 now 时刻售价的 SQL 表达式，与 model.Product.PriceAt 的取价规则一致：
+// 促销价优先于基础价，价格记录优先于商品或 SKU 上保存的价格，未单独定价的 SKU 继承商品价格与促销价
+// skuTable 为空时计算商品售价，否则计算该表当前行 SKU 的售价
+func effectivePrice(now time.Time, skuTable string) clause.Expr {
+	productBase := priceEntry(now, model.PriceKindBase, "")
+	productSale := priceEntry(now, model.PriceKindSale, "")
+	if skuTable == "" {
+		return gorm.Expr("COALESCE(?, ?, products.price)", productSale, productBase)
+	}
+	skuBase := priceEntry(now, model.PriceKindBase, skuTable)
+	skuSale := priceEntry(now, model.PriceKindSale, skuTable)
+	return gorm.Expr("COALESCE(?, CASE WHEN ? IS NULL AND "+skuTable+".price IS NULL THEN ? END, ?, "+skuTable+".price, ?, products.price)",
+		skuSale, skuBase, productSale, skuBase, productBase)
+}
+
+// priceEntry 返回 now 时刻生效且生效时间最晚的价格记录的子查询，没有记录时为 NULL
+func priceEntry(now time.Time, kind model.PriceKind, skuTable string) clause.Expr {
+	skuCond := "product_prices.sku_id = ''"
+	if skuTable != "" {
+		skuCond = "product_prices.sku_id = " + skuTable + ".id"
+	}
+	return gorm.Expr("(SELECT product_prices.price FROM product_prices WHERE product_prices.product_id = products.id AND "+skuCond+
+		" AND product_prices.kind = ? AND product_prices.effective_from <= ?"+
+		" AND (product_prices.effective_until IS NULL OR product_prices.effective_until > ?)"+
+		" ORDER BY product_prices.effective_from DESC, product_prices.id DESC LIMIT 1)", kind, now, now)
+}
+
+// replaceProductCategories 用 product.Categories 替换商品的分类关联
+func replaceProductCategories(tx *gorm.DB, product *model.Product) error {
+	if err := tx.Where("product_id = ?", product.ID).Delete(&model.ProductCategory{}).Error; err != nil {
+		return err
+	}
+	if len(product.Categories) == 0 {
+		return nil
+	}
+	links := make([]model.ProductCategory, 0, len(product.Categories))
+	for _, category := range product.Categories {
+		links = append(links, model.ProductCategory{ProductID: product.ID, CategoryID: category.ID})
+	}
+	return tx.Create(&links).Error
+}
+
+// AdjustStock 原子地调整商品或 SKU 库存，并在同一事务中记录调整日志、更新低库存预警
+// Delta 为负数表示扣减，库存不足时不做修改并返回 ErrInsufficientStock
+// 商品库存属于商品本身，调整后递增商品版本号，避免持有旧库存的更新覆盖这次调整
+func (r *productRepository) AdjustStock(ctx context.Context, adj *model.InventoryAdjustment) error {
+	return dbWithContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := applyAdjustment(tx, adj); err != nil {
+			return err
+		}
+		if adj.SKUID != "" {
+			return nil
+		}
+		return tx.Unscoped().Model(&model.Product{}).Where("id = ?", adj.ProductID).
+			UpdateColumn("version", gorm.Expr("version + 1")).Error
+	})
+}
+
+// CreateSKU 创建 SKU，记录初始库存，单独定价时记录初始价格
+func (r *productRepository) CreateSKU(ctx context.Context, sku *model.SKU) error {
+	return dbWithContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(sku).Error; err != nil {
+			return err
+		}
+		if err := recordInitialStock(tx, sku.ProductID, sku.ID, sku.Stock, sku.ChangedBy); err != nil {
+			return err
+		}
+		if sku.Price == nil {
+			return nil
+		}
+		return recordPrice(tx, sku.ProductID, sku.ID, *sku.Price, sku.ChangedBy)
+	})
+}
+
+func (r *productRepository) GetSKU(ctx context.Context, id string) (*model.SKU, error) {
+	var sku model.SKU
+	if err := dbWithContext(ctx, r.db).Where("id = ?", id).First(&sku).Error; err != nil {
+		return nil, err
+	}
+	return &sku, nil
+}
+
+func (r *productRepository) GetSKUByCode(ctx context.Context, code string) (*model.SKU, error) {
+	var sku model.SKU
+	if err := dbWithContext(ctx, r.db).Where("code = ?", code).First(&sku).Error; err != nil {
+		return nil, err
+	}
+	return &sku, nil
+}
+
+// UpdateSKU 更新 SKU，价格变化时记录价格历史，库存变化按盘点修正调整
+func (r *productRepository) UpdateSKU(ctx context.Context, sku *model.SKU) error {
+	return dbWithContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var stored model.SKU
+		if err := tx.Select("price", "stock").Where("id = ?", sku.ID).First(&stored).Error; err != nil {
+			return err
+		}
+		if err := tx.Omit("Stock").Save(sku).Error; err != nil {
+			return err
+		}
+		if stored.Stock != sku.Stock {
+			if err := applyAdjustment(tx, stockCorrection(sku.ProductID, sku.ID, sku.Stock-stored.Stock, sku.ChangedBy)); err != nil {
+				return err
+			}
+		}
+		if sku.Price == nil || (stored.Price != nil && *stored.Price == *sku.Price) {
+			return nil
+		}
+		return recordPrice(tx, sku.ProductID, sku.ID, *sku.Price, sku.ChangedBy)
+	})
+}
+
+// DeleteSKU 软删除 SKU，保留价格历史与库存调整记录，并解除其未解除的低库存预警
+func (r *productRepository) DeleteSKU(ctx context.Context, id string) error {
+	return dbWithContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&model.SKU{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Model(&model.StockAlert{}).
+			Where("sku_id = ? AND status = ?", id, model.StockAlertOpen).
+			Updates(map[string]interface{}{"status": model.StockAlertResolved, "resolved_at": time.Now()}).Error
+	})
+}
+
+func (r *productRepository) CreatePrice(ctx context.Context, price *model.ProductPrice) error {
+	return dbWithContext(ctx, r.db).Create(price).Error
+}
+
+func (r *productRepository) GetPrice(ctx context.Context, id uint) (*model.ProductPrice, error) {
+	var price model.ProductPrice
+	if err := dbWithContext(ctx, r.db).Where("id = ?", id).First(&price).Error; err != nil {
+		return nil, err
+	}
+	return &price, nil
+}
+
+func (r *productRepository) DeletePrice(ctx context.Context, id uint) error {
+	return dbWithContext(ctx, r.db).Delete(&model.ProductPrice{}, "id = ?", id).Error
+}
+
+func (r *productRepository) ListPrices(ctx context.Context, productID, skuID string) ([]*model.ProductPrice, error) {
+	var prices []*model.ProductPrice
+	query := dbWithContext(ctx, r.db).Where("product_id = ?", productID)
+	if skuID != "" {
+		query = query.Where("sku_id = ?", skuID)
+	}
+	if err := query.Order("effective_from DESC").Order("id DESC").Find(&prices).Error; err != nil {
+		return nil, err
+	}
+	return prices, nil
+}
+
+// recordPrice 记录一次立即生效的基础价变更
+func recordPrice(tx *gorm.DB, productID, skuID string, price float64, actor string) error {
+	return tx.Create(&model.ProductPrice{
+		ProductID:     productID,
+		SKUID:         skuID,
+		Kind:          model.PriceKindBase,
+		Price:         price,
+		EffectiveFrom: time.Now(),
+		Actor:         actor,
+	}).Error
+}
+
+// orderImages 商品图片按排序字段返回
+func orderImages(db *gorm.DB) *gorm.DB {
+	return db.Order(imageOrder)
+}
+
+// activePrices 只预加载尚未结束的价格记录，已结束的促销价不参与计价
+func activePrices(db *gorm.DB) *gorm.DB {
+	return db.Where("effective_until IS NULL OR effective_until > ?", time.Now())
+}
 
-	return products, total, nil
+// orderSKUs SKU 按创建顺序返回，保证规格矩阵顺序稳定
+func orderSKUs(db *gorm.DB) *gorm.DB {
+	return db.Order("created_at ASC")
 }
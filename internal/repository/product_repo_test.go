@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/queryspec"
+	"github.com/innovationmech/simple-cli/internal/testutil"
+)
+
+// seedPricedProducts 创建售价与保存价格不同的商品：
+// p1 售价 10；p2 促销价 5；p3 调价后 30（保存价格仍为 20）；
+// p4 商品售价 40，SKU a 促销价 8、SKU b 售价 60
+func seedPricedProducts(t *testing.T, repo ProductRepository) {
+	t.Helper()
+	ctx := context.Background()
+	past, future := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+	skuPrice := 60.0
+
+	for _, p := range []*model.Product{
+		{ID: "p1", Name: "p1", Price: 10},
+		{ID: "p2", Name: "p2", Price: 50},
+		{ID: "p3", Name: "p3", Price: 20},
+		{ID: "p4", Name: "p4", Price: 40},
+	} {
+		if err := repo.CreateProduct(ctx, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, sku := range []*model.SKU{
+		{ID: "a", ProductID: "p4", Code: "P4-A"},
+		{ID: "b", ProductID: "p4", Code: "P4-B", Price: &skuPrice},
+	} {
+		if err := repo.CreateSKU(ctx, sku); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, price := range []*model.ProductPrice{
+		{ProductID: "p2", Kind: model.PriceKindSale, Price: 5, EffectiveFrom: past, EffectiveUntil: &future},
+		{ProductID: "p3", Kind: model.PriceKindBase, Price: 30, EffectiveFrom: time.Now()},
+		{ProductID: "p3", Kind: model.PriceKindBase, Price: 100, EffectiveFrom: future},
+		{ProductID: "p4", SKUID: "a", Kind: model.PriceKindSale, Price: 8, EffectiveFrom: past, EffectiveUntil: &future},
+		{ProductID: "p1", Kind: model.PriceKindSale, Price: 1, EffectiveFrom: past.Add(-time.Hour), EffectiveUntil: &past},
+	} {
+		if err := repo.CreatePrice(ctx, price); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func productIDs(products []*model.Product) []string {
+	ids := make([]string, 0, len(products))
+	for _, p := range products {
+		ids = append(ids, p.ID)
+	}
+	return ids
+}
+
+func TestListProductsUsesEffectivePrice(t *testing.T) {
+	repo := NewProductRepository(testutil.NewDB(t))
+	seedPricedProducts(t, repo)
+
+	price := func(v float64) *float64 { return &v }
+	tests := []struct {
+		name   string
+		filter model.ProductFilter
+		query  string
+		want   []string
+	}{
+		{name: "sort by price", query: "sort=price", want: []string{"p2", "p1", "p3", "p4"}},
+		{name: "sort by price desc", query: "sort=-price", want: []string{"p4", "p3", "p1", "p2"}},
+		{name: "scheduled base price", filter: model.ProductFilter{MinPrice: price(25), MaxPrice: price(35)}, query: "sort=price", want: []string{"p3"}},
+		{name: "sale prices", filter: model.ProductFilter{MaxPrice: price(9)}, query: "sort=price", want: []string{"p2", "p4"}},
+		{name: "sku base price", filter: model.ProductFilter{MinPrice: price(55)}, query: "sort=price", want: []string{"p4"}},
+		{name: "spec filter", query: "sort=price&filter[price][gte]=30", want: []string{"p3", "p4"}},
+		{name: "include deleted", filter: model.ProductFilter{IncludeDeleted: true, MaxPrice: price(9)}, query: "sort=-price", want: []string{"p4", "p2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, _ := url.ParseQuery(tt.query)
+			spec, err := queryspec.Parse(values, model.ProductQuerySchema)
+			if err != nil {
+				t.Fatal(err)
+			}
+			products, _, err := repo.ListProducts(context.Background(), tt.filter, spec)
+			if err != nil {
+				t.Fatalf("ListProducts: %v", err)
+			}
+			if got := productIDs(products); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("products = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListProductsCursorByEffectivePrice(t *testing.T) {
+	repo := NewProductRepository(testutil.NewDB(t))
+	seedPricedProducts(t, repo)
+
+	var got []string
+	query := url.Values{"sort": {"price"}}
+	for page := 0; page < 3; page++ {
+		spec, err := queryspec.Parse(query, model.ProductQuerySchema)
+		if err != nil {
+			t.Fatal(err)
+		}
+		spec.SetPage(1, 2)
+		products, info, err := repo.ListProducts(context.Background(), model.ProductFilter{}, spec)
+		if err != nil {
+			t.Fatalf("ListProducts: %v", err)
+		}
+		got = append(got, productIDs(products)...)
+		if info.NextCursor == "" {
+			break
+		}
+		query.Set("cursor", info.NextCursor)
+	}
+	if want := []string{"p2", "p1", "p3", "p4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("paged products = %v, want %v", got, want)
+	}
+}
+
+func TestDeleteSKUKeepsHistory(t *testing.T) {
+	db := testutil.NewDB(t)
+	repo := NewProductRepository(db)
+	ctx := context.Background()
+	threshold := 5
+	skuPrice := 99.0
+
+	if err := repo.CreateProduct(ctx, &model.Product{ID: "p1", Name: "p1", Price: 10, LowStockThreshold: &threshold}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.CreateSKU(ctx, &model.SKU{ID: "a", ProductID: "p1", Code: "P1-A", Price: &skuPrice, Stock: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.AdjustStock(ctx, &model.InventoryAdjustment{ProductID: "p1", SKUID: "a", Type: model.AdjustmentReservation, Delta: -1}); err != nil {
+		t.Fatal(err)
+	}
+	var alerts int64
+	db.Model(&model.StockAlert{}).Where("sku_id = ? AND status = ?", "a", model.StockAlertOpen).Count(&alerts)
+	if alerts != 1 {
+		t.Fatalf("%d open alerts before delete, want 1", alerts)
+	}
+	if err := repo.DeleteSKU(ctx, "a"); err != nil {
+		t.Fatalf("DeleteSKU: %v", err)
+	}
+
+	if _, err := repo.GetSKU(ctx, "a"); err == nil {
+		t.Errorf("deleted sku still visible")
+	}
+	var prices, adjustments, openAlerts int64
+	db.Model(&model.ProductPrice{}).Where("sku_id = ?", "a").Count(&prices)
+	db.Model(&model.InventoryAdjustment{}).Where("sku_id = ?", "a").Count(&adjustments)
+	db.Model(&model.StockAlert{}).Where("sku_id = ? AND status = ?", "a", model.StockAlertOpen).Count(&openAlerts)
+	if prices == 0 || adjustments == 0 {
+		t.Errorf("history removed: %d prices, %d adjustments", prices, adjustments)
+	}
+	if openAlerts != 0 {
+		t.Errorf("%d open alerts for deleted sku", openAlerts)
+	}
+
+	// 取消订单仍可归还已删除 SKU 的库存
+	if err := repo.AdjustStock(ctx, &model.InventoryAdjustment{ProductID: "p1", SKUID: "a", Type: model.AdjustmentRelease, Delta: 1}); err != nil {
+		t.Errorf("release stock to deleted sku: %v", err)
+	}
+
+	// 已删除的 SKU 不参与价格过滤，编码可以重新使用
+	spec, _ := queryspec.Parse(url.Values{}, model.ProductQuerySchema)
+	min := 50.0
+	if products, _, err := repo.ListProducts(ctx, model.ProductFilter{MinPrice: &min}, spec); err != nil || len(products) != 0 {
+		t.Errorf("ListProducts = %v, %v; want no products", productIDs(products), err)
+	}
+	if err := repo.CreateSKU(ctx, &model.SKU{ID: "b", ProductID: "p1", Code: "P1-A"}); err != nil {
+		t.Errorf("reuse code of deleted sku: %v", err)
+	}
+	if err := repo.CreateSKU(ctx, &model.SKU{ID: "c", ProductID: "p1", Code: "P1-A"}); err == nil {
+		t.Errorf("duplicate code accepted for active skus")
+	}
+}
@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+	"gorm.io/gorm"
+)
+
+// maxSearchTerms 单次搜索最多使用的关键词数量
+const maxSearchTerms = 8
+
+// searchMode 商品全文搜索的实现方式，由数据库类型与可用模块决定
+type searchMode int
+
+const (
+	// searchLike 未启用 FTS5 的 SQLite，退化为 LIKE 匹配
+	searchLike searchMode = iota
+	// searchFTS5 SQLite FTS5 虚拟表 products_fts
+	searchFTS5
+	// searchPostgres Postgres tsvector 生成列 search_vector
+	searchPostgres
+)
+
+// searchRow 搜索命中的商品 ID 与相关度、高亮片段
+type searchRow struct {
+	ProductID            string
+	Score                float64
+	NameHighlight        string
+	DescriptionHighlight string
+}
+
+// SearchProducts 全文搜索商品，按相关度降序分页返回
+func (r *productRepository) SearchProducts(ctx context.Context, query string, filter model.ProductFilter, offset, limit int) ([]*model.ProductSearchHit, int64, error) {
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return []*model.ProductSearchHit{}, 0, nil
+	}
+
+	db := dbWithContext(ctx, r.db)
+	mode := r.searchMode()
+	base := r.applyFilter(ctx, r.matchQuery(db, mode, terms), filter)
+
+	var total int64
+	if err := base.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []searchRow
+	selectSQL, args := searchSelect(mode, terms)
+	if err := base.Select(selectSQL, args...).
+		Order("score DESC").
+		Order("products.id").
+		Offset(offset).
+		Limit(limit).
+		Scan(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	if len(rows) == 0 {
+		return []*model.ProductSearchHit{}, total, nil
+	}
+
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ProductID)
+	}
+	var products []*model.Product
+	if err := db.Preload("Categories").
+		Preload("SKUs", orderSKUs).
+		Preload("Images", orderImages).
+		Preload("Prices", activePrices).
+		Where("id IN ?", ids).
+		Find(&products).Error; err != nil {
+		return nil, 0, err
+	}
+	byID := make(map[string]*model.Product, len(products))
+	for _, p := range products {
+		byID[p.ID] = p
+	}
+
+	// 按相关度顺序组装结果，LIKE 模式下高亮在内存中生成
+	var highlighter *regexp.Regexp
+	if mode == searchLike {
+		highlighter = termPattern(terms)
+	}
+	hits := make([]*model.ProductSearchHit, 0, len(rows))
+	for _, row := range rows {
+		product, ok := byID[row.ProductID]
+		if !ok {
+			continue
+		}
+		hit := &model.ProductSearchHit{
+			Product:              product,
+			Score:                row.Score,
+			NameHighlight:        row.NameHighlight,
+			DescriptionHighlight: row.DescriptionHighlight,
+		}
+		if highlighter != nil {
+			hit.NameHighlight = highlighter.ReplaceAllString(product.Name, "<mark>$0</mark>")
+			hit.DescriptionHighlight = highlighter.ReplaceAllString(product.Description, "<mark>$0</mark>")
+		}
+		hits = append(hits, hit)
+	}
+	return hits, total, nil
+}
+
+// searchMode 检测当前数据库可用的全文搜索方式，结果在首次调用后缓存
+func (r *productRepository) searchMode() searchMode {
+	r.searchOnce.Do(func() {
+		switch r.db.Dialector.Name() {
+		case "postgres":
+			r.search = searchPostgres
+		case "sqlite":
+			if r.db.Migrator().HasTable("products_fts") {
+				r.search = searchFTS5
+			}
+		}
+	})
+	return r.search
+}
+
+// matchQuery 构造匹配所有关键词的商品查询，每个关键词按前缀匹配
+func (r *productRepository) matchQuery(db *gorm.DB, mode searchMode, terms []string) *gorm.DB {
+	query := db.Model(&model.Product{})
+	switch mode {
+	case searchFTS5:
+		return query.Joins("JOIN products_fts ON products_fts.product_id = products.id").
+			Where("products_fts MATCH ?", fts5Expression(terms))
+	case searchPostgres:
+		return query.Where("products.search_vector @@ to_tsquery('simple', ?)", tsQuery(terms))
+	default:
+		for _, term := range terms {
+			pattern := "%" + term + "%"
+			query = query.Where("(products.name LIKE ? OR products.description LIKE ?)", pattern, pattern)
+		}
+		return query
+	}
+}
+
+// searchSelect 返回查询相关度与高亮片段的 SELECT 子句
+// 名称的权重高于描述；描述只返回包含关键词的片段
+func searchSelect(mode searchMode, terms []string) (string, []interface{}) {
+	switch mode {
+	case searchFTS5:
+		// bm25 越小越相关，取反后与其他实现保持“越大越相关”
+		return `products.id AS product_id,
+			-bm25(products_fts, 0.0, 10.0, 1.0) AS score,
+			highlight(products_fts, 1, '<mark>', '</mark>') AS name_highlight,
+			snippet(products_fts, 2, '<mark>', '</mark>', '…', 16) AS description_highlight`, nil
+	case searchPostgres:
+		q := tsQuery(terms)
+		return `products.id AS product_id,
+			ts_rank(products.search_vector, to_tsquery('simple', ?)) AS score,
+			ts_headline('simple', products.name, to_tsquery('simple', ?), 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true') AS name_highlight,
+			ts_headline('simple', products.description, to_tsquery('simple', ?), 'StartSel=<mark>, StopSel=</mark>, MaxWords=16, MinWords=4') AS description_highlight`,
+			[]interface{}{q, q, q}
+	default:
+		// 名称命中计 2 分，描述命中计 1 分
+		var parts []string
+		var args []interface{}
+		for _, term := range terms {
+			pattern := "%" + term + "%"
+			parts = append(parts, "(CASE WHEN products.name LIKE ? THEN 2 ELSE 0 END + CASE WHEN products.description LIKE ? THEN 1 ELSE 0 END)")
+			args = append(args, pattern, pattern)
+		}
+		return "products.id AS product_id, " + strings.Join(parts, " + ") + " AS score", args
+	}
+}
+
+// searchTerms 将搜索词按空白与标点拆分为关键词
+// 关键词只包含字母与数字，因此可以安全地拼入 FTS5 与 tsquery 表达式
+func searchTerms(query string) []string {
+	terms := strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(terms) > maxSearchTerms {
+		terms = terms[:maxSearchTerms]
+	}
+	return terms
+}
+
+// fts5Expression 生成 FTS5 查询表达式，例如 {name description} : ("red"* AND "sho"*)
+func fts5Expression(terms []string) string {
+	quoted := make([]string, 0, len(terms))
+	for _, term := range terms {
+		quoted = append(quoted, `"`+term+`"*`)
+	}
+	return "{name description} : (" + strings.Join(quoted, " AND ") + ")"
+}
+
+// tsQuery 生成 Postgres tsquery 表达式，例如 red:* & sho:*
+func tsQuery(terms []string) string {
+	parts := make([]string, 0, len(terms))
+	for _, term := range terms {
+		parts = append(parts, term+":*")
+	}
+	return strings.Join(parts, " & ")
+}
+
+// termPattern 生成不区分大小写匹配任一关键词的正则，用于 LIKE 模式的高亮
+func termPattern(terms []string) *regexp.Regexp {
+	quoted := make([]string, 0, len(terms))
+	for _, term := range terms {
+		quoted = append(quoted, regexp.QuoteMeta(term))
+	}
+	return regexp.MustCompile("(?i)" + strings.Join(quoted, "|"))
+}
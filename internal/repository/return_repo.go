@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/queryspec"
+	"gorm.io/gorm"
+)
+
+// ReturnRepository 退货申请数据访问接口
+type ReturnRepository interface {
+	CreateReturn(ctx context.Context, ret *model.ReturnRequest) error
+	GetReturn(ctx context.Context, id string) (*model.ReturnRequest, error)
+	UpdateReturn(ctx context.Context, ret *model.ReturnRequest) error
+	ListReturns(ctx context.Context, orderID, userID string, status model.ReturnStatus, spec *queryspec.Spec) ([]*model.ReturnRequest, *queryspec.PageInfo, error)
+	ListReturnsByOrder(ctx context.Context, orderID string) ([]*model.ReturnRequest, error)
+}
+
+type returnRepository struct {
+	db *gorm.DB
+}
+
+// NewReturnRepository 创建退货申请仓储实例
+func NewReturnRepository(db *gorm.DB) ReturnRepository {
+	return &returnRepository{db: db}
+}
+
+func (r *returnRepository) CreateReturn(ctx context.Context, ret *model.ReturnRequest) error {
+	return dbWithContext(ctx, r.db).Create(ret).Error
+}
+
+func (r *returnRepository) GetReturn(ctx context.Context, id string) (*model.ReturnRequest, error) {
+	var ret model.ReturnRequest
+	if err := dbWithContext(ctx, r.db).Preload("Items").Where("id = ?", id).First(&ret).Error; err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+// UpdateReturn 更新退货申请主记录（明细创建后不可修改）
+func (r *returnRepository) UpdateReturn(ctx context.Context, ret *model.ReturnRequest) error {
+	return dbWithContext(ctx, r.db).Omit("Items").Save(ret).Error
+}
+
+func (r *returnRepository) ListReturns(ctx context.Context, orderID, userID string, status model.ReturnStatus, spec *queryspec.Spec) ([]*model.ReturnRequest, *queryspec.PageInfo, error) {
+	var returns []*model.ReturnRequest
+
+	query := dbWithContext(ctx, r.db).Model(&model.ReturnRequest{})
+	if orderID != "" {
+		query = query.Where("order_id = ?", orderID)
+	}
+	if userID != "" {
+		query = query.Where("user_id = ?", userID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	query = spec.ApplyFilters(query)
+
+	page, err := spec.Find(query.Preload("Items"), &returns)
+	if err != nil {
+		return nil, nil, err
+	}
+	return returns, page, nil
+}
+
+func (r *returnRepository) ListReturnsByOrder(ctx context.Context, orderID string) ([]*model.ReturnRequest, error) {
+	var returns []*model.ReturnRequest
+	if err := dbWithContext(ctx, r.db).Preload("Items").Where("order_id = ?", orderID).Order("created_at ASC").Find(&returns).Error; err != nil {
+		return nil, err
+	}
+	return returns, nil
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// txKey 事务在 context 中的键
+type txKey struct{}
+
+// txState 进行中的事务及其提交后要执行的回调
+type txState struct {
+	tx          *gorm.DB
+	afterCommit []func()
+}
+
+// TxManager 事务管理器
+// 在 fn 内通过 ctx 传递事务，各仓储方法会自动加入该事务，
+// 从而让跨仓储、跨服务的多个操作原子地提交或回滚
+type TxManager interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+	// AfterCommit 在最外层事务提交后执行 fn，事务回滚时不执行；ctx 中没有事务时立即执行
+	// 用于只应在数据确实写入后发生的副作用，如业务指标计数
+	AfterCommit(ctx context.Context, fn func())
+}
+
+type txManager struct {
+	db *gorm.DB
+}
+
+// NewTxManager 创建事务管理器实例
+func NewTxManager(db *gorm.DB) TxManager {
+	return &txManager{db: db}
+}
+
+// Transaction 在事务中执行 fn
+// 若 ctx 中已存在事务则直接复用（嵌套调用不会开启新事务）
+func (m *txManager) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*txState); ok {
+		return fn(ctx)
+	}
+	state := &txState{}
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		state.tx = tx
+		return fn(context.WithValue(ctx, txKey{}, state))
+	})
+	if err != nil {
+		return err
+	}
+	for _, f := range state.afterCommit {
+		f()
+	}
+	return nil
+}
+
+func (m *txManager) AfterCommit(ctx context.Context, fn func()) {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		state.afterCommit = append(state.afterCommit, fn)
+		return
+	}
+	fn()
+}
+
+// dbWithContext 返回当前 ctx 应使用的数据库连接
+// ctx 中存在事务时使用事务连接，否则使用仓储默认连接
+func dbWithContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		return state.tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
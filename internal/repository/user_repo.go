@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/innovationmech/simple-cli/internal/model"
 	"gorm.io/gorm"
@@ -11,7 +12,12 @@ type UserRepository interface {
 	CreateUser(ctx context.Context, user *model.User) error
 	GetUser(ctx context.Context, id string) (*model.User, error)
 	UpdateUser(ctx context.Context, user *model.User) error
+	// DeleteUser 软删除用户，地址簿与购物车保留以便恢复
 	DeleteUser(ctx context.Context, id string) error
+	// RestoreUser 恢复已软删除的用户
+	RestoreUser(ctx context.Context, id string) error
+	// PurgeUsers 彻底删除在 before 之前软删除的用户及其地址簿与购物车，返回删除的用户数
+	PurgeUsers(ctx context.Context, before time.Time) (int64, error)
 }
 
 type userRepository struct {
@@ -23,7 +29,7 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 }
 
 func (r *userRepository) CreateUser(ctx context.Context, user *model.User) error {
-	if err := r.db.Create(user).Error; err != nil {
+	if err := dbWithContext(ctx, r.db).Create(user).Error; err != nil {
 		return err
 	}
 	return nil
@@ -31,22 +37,68 @@ func (r *userRepository) CreateUser(ctx context.Context, user *model.User) error
 
 func (r *userRepository) GetUser(ctx context.Context, id string) (*model.User, error) {
 	var user model.User
-	if err := r.db.Where("id = ?", id).First(&user).Error; err != nil {
+	if err := dbWithContext(ctx, r.db).Where("id = ?", id).First(&user).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
 func (r *userRepository) UpdateUser(ctx context.Context, user *model.User) error {
-	if err := r.db.Save(user).Error; err != nil {
+	if err := dbWithContext(ctx, r.db).Save(user).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
 func (r *userRepository) DeleteUser(ctx context.Context, id string) error {
-	if err := r.db.Delete(&model.User{}, id).Error; err != nil {
-		return err
+	result := dbWithContext(ctx, r.db).Delete(&model.User{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
 	}
 	return nil
 }
+
+func (r *userRepository) RestoreUser(ctx context.Context, id string) error {
+	result := dbWithContext(ctx, r.db).Unscoped().Model(&model.User{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *userRepository) PurgeUsers(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	err := dbWithContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var ids []string
+		if err := tx.Unscoped().Model(&model.User{}).
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		if err := tx.Where("user_id IN ?", ids).Delete(&model.Address{}).Error; err != nil {
+			return err
+		}
+		carts := tx.Model(&model.Cart{}).Select("id").Where("user_id IN ?", ids)
+		if err := tx.Where("cart_id IN (?)", carts).Delete(&model.CartItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id IN ?", ids).Delete(&model.Cart{}).Error; err != nil {
+			return err
+		}
+		result := tx.Unscoped().Where("id IN ?", ids).Delete(&model.User{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	return purged, err
+}
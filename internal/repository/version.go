@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"github.com/innovationmech/simple-cli/internal/domain"
+	"gorm.io/gorm"
+)
+
+// ErrVersionConflict 按版本号条件更新时记录已被修改（版本号不一致）
+var ErrVersionConflict = domain.Keyed(domain.CodePreconditionFailed, "version.conflict", "version conflict")
+
+// updateVersioned 以 *version 为期望版本号更新整条记录，成功后版本号加一
+// 期望版本号与库中不一致时不做修改并返回 ErrVersionConflict；omit 为不更新的字段或关联
+func updateVersioned(tx *gorm.DB, value interface{}, version *int, omit ...string) error {
+	expected := *version
+	*version = expected + 1
+	result := tx.Model(value).Where("version = ?", expected).Select("*").Omit(omit...).Updates(value)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrVersionConflict
+	}
+	if result.Error != nil {
+		*version = expected
+		return result.Error
+	}
+	return nil
+}
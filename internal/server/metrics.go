@@ -0,0 +1,45 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/innovationmech/simple-cli/internal/config"
+	"github.com/innovationmech/simple-cli/internal/metrics"
+	"github.com/innovationmech/simple-cli/internal/middleware"
+)
+
+// useMetrics 开启指标时记录请求并采集连接池状态；未配置管理端口时指标挂载在业务端口上
+func useMetrics(server *gin.Engine, cfg config.MetricsConfig) {
+	if !cfg.Enabled {
+		return
+	}
+	server.Use(middleware.Metrics())
+
+	sqlDB, err := config.GetDB().DB()
+	if err != nil {
+		panic(err)
+	}
+	if err := metrics.RegisterDB(sqlDB); err != nil {
+		panic(err)
+	}
+
+	if cfg.AdminPort == 0 {
+		server.GET(cfg.Path, gin.WrapH(metrics.Handler()))
+	}
+}
+
+// NewAdminServer 配置了管理端口时返回仅暴露指标的服务，否则返回 nil
+func NewAdminServer() *http.Server {
+	cfg := config.Metrics()
+	if !cfg.Enabled || cfg.AdminPort == 0 {
+		return nil
+	}
+	mux := http.NewServeMux()
+	mux.Handle(cfg.Path, metrics.Handler())
+	return &http.Server{
+		Addr:    fmt.Sprintf(":%d", cfg.AdminPort),
+		Handler: mux,
+	}
+}
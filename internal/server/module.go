@@ -3,6 +3,7 @@ package server
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/innovationmech/simple-cli/internal/app"
+	"github.com/innovationmech/simple-cli/internal/openapi"
 )
 
 // Module 定义了业务模块的标准接口
@@ -12,5 +13,10 @@ type Module interface {
 	// Container 集中管理所有单例依赖，确保组件被多处使用时共享同一实例
 	Init(container *app.Container) error
 	// RegisterRoutes 注册该模块的所有路由
-	RegisterRoutes(router *gin.Engine)
+	RegisterRoutes(router *gin.RouterGroup)
+}
+
+// Documented 模块可选实现的接口，为 OpenAPI 文档描述其路由的参数与请求/响应结构
+type Documented interface {
+	Routes() []openapi.Route
 }
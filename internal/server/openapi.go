@@ -0,0 +1,61 @@
+package server
+
+import (
+	_ "embed"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/innovationmech/simple-cli/internal/cmd/version"
+	"github.com/innovationmech/simple-cli/internal/openapi"
+)
+
+//go:embed docs.html
+var docsPage []byte
+
+// Document 根据引擎上已注册的路由与各模块的路由描述生成 OpenAPI 文档
+// 只描述当前版本的接口，路径相对于 APIBasePath，旧的根路径别名不出现在文档中
+func Document(engine *gin.Engine, modules []Module) *openapi.Document {
+	var routes []openapi.Route
+	for _, m := range modules {
+		if d, ok := m.(Documented); ok {
+			routes = append(routes, d.Routes()...)
+		}
+	}
+	info := openapi.Info{
+		Title:   "Simple CLI API",
+		Version: version.GetVersion(),
+	}
+	var registered []gin.RouteInfo
+	for _, ri := range engine.Routes() {
+		if path, ok := strings.CutPrefix(ri.Path, APIBasePath+"/"); ok {
+			ri.Path = "/" + path
+			registered = append(registered, ri)
+		}
+	}
+	doc := openapi.Build(info, registered, routes)
+	doc.Servers = []openapi.Server{{URL: APIBasePath}}
+	return doc
+}
+
+// ExportDocument 不连接数据库生成 OpenAPI 文档，供命令行导出使用
+// 路由注册只引用处理器的方法值，因此未初始化的模块也能注册出完整的路由表
+func ExportDocument() *openapi.Document {
+	gin.SetMode(gin.ReleaseMode)
+	engine := gin.New()
+	modules := Modules()
+	for _, m := range modules {
+		m.RegisterRoutes(engine.Group(APIBasePath))
+	}
+	return Document(engine, modules)
+}
+
+// registerDocs 挂载 /openapi.json 与 Redoc 文档页 /docs
+func registerDocs(engine *gin.Engine, doc *openapi.Document) {
+	engine.GET("/openapi.json", func(c *gin.Context) {
+		c.JSON(http.StatusOK, doc)
+	})
+	engine.GET("/docs", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", docsPage)
+	})
+}
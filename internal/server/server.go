@@ -1,18 +1,51 @@
 package server
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/gin-gonic/gin"
 	"github.com/innovationmech/simple-cli/internal/app"
 	"github.com/innovationmech/simple-cli/internal/config"
+	"github.com/innovationmech/simple-cli/internal/handler/cart"
+	"github.com/innovationmech/simple-cli/internal/handler/category"
+	"github.com/innovationmech/simple-cli/internal/handler/coupon"
 	"github.com/innovationmech/simple-cli/internal/handler/health"
+	"github.com/innovationmech/simple-cli/internal/handler/inventory"
+	"github.com/innovationmech/simple-cli/internal/handler/media"
+	"github.com/innovationmech/simple-cli/internal/handler/meta"
 	"github.com/innovationmech/simple-cli/internal/handler/order"
 	"github.com/innovationmech/simple-cli/internal/handler/payment"
 	"github.com/innovationmech/simple-cli/internal/handler/product"
+	"github.com/innovationmech/simple-cli/internal/handler/returns"
 	"github.com/innovationmech/simple-cli/internal/handler/user"
+	"github.com/innovationmech/simple-cli/internal/logging"
+	"github.com/innovationmech/simple-cli/internal/middleware"
+	"github.com/innovationmech/simple-cli/internal/openapi"
+	"github.com/innovationmech/simple-cli/internal/queryspec"
+	"go.uber.org/zap"
 )
 
+// APIBasePath 当前版本接口的路径前缀，新版本上线时以新的前缀与之并存
+const APIBasePath = "/api/v1"
+
 func NewServer() *gin.Engine {
-	server := gin.Default()
+	// gin 的调试信息与路由注册信息写入 debug 日志，访问日志与 panic 恢复使用结构化日志
+	gin.DebugPrintRouteFunc = func(method, path, handler string, handlers int) {
+		logging.L().Debug("route registered",
+			zap.String("method", method),
+			zap.String("path", path),
+			zap.String("handler", handler),
+		)
+	}
+	gin.DebugPrintFunc = func(format string, values ...interface{}) {
+		logging.L().Debug(strings.TrimSpace(fmt.Sprintf(format, values...)))
+	}
+	server := gin.New()
+	server.Use(middleware.RequestID(), middleware.AccessLog())
+	// 指标在 panic 恢复之外记录，恢复后写出的 500 同样计入
+	useMetrics(server, config.Metrics())
+	server.Use(middleware.Recovery())
 
 	// 创建依赖容器，集中管理所有单例依赖
 	container, err := app.NewContainer(config.GetDB())
@@ -20,25 +53,79 @@ func NewServer() *gin.Engine {
 		panic(err)
 	}
 
-	// 注册所有业务模块
-	// 新增模块只需在此切片中追加即可
-	// - User/Product: 使用手动依赖注入（通过 Container）
-	// - Order: 使用 Google Wire 框架（编译时依赖注入）
-	// - Payment: 使用 Uber fx 框架（运行时依赖注入）
-	modules := []Module{
-		&health.HealthModule{},
-		&user.UserModule{},
-		&product.ProductModule{},
-		&order.OrderModule{},     // 使用 Wire 依赖注入
-		&payment.PaymentModule{}, // 使用 fx 依赖注入
+	// 处理器通过 c.Error 返回的错误统一在此按请求语言转换为响应；
+	// 幂等中间件在其外层，以便保存包括错误在内的完整响应
+	server.Use(
+		middleware.Locale(),
+		middleware.Idempotency(container.IdempotencyRepo, config.IdempotencyTTL(), config.IdempotencyLease(), config.IdempotencyMaxBodyBytes()),
+		middleware.Errors(),
+	)
+	// 按文档校验请求；文档在路由注册后才能生成，校验器先挂载、稍后载入文档
+	validator := openapi.NewValidator()
+	mode, err := middleware.ParseValidationMode(config.OpenAPIValidationMode())
+	if err != nil {
+		panic(err)
+	}
+	if mode != middleware.ValidationOff || config.OpenAPIValidateResponses() {
+		server.Use(middleware.OpenAPI(validator, mode, config.OpenAPIValidateResponses()))
+	}
+	if err := middleware.ConfigureValidator(); err != nil {
+		panic(err)
 	}
 
+	// 列表游标使用配置的密钥签名，保证多实例间游标通用
+	queryspec.SetCursorSecret(config.CursorSecret())
+
+	// 各模块的路由挂载在版本前缀下；旧的根路径作为已弃用的别名保留，调用方迁移后可关闭
+	v1 := server.Group(APIBasePath)
+	var legacy *gin.RouterGroup
+	if config.LegacyRoutesEnabled() {
+		legacy = server.Group("/", middleware.Deprecated(middleware.Deprecation{
+			Since:  config.LegacyRoutesDeprecatedAt(),
+			Sunset: config.LegacyRoutesSunset(),
+			Successor: func(c *gin.Context) string {
+				return APIBasePath + c.Request.URL.Path
+			},
+		}))
+	}
+
+	modules := Modules()
 	for _, m := range modules {
 		if err := m.Init(container); err != nil {
 			panic(err)
 		}
-		m.RegisterRoutes(server)
+		m.RegisterRoutes(v1)
+		if legacy != nil {
+			m.RegisterRoutes(legacy)
+		}
 	}
 
+	// 文档基于已注册的路由生成，因此在所有模块注册之后挂载
+	doc := Document(server, modules)
+	validator.Load(doc)
+	registerDocs(server, doc)
+
 	return server
 }
+
+// Modules 返回所有业务模块
+// 新增模块只需在此切片中追加即可
+// - User/Product: 使用手动依赖注入（通过 Container）
+// - Order: 使用 Google Wire 框架（编译时依赖注入）
+// - Payment: 使用 Uber fx 框架（运行时依赖注入）
+func Modules() []Module {
+	return []Module{
+		&health.HealthModule{},
+		&user.UserModule{},
+		&product.ProductModule{},
+		&order.OrderModule{},     // 使用 Wire 依赖注入
+		&payment.PaymentModule{}, // 使用 fx 依赖注入
+		&returns.ReturnModule{},
+		&cart.CartModule{},
+		&coupon.CouponModule{},
+		&category.CategoryModule{},
+		&media.MediaModule{},
+		&inventory.InventoryModule{},
+		&meta.MetaModule{},
+	}
+}
@@ -0,0 +1,147 @@
+package address
+
+import (
+	"context"
+	"errors"
+
+	"github.com/innovationmech/simple-cli/internal/domain"
+	"github.com/innovationmech/simple-cli/internal/interfaces"
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/repository"
+	"gorm.io/gorm"
+)
+
+// AddressSrv 是 AddressService 接口的别名，方便外部引用
+type AddressSrv = interfaces.AddressService
+
+var (
+	// ErrUserNotFound 地址所属用户不存在
+	ErrUserNotFound = domain.Keyed(domain.CodeNotFound, "user.not_found", "user not found")
+	// ErrAddressNotFound 地址不存在或不属于该用户
+	ErrAddressNotFound = domain.Keyed(domain.CodeNotFound, "address.not_found", "address not found")
+)
+
+// AddressServiceConfig 收货地址服务配置
+type AddressServiceConfig struct {
+	AddressRepository repository.AddressRepository
+	UserRepository    repository.UserRepository
+}
+
+// AddressServiceOption 函数式选项模式
+type AddressServiceOption func(*AddressServiceConfig)
+
+type addressService struct {
+	config *AddressServiceConfig
+}
+
+// WithAddressRepository 注入收货地址仓储依赖
+func WithAddressRepository(repo repository.AddressRepository) AddressServiceOption {
+	return func(config *AddressServiceConfig) {
+		config.AddressRepository = repo
+	}
+}
+
+// WithUserRepository 注入用户仓储依赖，用于校验地址所属用户
+func WithUserRepository(repo repository.UserRepository) AddressServiceOption {
+	return func(config *AddressServiceConfig) {
+		config.UserRepository = repo
+	}
+}
+
+// NewAddressService 创建收货地址服务实例
+// 使用函数式选项模式注入依赖
+func NewAddressService(opts ...AddressServiceOption) (AddressSrv, error) {
+	config := &AddressServiceConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	if config.AddressRepository == nil {
+		return nil, errors.New("address repository is required")
+	}
+	if config.UserRepository == nil {
+		return nil, errors.New("user repository is required")
+	}
+	return &addressService{config: config}, nil
+}
+
+func (s *addressService) CreateAddress(ctx context.Context, address *model.Address) error {
+	if _, err := s.config.UserRepository.GetUser(ctx, address.UserID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	// 用户的第一个地址自动成为默认地址
+	existing, err := s.config.AddressRepository.ListAddressesByUser(ctx, address.UserID)
+	if err != nil {
+		return err
+	}
+	if len(existing) == 0 {
+		address.IsDefault = true
+	}
+
+	return s.config.AddressRepository.CreateAddress(ctx, address)
+}
+
+func (s *addressService) GetAddress(ctx context.Context, userID, id string) (*model.Address, error) {
+	address, err := s.config.AddressRepository.GetAddress(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAddressNotFound
+		}
+		return nil, err
+	}
+	// 地址不属于该用户时按不存在处理，避免泄露其他用户的数据
+	if address.UserID != userID {
+		return nil, ErrAddressNotFound
+	}
+	return address, nil
+}
+
+func (s *addressService) UpdateAddress(ctx context.Context, address *model.Address) error {
+	if _, err := s.GetAddress(ctx, address.UserID, address.ID); err != nil {
+		return err
+	}
+	return s.config.AddressRepository.UpdateAddress(ctx, address)
+}
+
+func (s *addressService) DeleteAddress(ctx context.Context, userID, id string) error {
+	address, err := s.GetAddress(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.config.AddressRepository.DeleteAddress(ctx, id); err != nil {
+		return err
+	}
+
+	// 删除默认地址后，将最近使用的地址提升为默认地址
+	if address.IsDefault {
+		remaining, err := s.config.AddressRepository.ListAddressesByUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if len(remaining) > 0 {
+			remaining[0].IsDefault = true
+			return s.config.AddressRepository.UpdateAddress(ctx, remaining[0])
+		}
+	}
+	return nil
+}
+
+func (s *addressService) ListAddresses(ctx context.Context, userID string) ([]*model.Address, error) {
+	return s.config.AddressRepository.ListAddressesByUser(ctx, userID)
+}
+
+func (s *addressService) SetDefaultAddress(ctx context.Context, userID, id string) error {
+	address, err := s.GetAddress(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if address.IsDefault {
+		return nil
+	}
+	address.IsDefault = true
+	return s.config.AddressRepository.UpdateAddress(ctx, address)
+}
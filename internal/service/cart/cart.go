@@ -0,0 +1,338 @@
+package cart
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/innovationmech/simple-cli/internal/domain"
+	"github.com/innovationmech/simple-cli/internal/interfaces"
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/repository"
+	"gorm.io/gorm"
+)
+
+// CartSrv 是 CartService 接口的别名，方便外部引用
+type CartSrv = interfaces.CartService
+
+var (
+	// ErrItemNotInCart 购物车中没有该商品
+	ErrItemNotInCart = domain.Keyed(domain.CodeNotFound, "cart.item_not_found", "product not in cart")
+	// ErrCartEmpty 购物车为空，不能结算
+	ErrCartEmpty = domain.Keyed(domain.CodeUnprocessable, "cart.empty", "cart is empty")
+	// ErrProductNotFound 商品不存在
+	ErrProductNotFound = domain.Keyed(domain.CodeNotFound, "product.not_found", "product not found")
+	// ErrUserNotFound 用户不存在
+	ErrUserNotFound = domain.Keyed(domain.CodeNotFound, "user.not_found", "user not found")
+)
+
+// CartServiceConfig 购物车服务配置
+type CartServiceConfig struct {
+	CartRepository    repository.CartRepository
+	ProductRepository repository.ProductRepository
+	UserRepository    repository.UserRepository
+	OrderService      interfaces.OrderService
+	TxManager         repository.TxManager
+	// IdleTimeout 购物车闲置超过该时长后自动清空，为 0 表示不过期
+	IdleTimeout time.Duration
+}
+
+// CartServiceOption 函数式选项模式
+type CartServiceOption func(*CartServiceConfig)
+
+type cartService struct {
+	config *CartServiceConfig
+}
+
+// WithCartRepository 注入购物车仓储依赖
+func WithCartRepository(repo repository.CartRepository) CartServiceOption {
+	return func(config *CartServiceConfig) {
+		config.CartRepository = repo
+	}
+}
+
+// WithProductRepository 注入商品仓储依赖，用于实时校验价格与库存
+func WithProductRepository(repo repository.ProductRepository) CartServiceOption {
+	return func(config *CartServiceConfig) {
+		config.ProductRepository = repo
+	}
+}
+
+// WithUserRepository 注入用户仓储依赖
+func WithUserRepository(repo repository.UserRepository) CartServiceOption {
+	return func(config *CartServiceConfig) {
+		config.UserRepository = repo
+	}
+}
+
+// WithOrderService 注入订单服务依赖，用于结算下单
+func WithOrderService(srv interfaces.OrderService) CartServiceOption {
+	return func(config *CartServiceConfig) {
+		config.OrderService = srv
+	}
+}
+
+// WithTxManager 注入事务管理器
+func WithTxManager(txManager repository.TxManager) CartServiceOption {
+	return func(config *CartServiceConfig) {
+		config.TxManager = txManager
+	}
+}
+
+// WithIdleTimeout 设置购物车闲置过期时间
+func WithIdleTimeout(timeout time.Duration) CartServiceOption {
+	return func(config *CartServiceConfig) {
+		config.IdleTimeout = timeout
+	}
+}
+
+// NewCartService 创建购物车服务实例
+// 使用函数式选项模式注入依赖
+func NewCartService(opts ...CartServiceOption) (CartSrv, error) {
+	config := &CartServiceConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	if config.CartRepository == nil {
+		return nil, errors.New("cart repository is required")
+	}
+	if config.ProductRepository == nil {
+		return nil, errors.New("product repository is required")
+	}
+	if config.UserRepository == nil {
+		return nil, errors.New("user repository is required")
+	}
+	if config.OrderService == nil {
+		return nil, errors.New("order service is required")
+	}
+	if config.TxManager == nil {
+		return nil, errors.New("tx manager is required")
+	}
+	return &cartService{config: config}, nil
+}
+
+func (s *cartService) GetCart(ctx context.Context, userID string) (*model.CartDetail, error) {
+	cart, err := s.loadCart(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildDetail(ctx, userID, cart), nil
+}
+
+func (s *cartService) AddItem(ctx context.Context, userID, productID, skuID string, quantity int) (*model.CartDetail, error) {
+	cart, err := s.getOrCreateCart(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 已在购物车中的商品累加数量
+	for _, item := range cart.Items {
+		if item.ProductID == productID && item.SKUID == skuID {
+			quantity += item.Quantity
+			break
+		}
+	}
+	return s.setItem(ctx, userID, cart, productID, skuID, quantity)
+}
+
+func (s *cartService) UpdateItem(ctx context.Context, userID, productID, skuID string, quantity int) (*model.CartDetail, error) {
+	if quantity == 0 {
+		return s.RemoveItem(ctx, userID, productID, skuID)
+	}
+
+	cart, err := s.loadCart(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if cart == nil || !hasItem(cart, productID, skuID) {
+		return nil, ErrItemNotInCart
+	}
+	return s.setItem(ctx, userID, cart, productID, skuID, quantity)
+}
+
+func (s *cartService) RemoveItem(ctx context.Context, userID, productID, skuID string) (*model.CartDetail, error) {
+	cart, err := s.loadCart(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if cart == nil || !hasItem(cart, productID, skuID) {
+		return nil, ErrItemNotInCart
+	}
+	if err := s.config.CartRepository.RemoveItem(ctx, cart.ID, productID, skuID); err != nil {
+		return nil, err
+	}
+	return s.GetCart(ctx, userID)
+}
+
+func (s *cartService) ClearCart(ctx context.Context, userID string) error {
+	cart, err := s.loadCart(ctx, userID)
+	if err != nil || cart == nil {
+		return err
+	}
+	return s.config.CartRepository.ClearCart(ctx, cart.ID)
+}
+
+// Checkout 购物车结算
+// 在同一事务中创建订单（含库存扣减）并清空购物车，任一步骤失败则整体回滚
+func (s *cartService) Checkout(ctx context.Context, userID, addressID string, couponCodes []string) (*model.Order, error) {
+	cart, err := s.loadCart(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if cart == nil || len(cart.Items) == 0 {
+		return nil, ErrCartEmpty
+	}
+
+	order := &model.Order{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		AddressID:   addressID,
+		CouponCodes: couponCodes,
+	}
+	for _, item := range cart.Items {
+		order.Items = append(order.Items, model.OrderItem{
+			ProductID: item.ProductID,
+			SKUID:     item.SKUID,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	err = s.config.TxManager.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.config.OrderService.CreateOrder(ctx, order); err != nil {
+			return err
+		}
+		return s.config.CartRepository.ClearCart(ctx, cart.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// setItem 校验商品、规格与库存后写入购物车行
+func (s *cartService) setItem(ctx context.Context, userID string, cart *model.Cart, productID, skuID string, quantity int) (*model.CartDetail, error) {
+	product, err := s.config.ProductRepository.GetProduct(ctx, productID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+	sku, err := product.SelectSKU(skuID)
+	if err != nil {
+		return nil, err
+	}
+	if product.AvailableStock(sku) < quantity {
+		return nil, repository.ErrInsufficientStock
+	}
+
+	if err := s.config.CartRepository.SetItem(ctx, cart.ID, productID, skuID, quantity); err != nil {
+		return nil, err
+	}
+	return s.GetCart(ctx, userID)
+}
+
+// loadCart 读取用户购物车，不存在时返回 nil
+// 闲置超时的购物车会被清空
+func (s *cartService) loadCart(ctx context.Context, userID string) (*model.Cart, error) {
+	cart, err := s.config.CartRepository.GetCartByUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if s.isExpired(cart) && len(cart.Items) > 0 {
+		if err := s.config.CartRepository.ClearCart(ctx, cart.ID); err != nil {
+			return nil, err
+		}
+		cart.Items = nil
+		cart.UpdatedAt = time.Now()
+	}
+	return cart, nil
+}
+
+// getOrCreateCart 读取用户购物车，不存在时为其创建
+func (s *cartService) getOrCreateCart(ctx context.Context, userID string) (*model.Cart, error) {
+	cart, err := s.loadCart(ctx, userID)
+	if err != nil || cart != nil {
+		return cart, err
+	}
+
+	if _, err := s.config.UserRepository.GetUser(ctx, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	cart = &model.Cart{ID: uuid.New().String(), UserID: userID}
+	if err := s.config.CartRepository.CreateCart(ctx, cart); err != nil {
+		return nil, err
+	}
+	return cart, nil
+}
+
+// buildDetail 按商品当前价格与库存生成购物车视图
+func (s *cartService) buildDetail(ctx context.Context, userID string, cart *model.Cart) *model.CartDetail {
+	detail := &model.CartDetail{UserID: userID, Lines: []model.CartLine{}}
+	if cart == nil {
+		return detail
+	}
+
+	detail.Checkoutable = len(cart.Items) > 0
+	for _, item := range cart.Items {
+		line := model.CartLine{ProductID: item.ProductID, SKUID: item.SKUID, Quantity: item.Quantity}
+		product, err := s.config.ProductRepository.GetProduct(ctx, item.ProductID)
+		var sku *model.SKU
+		if err == nil {
+			sku, err = product.SelectSKU(item.SKUID)
+		}
+		if err == nil {
+			line.ProductName = product.Name
+			line.UnitPrice = product.UnitPrice(sku)
+			line.Stock = product.AvailableStock(sku)
+			if sku != nil {
+				line.SKUCode = sku.Code
+				line.Attributes = sku.Attributes
+			}
+		}
+		switch {
+		case err != nil:
+			line.Issue = "product no longer available"
+		case line.Stock < item.Quantity:
+			line.Issue = "insufficient stock"
+		default:
+			line.Available = true
+		}
+		line.Amount = line.UnitPrice * float64(line.Quantity)
+		if line.Available {
+			detail.TotalAmount += line.Amount
+		} else {
+			detail.Checkoutable = false
+		}
+		detail.Lines = append(detail.Lines, line)
+	}
+
+	if s.config.IdleTimeout > 0 && len(cart.Items) > 0 {
+		expiresAt := cart.UpdatedAt.Add(s.config.IdleTimeout)
+		detail.ExpiresAt = &expiresAt
+	}
+	return detail
+}
+
+// isExpired 判断购物车是否已闲置超时
+func (s *cartService) isExpired(cart *model.Cart) bool {
+	return s.config.IdleTimeout > 0 && time.Since(cart.UpdatedAt) > s.config.IdleTimeout
+}
+
+// hasItem 判断购物车中是否存在指定商品规格
+func hasItem(cart *model.Cart, productID, skuID string) bool {
+	for _, item := range cart.Items {
+		if item.ProductID == productID && item.SKUID == skuID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,203 @@
+package category
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/innovationmech/simple-cli/internal/domain"
+	"github.com/innovationmech/simple-cli/internal/interfaces"
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/repository"
+	"gorm.io/gorm"
+)
+
+// CategorySrv 是 CategoryService 接口的别名，方便外部引用
+type CategorySrv = interfaces.CategoryService
+
+var (
+	// ErrCategoryNotEmpty 非级联删除时分类下仍有商品或子分类
+	ErrCategoryNotEmpty = domain.Keyed(domain.CodeConflict, "category.not_empty", "category still has products or child categories")
+	// ErrCategoryNotFound 分类不存在
+	ErrCategoryNotFound = domain.Keyed(domain.CodeNotFound, "category.not_found", "category not found")
+	// ErrParentNotFound 指定的父分类不存在
+	ErrParentNotFound = domain.Keyed(domain.CodeValidation, "category.parent_not_found", "parent category not found")
+)
+
+// slugPattern slug 只允许小写字母、数字和连字符
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// CategoryServiceConfig 商品分类服务配置
+type CategoryServiceConfig struct {
+	CategoryRepository repository.CategoryRepository
+}
+
+// CategoryServiceOption 函数式选项模式
+type CategoryServiceOption func(*CategoryServiceConfig)
+
+type categoryService struct {
+	config *CategoryServiceConfig
+}
+
+// WithCategoryRepository 注入商品分类仓储依赖
+func WithCategoryRepository(repo repository.CategoryRepository) CategoryServiceOption {
+	return func(config *CategoryServiceConfig) {
+		config.CategoryRepository = repo
+	}
+}
+
+// NewCategoryService 创建商品分类服务实例
+// 使用函数式选项模式注入依赖
+func NewCategoryService(opts ...CategoryServiceOption) (CategorySrv, error) {
+	config := &CategoryServiceConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	if config.CategoryRepository == nil {
+		return nil, errors.New("category repository is required")
+	}
+	return &categoryService{config: config}, nil
+}
+
+func (s *categoryService) CreateCategory(ctx context.Context, category *model.Category) error {
+	if err := s.normalize(ctx, category); err != nil {
+		return err
+	}
+	if category.ParentID != "" {
+		if _, err := s.config.CategoryRepository.GetCategory(ctx, category.ParentID); err != nil {
+			return notFound(err, ErrParentNotFound)
+		}
+	}
+	return s.config.CategoryRepository.CreateCategory(ctx, category)
+}
+
+func (s *categoryService) GetCategory(ctx context.Context, id string) (*model.Category, error) {
+	category, err := s.config.CategoryRepository.GetCategory(ctx, id)
+	if err != nil {
+		return nil, notFound(err, ErrCategoryNotFound)
+	}
+	return category, nil
+}
+
+func (s *categoryService) UpdateCategory(ctx context.Context, category *model.Category) error {
+	if err := s.normalize(ctx, category); err != nil {
+		return err
+	}
+	if category.ParentID != "" {
+		if _, err := s.config.CategoryRepository.GetCategory(ctx, category.ParentID); err != nil {
+			return notFound(err, ErrParentNotFound)
+		}
+		// 不能把分类移动到自身或其后代之下，否则会形成环
+		subtree, err := s.config.CategoryRepository.ListDescendantIDs(ctx, category.ID)
+		if err != nil {
+			return err
+		}
+		for _, id := range subtree {
+			if id == category.ParentID {
+				return domain.Keyed(domain.CodeValidation, "category.invalid_parent", "category cannot be moved under itself or its descendants")
+			}
+		}
+	}
+	return s.config.CategoryRepository.UpdateCategory(ctx, category)
+}
+
+func (s *categoryService) DeleteCategory(ctx context.Context, id string, cascade bool) error {
+	if _, err := s.config.CategoryRepository.GetCategory(ctx, id); err != nil {
+		return notFound(err, ErrCategoryNotFound)
+	}
+
+	if !cascade {
+		children, err := s.config.CategoryRepository.CountChildren(ctx, id)
+		if err != nil {
+			return err
+		}
+		products, err := s.config.CategoryRepository.CountProducts(ctx, []string{id})
+		if err != nil {
+			return err
+		}
+		if children > 0 || products > 0 {
+			return ErrCategoryNotEmpty
+		}
+		return s.config.CategoryRepository.DeleteCategories(ctx, []string{id})
+	}
+
+	// 级联删除整棵子树，商品本身保留，仅解除关联
+	subtree, err := s.config.CategoryRepository.ListDescendantIDs(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.config.CategoryRepository.DeleteCategories(ctx, subtree)
+}
+
+// GetCategoryTree 返回完整的分类树，同级节点按 SortOrder 排序
+func (s *categoryService) GetCategoryTree(ctx context.Context) ([]*model.CategoryNode, error) {
+	categories, err := s.config.CategoryRepository.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[string]*model.CategoryNode, len(categories))
+	for _, c := range categories {
+		nodes[c.ID] = &model.CategoryNode{
+			ID:        c.ID,
+			ParentID:  c.ParentID,
+			Name:      c.Name,
+			Slug:      c.Slug,
+			SortOrder: c.SortOrder,
+			Children:  []*model.CategoryNode{},
+		}
+	}
+
+	// 分类已按排序字段查询，按原顺序挂载即可保持同级有序
+	roots := []*model.CategoryNode{}
+	for _, c := range categories {
+		node := nodes[c.ID]
+		if parent, ok := nodes[c.ParentID]; ok {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+	return roots, nil
+}
+
+// normalize 规范化 slug：未指定时由名称生成，并校验格式与唯一性
+func (s *categoryService) normalize(ctx context.Context, category *model.Category) error {
+	if category.Slug == "" {
+		category.Slug = Slugify(category.Name)
+	}
+	category.Slug = strings.ToLower(strings.TrimSpace(category.Slug))
+	if !slugPattern.MatchString(category.Slug) {
+		return domain.Keyed(domain.CodeValidation, "category.invalid_slug", "slug must contain only lowercase letters, digits and hyphens")
+	}
+	if existing, err := s.config.CategoryRepository.GetCategoryBySlug(ctx, category.Slug); err == nil && existing.ID != category.ID {
+		return domain.Keyed(domain.CodeConflict, "category.slug_exists", "category slug already exists")
+	}
+	return nil
+}
+
+// notFound 将记录不存在转换为 target，其余错误原样返回
+func notFound(err, target error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return target
+	}
+	return err
+}
+
+// Slugify 将名称转换为 slug，非字母数字字符替换为连字符
+// 名称不含 ASCII 字母数字（如中文名称）时返回空字符串，需显式指定 slug
+func Slugify(name string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			hyphen = false
+		} else if b.Len() > 0 && !hyphen {
+			b.WriteByte('-')
+			hyphen = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
@@ -0,0 +1,147 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/innovationmech/simple-cli/internal/domain"
+	"github.com/innovationmech/simple-cli/internal/interfaces"
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/queryspec"
+	"github.com/innovationmech/simple-cli/internal/repository"
+	"gorm.io/gorm"
+)
+
+// InventorySrv 是 InventoryService 接口的别名，方便外部引用
+type InventorySrv = interfaces.InventoryService
+
+var (
+	// ErrProductNotFound 商品不存在
+	ErrProductNotFound = domain.Keyed(domain.CodeNotFound, "product.not_found", "product not found")
+	// ErrInvalidQuantity 调整数量与调整类型不符
+	ErrInvalidQuantity = domain.Keyed(domain.CodeValidation, "inventory.invalid_quantity", "quantity must be positive, only corrections may be negative")
+	// ErrInsufficientStock 扣减后库存将为负数
+	ErrInsufficientStock = domain.Keyed(domain.CodeInsufficientStock, "stock.insufficient", "insufficient stock")
+	// ErrInvalidSKU 未按商品规格指定 SKU
+	ErrInvalidSKU = domain.Keyed(domain.CodeValidation, "inventory.invalid_sku", "invalid sku")
+)
+
+// InventoryServiceConfig 库存服务配置
+type InventoryServiceConfig struct {
+	ProductRepository   repository.ProductRepository
+	InventoryRepository repository.InventoryRepository
+}
+
+// InventoryServiceOption 函数式选项模式
+type InventoryServiceOption func(*InventoryServiceConfig)
+
+type inventoryService struct {
+	config *InventoryServiceConfig
+}
+
+// WithProductRepository 注入商品仓储依赖，用于调整库存
+func WithProductRepository(repo repository.ProductRepository) InventoryServiceOption {
+	return func(config *InventoryServiceConfig) {
+		config.ProductRepository = repo
+	}
+}
+
+// WithInventoryRepository 注入库存仓储依赖
+func WithInventoryRepository(repo repository.InventoryRepository) InventoryServiceOption {
+	return func(config *InventoryServiceConfig) {
+		config.InventoryRepository = repo
+	}
+}
+
+// NewInventoryService 创建库存服务实例
+// 使用函数式选项模式注入依赖
+func NewInventoryService(opts ...InventoryServiceOption) (InventorySrv, error) {
+	config := &InventoryServiceConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	if config.ProductRepository == nil {
+		return nil, errors.New("product repository is required")
+	}
+	if config.InventoryRepository == nil {
+		return nil, errors.New("inventory repository is required")
+	}
+	return &inventoryService{config: config}, nil
+}
+
+func (s *inventoryService) GetInventory(ctx context.Context, productID, skuID string, spec *queryspec.Spec, page, pageSize int) (*model.InventoryResponse, error) {
+	product, err := s.config.ProductRepository.GetProduct(ctx, productID)
+	if err != nil {
+		return nil, productNotFound(err)
+	}
+	var sku *model.SKU
+	if skuID != "" {
+		if sku, err = product.SelectSKU(skuID); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSKU, err)
+		}
+	}
+
+	spec.SetPage(page, pageSize)
+	adjustments, info, err := s.config.InventoryRepository.ListAdjustments(ctx, productID, skuID, spec)
+	if err != nil {
+		return nil, err
+	}
+	return &model.InventoryResponse{
+		ProductID:         productID,
+		Stock:             product.AvailableStock(sku),
+		LowStockThreshold: product.LowStockThreshold,
+		Adjustments:       adjustments,
+		PageInfo:          *info,
+	}, nil
+}
+
+// Adjust 库存调整
+// 入库与释放增加库存，损耗与占用减少库存，盘点修正按数量的正负号增减
+func (s *inventoryService) Adjust(ctx context.Context, adj *model.InventoryAdjustment) error {
+	switch {
+	case adj.Delta == 0:
+		return ErrInvalidQuantity
+	case adj.Type == model.AdjustmentCorrection:
+	case adj.Delta < 0:
+		return ErrInvalidQuantity
+	case !adj.Type.Inbound():
+		adj.Delta = -adj.Delta
+	}
+
+	product, err := s.config.ProductRepository.GetProduct(ctx, adj.ProductID)
+	if err != nil {
+		return productNotFound(err)
+	}
+	if _, err := product.SelectSKU(adj.SKUID); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSKU, err)
+	}
+
+	if err := s.config.ProductRepository.AdjustStock(ctx, adj); err != nil {
+		if errors.Is(err, repository.ErrInsufficientStock) {
+			return ErrInsufficientStock
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *inventoryService) SetThreshold(ctx context.Context, productID string, threshold *int) error {
+	if _, err := s.config.ProductRepository.GetProduct(ctx, productID); err != nil {
+		return productNotFound(err)
+	}
+	return s.config.InventoryRepository.SetThreshold(ctx, productID, threshold)
+}
+
+func (s *inventoryService) ListAlerts(ctx context.Context, status model.StockAlertStatus, spec *queryspec.Spec, page, pageSize int) ([]*model.StockAlert, *queryspec.PageInfo, error) {
+	spec.SetPage(page, pageSize)
+	return s.config.InventoryRepository.ListAlerts(ctx, status, spec)
+}
+
+// productNotFound 将商品记录不存在转换为 ErrProductNotFound，其余错误原样返回
+func productNotFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrProductNotFound
+	}
+	return err
+}
@@ -0,0 +1,256 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/innovationmech/simple-cli/internal/domain"
+	"github.com/innovationmech/simple-cli/internal/interfaces"
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/repository"
+	"github.com/innovationmech/simple-cli/internal/storage"
+	"gorm.io/gorm"
+)
+
+// MediaSrv 是 MediaService 接口的别名，方便外部引用
+type MediaSrv = interfaces.MediaService
+
+var (
+	// ErrImageTooLarge 图片超过大小或像素限制
+	ErrImageTooLarge = domain.Keyed(domain.CodePayloadTooLarge, "image.too_large", "image too large")
+	// ErrProductNotFound 图片所属商品不存在
+	ErrProductNotFound = domain.Keyed(domain.CodeNotFound, "product.not_found", "product not found")
+	// ErrUnsupportedImage 图片格式不受支持
+	ErrUnsupportedImage = domain.Keyed(domain.CodeUnsupportedMediaType, "image.unsupported_type", "unsupported image type, only jpeg, png and gif are allowed")
+	// ErrImageNotFound 图片不存在或不属于该商品
+	ErrImageNotFound = domain.Keyed(domain.CodeNotFound, "image.not_found", "image not found")
+	// ErrFileNotFound 存储中不存在该文件
+	ErrFileNotFound = domain.Keyed(domain.CodeNotFound, "file.not_found", "file not found")
+	// ErrInvalidSortOrder 图片排序为负数
+	ErrInvalidSortOrder = domain.Keyed(domain.CodeValidation, "image.invalid_sort_order", "sort order must not be negative")
+)
+
+// allowedTypes 允许上传的图片类型及其文件扩展名
+var allowedTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+// MediaServiceConfig 媒体文件服务配置
+type MediaServiceConfig struct {
+	ImageRepository   repository.ImageRepository
+	ProductRepository repository.ProductRepository
+	BlobStore         storage.BlobStore
+	// PublicURL 文件访问地址前缀
+	PublicURL string
+	// MaxBytes 单张图片的最大字节数
+	MaxBytes int64
+	// ThumbnailWidth 缩略图宽度，高度按比例缩放
+	ThumbnailWidth int
+}
+
+// MediaServiceOption 函数式选项模式
+type MediaServiceOption func(*MediaServiceConfig)
+
+type mediaService struct {
+	config *MediaServiceConfig
+}
+
+// WithImageRepository 注入商品图片仓储依赖
+func WithImageRepository(repo repository.ImageRepository) MediaServiceOption {
+	return func(config *MediaServiceConfig) {
+		config.ImageRepository = repo
+	}
+}
+
+// WithProductRepository 注入商品仓储依赖，用于校验商品是否存在
+func WithProductRepository(repo repository.ProductRepository) MediaServiceOption {
+	return func(config *MediaServiceConfig) {
+		config.ProductRepository = repo
+	}
+}
+
+// WithBlobStore 注入对象存储
+func WithBlobStore(store storage.BlobStore) MediaServiceOption {
+	return func(config *MediaServiceConfig) {
+		config.BlobStore = store
+	}
+}
+
+// WithPublicURL 设置文件访问地址前缀
+func WithPublicURL(publicURL string) MediaServiceOption {
+	return func(config *MediaServiceConfig) {
+		config.PublicURL = publicURL
+	}
+}
+
+// WithMaxBytes 设置单张图片的最大字节数
+func WithMaxBytes(maxBytes int64) MediaServiceOption {
+	return func(config *MediaServiceConfig) {
+		config.MaxBytes = maxBytes
+	}
+}
+
+// WithThumbnailWidth 设置缩略图宽度
+func WithThumbnailWidth(width int) MediaServiceOption {
+	return func(config *MediaServiceConfig) {
+		config.ThumbnailWidth = width
+	}
+}
+
+// NewMediaService 创建媒体文件服务实例
+// 使用函数式选项模式注入依赖
+func NewMediaService(opts ...MediaServiceOption) (MediaSrv, error) {
+	config := &MediaServiceConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	if config.ImageRepository == nil {
+		return nil, errors.New("image repository is required")
+	}
+	if config.ProductRepository == nil {
+		return nil, errors.New("product repository is required")
+	}
+	if config.BlobStore == nil {
+		return nil, errors.New("blob store is required")
+	}
+	if config.MaxBytes <= 0 {
+		return nil, errors.New("max bytes must be positive")
+	}
+	if config.ThumbnailWidth <= 0 {
+		return nil, errors.New("thumbnail width must be positive")
+	}
+	return &mediaService{config: config}, nil
+}
+
+// UploadProductImage 上传商品图片
+// 内容类型以文件内容嗅探为准，不信任客户端声明；原图与缩略图写入存储后再保存记录
+func (s *mediaService) UploadProductImage(ctx context.Context, image *model.ProductImage, data []byte) error {
+	if int64(len(data)) > s.config.MaxBytes {
+		return ErrImageTooLarge
+	}
+	contentType := http.DetectContentType(data)
+	ext, ok := allowedTypes[contentType]
+	if !ok {
+		return ErrUnsupportedImage
+	}
+	if _, err := s.config.ProductRepository.GetProduct(ctx, image.ProductID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrProductNotFound
+		}
+		return err
+	}
+
+	thumb, err := makeThumbnail(data, contentType, s.config.ThumbnailWidth)
+	if err != nil {
+		return err
+	}
+
+	if image.SortOrder < 0 {
+		existing, err := s.config.ImageRepository.ListImagesByProduct(ctx, image.ProductID)
+		if err != nil {
+			return err
+		}
+		image.SortOrder = 0
+		for _, img := range existing {
+			if img.SortOrder >= image.SortOrder {
+				image.SortOrder = img.SortOrder + 1
+			}
+		}
+	}
+
+	prefix := "products/" + image.ProductID + "/" + image.ID
+	image.Key = prefix + ext
+	image.ThumbnailKey = prefix + "_thumb" + thumb.ext
+	image.ContentType = contentType
+	image.Size = int64(len(data))
+	image.Width = thumb.srcWidth
+	image.Height = thumb.srcHeight
+
+	if err := s.config.BlobStore.Put(ctx, image.Key, data, contentType); err != nil {
+		return err
+	}
+	if err := s.config.BlobStore.Put(ctx, image.ThumbnailKey, thumb.data, thumb.contentType); err != nil {
+		_ = s.config.BlobStore.Delete(ctx, image.Key)
+		return err
+	}
+	if err := s.config.ImageRepository.CreateImage(ctx, image); err != nil {
+		s.deleteFiles(ctx, image)
+		return err
+	}
+	return nil
+}
+
+func (s *mediaService) GetProductImage(ctx context.Context, productID, imageID string) (*model.ProductImage, error) {
+	image, err := s.config.ImageRepository.GetImage(ctx, imageID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrImageNotFound
+		}
+		return nil, err
+	}
+	if image.ProductID != productID {
+		return nil, ErrImageNotFound
+	}
+	return image, nil
+}
+
+func (s *mediaService) ListProductImages(ctx context.Context, productID string) ([]*model.ProductImage, error) {
+	return s.config.ImageRepository.ListImagesByProduct(ctx, productID)
+}
+
+func (s *mediaService) UpdateProductImage(ctx context.Context, image *model.ProductImage) error {
+	if image.SortOrder < 0 {
+		return ErrInvalidSortOrder
+	}
+	return s.config.ImageRepository.UpdateImage(ctx, image)
+}
+
+func (s *mediaService) DeleteProductImage(ctx context.Context, productID, imageID string) error {
+	image, err := s.GetProductImage(ctx, productID, imageID)
+	if err != nil {
+		return err
+	}
+	if err := s.config.ImageRepository.DeleteImage(ctx, image.ID); err != nil {
+		return err
+	}
+	s.deleteFiles(ctx, image)
+	return nil
+}
+
+func (s *mediaService) DeleteProductImages(ctx context.Context, productID string) error {
+	images, err := s.config.ImageRepository.ListImagesByProduct(ctx, productID)
+	if err != nil {
+		return err
+	}
+	for _, image := range images {
+		if err := s.config.ImageRepository.DeleteImage(ctx, image.ID); err != nil {
+			return err
+		}
+		s.deleteFiles(ctx, image)
+	}
+	return nil
+}
+
+func (s *mediaService) OpenFile(ctx context.Context, key string) (io.ReadCloser, string, error) {
+	body, contentType, err := s.config.BlobStore.Get(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, "", ErrFileNotFound
+	}
+	return body, contentType, err
+}
+
+func (s *mediaService) ImageURL(key string) string {
+	return strings.TrimSuffix(s.config.PublicURL, "/") + "/" + key
+}
+
+// deleteFiles 删除图片在存储中的原图与缩略图
+// 记录已删除时文件删除失败只会留下孤立文件，不影响业务，因此忽略错误
+func (s *mediaService) deleteFiles(ctx context.Context, image *model.ProductImage) {
+	_ = s.config.BlobStore.Delete(ctx, image.Key)
+	_ = s.config.BlobStore.Delete(ctx, image.ThumbnailKey)
+}
@@ -0,0 +1,236 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/repository"
+	"github.com/innovationmech/simple-cli/internal/storage"
+	"gorm.io/gorm"
+)
+
+// fakeProductRepo 只实现 GetProduct 的商品仓储
+type fakeProductRepo struct {
+	repository.ProductRepository
+	products map[string]*model.Product
+}
+
+func (r *fakeProductRepo) GetProduct(ctx context.Context, id string) (*model.Product, error) {
+	p, ok := r.products[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return p, nil
+}
+
+// fakeImageRepo 保存在内存中的图片仓储
+type fakeImageRepo struct {
+	repository.ImageRepository
+	images []*model.ProductImage
+}
+
+func (r *fakeImageRepo) CreateImage(ctx context.Context, image *model.ProductImage) error {
+	r.images = append(r.images, image)
+	return nil
+}
+
+func (r *fakeImageRepo) ListImagesByProduct(ctx context.Context, productID string) ([]*model.ProductImage, error) {
+	var images []*model.ProductImage
+	for _, img := range r.images {
+		if img.ProductID == productID {
+			images = append(images, img)
+		}
+	}
+	return images, nil
+}
+
+func newTestService(t *testing.T, maxBytes int64) (*mediaService, *fakeImageRepo, storage.BlobStore) {
+	t.Helper()
+	images := &fakeImageRepo{}
+	store := storage.NewLocalStore(t.TempDir())
+	srv, err := NewMediaService(
+		WithImageRepository(images),
+		WithProductRepository(&fakeProductRepo{products: map[string]*model.Product{"p1": {ID: "p1"}}}),
+		WithBlobStore(store),
+		WithPublicURL("/api/v1/media/"),
+		WithMaxBytes(maxBytes),
+		WithThumbnailWidth(320),
+	)
+	if err != nil {
+		t.Fatalf("NewMediaService: %v", err)
+	}
+	return srv.(*mediaService), images, store
+}
+
+func testImage(width, height int) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	return img
+}
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, testImage(width, height)); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func encodeJPEG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, testImage(width, height), nil); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func encodeGIF(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := gif.Encode(&buf, testImage(width, height), nil); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// hugePNG 返回仅声明了巨大尺寸的 PNG，用于验证解码前的像素限制
+func hugePNG(t *testing.T, width, height uint32) []byte {
+	t.Helper()
+	data := encodePNG(t, 1, 1)
+	// IHDR 数据位于 8 字节签名、4 字节长度与 4 字节类型之后，CRC 覆盖类型与数据
+	binary.BigEndian.PutUint32(data[16:20], width)
+	binary.BigEndian.PutUint32(data[20:24], height)
+	binary.BigEndian.PutUint32(data[29:33], crc32.ChecksumIEEE(data[12:29]))
+	return data
+}
+
+func readObject(t *testing.T, store storage.BlobStore, key string) ([]byte, string) {
+	t.Helper()
+	body, contentType, err := store.Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("Get(%s): %v", key, err)
+	}
+	defer body.Close()
+	data, _ := io.ReadAll(body)
+	return data, contentType
+}
+
+func TestUploadProductImage(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          func(t *testing.T) []byte
+		contentType   string
+		ext           string
+		thumbType     string
+		thumbExt      string
+		width, height int
+	}{
+		{"png", func(t *testing.T) []byte { return encodePNG(t, 640, 480) }, "image/png", ".png", "image/png", ".png", 640, 480},
+		{"jpeg", func(t *testing.T) []byte { return encodeJPEG(t, 800, 400) }, "image/jpeg", ".jpg", "image/jpeg", ".jpg", 800, 400},
+		{"gif", func(t *testing.T) []byte { return encodeGIF(t, 400, 200) }, "image/gif", ".gif", "image/png", ".png", 400, 200},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, images, store := newTestService(t, 5<<20)
+			img := &model.ProductImage{ID: "img1", ProductID: "p1", SortOrder: -1}
+			if err := srv.UploadProductImage(context.Background(), img, tt.data(t)); err != nil {
+				t.Fatalf("UploadProductImage: %v", err)
+			}
+
+			if img.ContentType != tt.contentType || !strings.HasSuffix(img.Key, tt.ext) {
+				t.Errorf("content type %s key %s, want %s *%s", img.ContentType, img.Key, tt.contentType, tt.ext)
+			}
+			if img.Width != tt.width || img.Height != tt.height {
+				t.Errorf("size %dx%d, want %dx%d", img.Width, img.Height, tt.width, tt.height)
+			}
+			if img.SortOrder != 0 || len(images.images) != 1 {
+				t.Errorf("sort order %d, %d records saved", img.SortOrder, len(images.images))
+			}
+
+			thumb, thumbType := readObject(t, store, img.ThumbnailKey)
+			if !strings.HasSuffix(img.ThumbnailKey, "_thumb"+tt.thumbExt) {
+				t.Errorf("thumbnail key %s, want suffix _thumb%s", img.ThumbnailKey, tt.thumbExt)
+			}
+			cfg, format, err := image.DecodeConfig(bytes.NewReader(thumb))
+			if err != nil {
+				t.Fatalf("decode thumbnail: %v", err)
+			}
+			if "image/"+format != tt.thumbType || thumbType != tt.thumbType {
+				t.Errorf("thumbnail format %s stored as %s, want %s", format, thumbType, tt.thumbType)
+			}
+			if wantHeight := tt.height * 320 / tt.width; cfg.Width != 320 || cfg.Height != wantHeight {
+				t.Errorf("thumbnail %dx%d, want 320x%d", cfg.Width, cfg.Height, wantHeight)
+			}
+		})
+	}
+}
+
+func TestUploadProductImageKeepsSmallImages(t *testing.T) {
+	srv, _, store := newTestService(t, 5<<20)
+	img := &model.ProductImage{ID: "img1", ProductID: "p1", SortOrder: -1}
+	if err := srv.UploadProductImage(context.Background(), img, encodePNG(t, 100, 50)); err != nil {
+		t.Fatalf("UploadProductImage: %v", err)
+	}
+	thumb, _ := readObject(t, store, img.ThumbnailKey)
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(thumb))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Width != 100 || cfg.Height != 50 {
+		t.Errorf("thumbnail %dx%d, want 100x50 (no upscaling)", cfg.Width, cfg.Height)
+	}
+}
+
+func TestUploadProductImageRejected(t *testing.T) {
+	tests := []struct {
+		name      string
+		productID string
+		maxBytes  int64
+		data      func(t *testing.T) []byte
+		want      error
+	}{
+		{"plain text", "p1", 5 << 20, func(t *testing.T) []byte { return []byte("hello, not an image") }, ErrUnsupportedImage},
+		{"html", "p1", 5 << 20, func(t *testing.T) []byte { return []byte("<html><img src=x></html>") }, ErrUnsupportedImage},
+		{"bmp", "p1", 5 << 20, func(t *testing.T) []byte { return append([]byte("BM"), make([]byte, 64)...) }, ErrUnsupportedImage},
+		{"truncated png", "p1", 5 << 20, func(t *testing.T) []byte { return encodePNG(t, 10, 10)[:20] }, ErrUnsupportedImage},
+		{"over size limit", "p1", 64, func(t *testing.T) []byte { return encodeJPEG(t, 10, 10) }, ErrImageTooLarge},
+		{"over pixel limit", "p1", 5 << 20, func(t *testing.T) []byte { return hugePNG(t, 10000, 10000) }, ErrImageTooLarge},
+		{"unknown product", "missing", 5 << 20, func(t *testing.T) []byte { return encodePNG(t, 10, 10) }, ErrProductNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, images, store := newTestService(t, tt.maxBytes)
+			img := &model.ProductImage{ID: "img1", ProductID: tt.productID, SortOrder: -1}
+			err := srv.UploadProductImage(context.Background(), img, tt.data(t))
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			if len(images.images) != 0 {
+				t.Errorf("%d records saved for rejected upload", len(images.images))
+			}
+			if img.Key != "" {
+				if _, _, err := store.Get(context.Background(), img.Key); !errors.Is(err, storage.ErrNotFound) {
+					t.Errorf("object %s stored for rejected upload", img.Key)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package media
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif" // 注册 GIF 解码器
+	"image/jpeg"
+	"image/png"
+
+	"github.com/innovationmech/simple-cli/internal/domain"
+)
+
+// maxImagePixels 允许解码的最大像素数，防止体积很小但尺寸巨大的图片耗尽内存
+const maxImagePixels = 40_000_000
+
+// thumbnail 缩略图生成结果
+type thumbnail struct {
+	data        []byte
+	contentType string
+	ext         string
+	// srcWidth 与 srcHeight 为原图尺寸
+	srcWidth  int
+	srcHeight int
+}
+
+// makeThumbnail 解码图片并按指定宽度等比缩放
+// 原图不超过目标宽度时不放大；JPEG 缩略图仍输出 JPEG，其余格式输出 PNG 以保留透明通道
+func makeThumbnail(data []byte, contentType string, width int) (*thumbnail, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, domain.Keyed(domain.CodeValidation, "image.invalid_dimensions", "invalid image dimensions")
+	}
+	if cfg.Width*cfg.Height > maxImagePixels {
+		return nil, ErrImageTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+
+	dst := src
+	if cfg.Width > width {
+		height := cfg.Height * width / cfg.Width
+		if height < 1 {
+			height = 1
+		}
+		dst = resize(src, width, height)
+	}
+
+	thumb := &thumbnail{srcWidth: cfg.Width, srcHeight: cfg.Height}
+	var buf bytes.Buffer
+	if contentType == "image/jpeg" {
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 85})
+		thumb.contentType, thumb.ext = "image/jpeg", ".jpg"
+	} else {
+		err = png.Encode(&buf, dst)
+		thumb.contentType, thumb.ext = "image/png", ".png"
+	}
+	if err != nil {
+		return nil, err
+	}
+	thumb.data = buf.Bytes()
+	return thumb, nil
+}
+
+// resize 使用区域平均法缩小图片
+// 目标像素取其在原图中覆盖区域内所有像素的平均值，缩小时比最近邻采样更平滑
+func resize(src image.Image, width, height int) *image.NRGBA {
+	bounds := src.Bounds()
+	rgba := image.NewNRGBA(bounds)
+	draw.Draw(rgba, bounds, src, bounds.Min, draw.Src)
+
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := y * srcH / height
+		y1 := max((y+1)*srcH/height, y0+1)
+		for x := 0; x < width; x++ {
+			x0 := x * srcW / width
+			x1 := max((x+1)*srcW/width, x0+1)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				row := rgba.Pix[sy*rgba.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					// 按透明度加权，避免透明像素的颜色渗入边缘
+					pa := uint64(p[3])
+					r += uint64(p[0]) * pa
+					g += uint64(p[1]) * pa
+					b += uint64(p[2]) * pa
+					a += pa
+					n++
+				}
+			}
+			c := color.NRGBA{A: uint8(a / n)}
+			if a > 0 {
+				c.R, c.G, c.B = uint8(r/a), uint8(g/a), uint8(b/a)
+			}
+			dst.SetNRGBA(x, y, c)
+		}
+	}
+	return dst
+}
@@ -4,17 +4,39 @@ import (
 	"context"
 	"errors"
 
+	"github.com/innovationmech/simple-cli/internal/domain"
 	"github.com/innovationmech/simple-cli/internal/interfaces"
+	"github.com/innovationmech/simple-cli/internal/metrics"
 	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/pricing"
+	"github.com/innovationmech/simple-cli/internal/queryspec"
 	"github.com/innovationmech/simple-cli/internal/repository"
+	"gorm.io/gorm"
 )
 
 // OrderSrv 是 OrderService 接口的别名
 type OrderSrv = interfaces.OrderService
 
+var (
+	// ErrOrderNotFound 订单不存在
+	ErrOrderNotFound = domain.Keyed(domain.CodeNotFound, "order.not_found", "order not found")
+	// ErrVersionConflict 订单在读取后已被修改
+	ErrVersionConflict = domain.Keyed(domain.CodePreconditionFailed, "order.version_conflict", "order has been modified")
+	// ErrInvalidTransition 订单当前状态不能流转到目标状态
+	ErrInvalidTransition = domain.Keyed(domain.CodeInvalidTransition, "order.invalid_transition", "invalid status transition")
+	// ErrNotCancellable 只有待支付的订单可以取消
+	ErrNotCancellable = domain.Keyed(domain.CodeInvalidTransition, "order.not_cancellable", "only pending orders can be cancelled")
+	// ErrAddressNotFound 收货地址不存在或不属于下单用户
+	ErrAddressNotFound = domain.Keyed(domain.CodeNotFound, "address.not_found", "address not found")
+)
+
 type orderService struct {
-	orderRepo   repository.OrderRepository
-	productRepo repository.ProductRepository
+	orderRepo    repository.OrderRepository
+	productRepo  repository.ProductRepository
+	addressRepo  repository.AddressRepository
+	promotionSrv interfaces.PromotionService
+	pricing      *pricing.Pipeline
+	txManager    repository.TxManager
 }
 
 // NewOrderService 创建订单服务实例
@@ -22,75 +44,220 @@ type orderService struct {
 func NewOrderService(
 	orderRepo repository.OrderRepository,
 	productRepo repository.ProductRepository,
+	addressRepo repository.AddressRepository,
+	promotionSrv interfaces.PromotionService,
+	pricingPipeline *pricing.Pipeline,
+	txManager repository.TxManager,
 ) OrderSrv {
 	return &orderService{
-		orderRepo:   orderRepo,
-		productRepo: productRepo,
+		orderRepo:    orderRepo,
+		productRepo:  productRepo,
+		addressRepo:  addressRepo,
+		promotionSrv: promotionSrv,
+		pricing:      pricingPipeline,
+		txManager:    txManager,
 	}
 }
 
 func (s *orderService) CreateOrder(ctx context.Context, order *model.Order) error {
-	// 获取商品信息计算总价
-	product, err := s.productRepo.GetProduct(ctx, order.ProductID)
-	if err != nil {
-		return errors.New("product not found")
-	}
-
-	// 检查库存
-	if product.Stock < order.Quantity {
-		return errors.New("insufficient stock")
+	if err := s.price(ctx, order); err != nil {
+		return err
 	}
 
-	// 计算总金额
-	order.TotalAmount = product.Price * float64(order.Quantity)
 	order.Status = model.OrderStatusPending
 
-	return s.orderRepo.CreateOrder(ctx, order)
+	// 扣减库存、核销优惠券、创建订单、记录历史在同一事务中完成
+	return s.txManager.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.promotionSrv.RedeemDiscounts(ctx, order.UserID, order.Discounts); err != nil {
+			return err
+		}
+		for _, item := range order.Items {
+			if err := s.productRepo.AdjustStock(ctx, &model.InventoryAdjustment{
+				ProductID: item.ProductID,
+				SKUID:     item.SKUID,
+				Type:      model.AdjustmentReservation,
+				Delta:     -item.Quantity,
+				Reason:    "order created",
+				Reference: order.ID,
+			}); err != nil {
+				return err
+			}
+		}
+		if err := s.orderRepo.CreateOrder(ctx, order); err != nil {
+			return err
+		}
+		// 购物车结算时本事务嵌套在结算事务中，订单只在最外层事务提交后计数
+		s.txManager.AfterCommit(ctx, metrics.OrderCreated)
+		return s.recordHistory(ctx, order.ID, model.OrderEventCreated, "", order.Status, "")
+	})
 }
 
-func (s *orderService) GetOrder(ctx context.Context, id string) (*model.Order, error) {
-	return s.orderRepo.GetOrder(ctx, id)
+// QuoteOrder 试算订单价格，不扣减库存、不核销优惠券也不保存订单
+func (s *orderService) QuoteOrder(ctx context.Context, order *model.Order) error {
+	return s.price(ctx, order)
 }
 
-func (s *orderService) UpdateOrderStatus(ctx context.Context, id string, status model.OrderStatus) error {
+func (s *orderService) GetOrder(ctx context.Context, id string) (*model.Order, error) {
 	order, err := s.orderRepo.GetOrder(ctx, id)
 	if err != nil {
-		return err
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	return order, nil
+}
+
+func (s *orderService) UpdateOrderStatus(ctx context.Context, id string, status model.OrderStatus, version int) (*model.Order, error) {
+	order, err := s.GetOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if order.Version != version {
+		return nil, ErrVersionConflict
 	}
 
 	// 状态流转验证
 	if !isValidStatusTransition(order.Status, status) {
-		return errors.New("invalid status transition")
+		return nil, ErrInvalidTransition
 	}
 
+	from := order.Status
 	order.Status = status
-	return s.orderRepo.UpdateOrder(ctx, order)
+	err = s.txManager.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.orderRepo.UpdateOrder(ctx, order); err != nil {
+			if errors.Is(err, repository.ErrVersionConflict) {
+				return ErrVersionConflict
+			}
+			return err
+		}
+		// 经状态接口取消与 CancelOrder 一致，同样释放库存并归还优惠券
+		if status == model.OrderStatusCancelled {
+			if err := s.release(ctx, order); err != nil {
+				return err
+			}
+			return s.recordHistory(ctx, order.ID, model.OrderEventCancelled, from, status, "")
+		}
+		return s.recordHistory(ctx, order.ID, model.OrderEventStatusChanged, from, status, "")
+	})
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
 }
 
 func (s *orderService) CancelOrder(ctx context.Context, id string) error {
-	order, err := s.orderRepo.GetOrder(ctx, id)
+	order, err := s.GetOrder(ctx, id)
 	if err != nil {
 		return err
 	}
 
 	// 只有待支付状态可以取消
 	if order.Status != model.OrderStatusPending {
-		return errors.New("only pending orders can be cancelled")
+		return ErrNotCancellable
 	}
 
+	from := order.Status
 	order.Status = model.OrderStatusCancelled
-	return s.orderRepo.UpdateOrder(ctx, order)
+
+	// 取消订单时释放已占用的库存并归还优惠券
+	return s.txManager.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.orderRepo.UpdateOrder(ctx, order); err != nil {
+			if errors.Is(err, repository.ErrVersionConflict) {
+				return ErrVersionConflict
+			}
+			return err
+		}
+		if err := s.release(ctx, order); err != nil {
+			return err
+		}
+		return s.recordHistory(ctx, order.ID, model.OrderEventCancelled, from, order.Status, "")
+	})
+}
+
+// release 释放取消订单占用的库存并归还优惠券，需在事务中调用
+func (s *orderService) release(ctx context.Context, order *model.Order) error {
+	if err := s.promotionSrv.ReleaseDiscounts(ctx, order.UserID, order.Discounts); err != nil {
+		return err
+	}
+	for _, item := range order.Items {
+		if err := s.productRepo.AdjustStock(ctx, &model.InventoryAdjustment{
+			ProductID: item.ProductID,
+			SKUID:     item.SKUID,
+			Type:      model.AdjustmentRelease,
+			Delta:     item.Quantity,
+			Reason:    "order cancelled",
+			Reference: order.ID,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *orderService) GetOrderHistory(ctx context.Context, id string) ([]*model.OrderHistory, error) {
+	if _, err := s.GetOrder(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.orderRepo.ListHistory(ctx, id)
+}
+
+func (s *orderService) ListOrdersByUser(ctx context.Context, userID string, spec *queryspec.Spec, page, pageSize int) ([]*model.Order, *queryspec.PageInfo, error) {
+	spec.SetPage(page, pageSize)
+	return s.orderRepo.ListOrdersByUser(ctx, userID, spec)
 }
 
-func (s *orderService) ListOrdersByUser(ctx context.Context, userID string, page, pageSize int) ([]*model.Order, int64, error) {
-	offset := (page - 1) * pageSize
-	if offset < 0 {
-		offset = 0
+// price 确定收货地址后执行计价流水线
+// 运费与税率依赖收货地区，因此需先写入地址快照
+func (s *orderService) price(ctx context.Context, order *model.Order) error {
+	if len(order.Items) == 0 {
+		return domain.Keyed(domain.CodeValidation, "order.no_items", "order items are required")
+	}
+	if err := s.attachShippingAddress(ctx, order); err != nil {
+		return err
 	}
-	if pageSize <= 0 {
-		pageSize = 10
+	return s.pricing.Price(ctx, order)
+}
+
+// recordHistory 追加一条订单历史记录
+func (s *orderService) recordHistory(ctx context.Context, orderID string, event model.OrderEvent, from, to model.OrderStatus, note string) error {
+	return s.orderRepo.AddHistory(ctx, &model.OrderHistory{
+		OrderID:    orderID,
+		Event:      event,
+		FromStatus: from,
+		ToStatus:   to,
+		Note:       note,
+	})
+}
+
+// attachShippingAddress 将收货地址快照写入订单
+// 指定了 AddressID 时必须属于下单用户；未指定时使用用户的默认地址（若存在）
+func (s *orderService) attachShippingAddress(ctx context.Context, order *model.Order) error {
+	var address *model.Address
+	if order.AddressID != "" {
+		a, err := s.addressRepo.GetAddress(ctx, order.AddressID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err != nil || a.UserID != order.UserID {
+			return ErrAddressNotFound
+		}
+		address = a
+	} else {
+		a, err := s.addressRepo.GetDefaultAddress(ctx, order.UserID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// 用户没有默认地址时允许不带收货地址下单
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		address = a
 	}
-	return s.orderRepo.ListOrdersByUser(ctx, userID, offset, pageSize)
+
+	order.AddressID = address.ID
+	order.ShippingAddress = address.Snapshot()
+	return nil
 }
 
 // isValidStatusTransition 验证状态流转是否合法
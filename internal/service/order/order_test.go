@@ -0,0 +1,147 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/pricing"
+	"github.com/innovationmech/simple-cli/internal/repository"
+	promotionSrv "github.com/innovationmech/simple-cli/internal/service/promotion"
+	"github.com/innovationmech/simple-cli/internal/testutil"
+	"gorm.io/gorm"
+)
+
+// newTestService 使用真实仓储与 SQLite 数据库创建订单服务
+// 计价只包含商品小计与优惠，不依赖运费与税率配置
+func newTestService(t *testing.T) (OrderSrv, *gorm.DB) {
+	t.Helper()
+	db := testutil.NewDB(t)
+	txManager := repository.NewTxManager(db)
+	productRepo := repository.NewProductRepository(db)
+	promotion := promotionSrv.NewPromotionService(
+		repository.NewCouponRepository(db), repository.NewCategoryRepository(db), txManager,
+	)
+	srv := NewOrderService(
+		repository.NewOrderRepository(db),
+		productRepo,
+		repository.NewAddressRepository(db),
+		promotion,
+		pricing.NewPipeline(
+			&pricing.SubtotalStep{ProductRepo: productRepo},
+			&pricing.DiscountStep{PromotionService: promotion},
+		),
+		txManager,
+	)
+
+	ctx := context.Background()
+	if err := productRepo.CreateProduct(ctx, &model.Product{ID: "p1", Name: "Widget", Price: 10, Stock: 10}); err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+	if err := db.Create(&model.Coupon{ID: "c1", Code: "SAVE5", Type: model.CouponTypeFixed, Value: 5, Active: true}).Error; err != nil {
+		t.Fatalf("create coupon: %v", err)
+	}
+	return srv, db
+}
+
+// placeOrder 使用优惠券下单，返回已扣减库存并核销优惠券的待支付订单
+func placeOrder(t *testing.T, srv OrderSrv) *model.Order {
+	t.Helper()
+	order := &model.Order{
+		ID:          "o1",
+		UserID:      "u1",
+		Items:       []model.OrderItem{{ProductID: "p1", Quantity: 3}},
+		CouponCodes: []string{"SAVE5"},
+	}
+	if err := srv.CreateOrder(context.Background(), order); err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	return order
+}
+
+// assertReserved 校验商品库存与优惠券使用次数
+func assertReserved(t *testing.T, db *gorm.DB, stock, used int) {
+	t.Helper()
+	var product model.Product
+	if err := db.First(&product, "id = ?", "p1").Error; err != nil {
+		t.Fatal(err)
+	}
+	var coupon model.Coupon
+	if err := db.First(&coupon, "id = ?", "c1").Error; err != nil {
+		t.Fatal(err)
+	}
+	var usage model.CouponUsage
+	if err := db.First(&usage, "coupon_id = ? AND user_id = ?", "c1", "u1").Error; err != nil {
+		t.Fatal(err)
+	}
+	if product.Stock != stock || coupon.UsedCount != used || usage.Count != used {
+		t.Errorf("stock %d, coupon used %d, user usage %d; want stock %d, used %d",
+			product.Stock, coupon.UsedCount, usage.Count, stock, used)
+	}
+}
+
+func TestCancelOrderReleasesReservation(t *testing.T) {
+	tests := []struct {
+		name   string
+		cancel func(ctx context.Context, srv OrderSrv, order *model.Order) error
+	}{
+		{"cancel endpoint", func(ctx context.Context, srv OrderSrv, order *model.Order) error {
+			return srv.CancelOrder(ctx, order.ID)
+		}},
+		{"status update", func(ctx context.Context, srv OrderSrv, order *model.Order) error {
+			_, err := srv.UpdateOrderStatus(ctx, order.ID, model.OrderStatusCancelled, order.Version)
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, db := newTestService(t)
+			ctx := context.Background()
+			order := placeOrder(t, srv)
+			assertReserved(t, db, 7, 1)
+
+			if err := tt.cancel(ctx, srv, order); err != nil {
+				t.Fatalf("cancel: %v", err)
+			}
+			assertReserved(t, db, 10, 0)
+
+			history, err := srv.GetOrderHistory(ctx, order.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if last := history[len(history)-1]; last.Event != model.OrderEventCancelled || last.ToStatus != model.OrderStatusCancelled {
+				t.Errorf("last history = %s -> %s, want cancelled event", last.Event, last.ToStatus)
+			}
+		})
+	}
+}
+
+func TestUpdateOrderStatusKeepsReservation(t *testing.T) {
+	srv, db := newTestService(t)
+	order := placeOrder(t, srv)
+
+	if _, err := srv.UpdateOrderStatus(context.Background(), order.ID, model.OrderStatusPaid, order.Version); err != nil {
+		t.Fatalf("UpdateOrderStatus: %v", err)
+	}
+	assertReserved(t, db, 7, 1)
+}
+
+func TestCancelledOrderCannotBeCancelledAgain(t *testing.T) {
+	srv, db := newTestService(t)
+	ctx := context.Background()
+	order := placeOrder(t, srv)
+
+	updated, err := srv.UpdateOrderStatus(ctx, order.ID, model.OrderStatusCancelled, order.Version)
+	if err != nil {
+		t.Fatalf("UpdateOrderStatus: %v", err)
+	}
+	if err := srv.CancelOrder(ctx, order.ID); !errors.Is(err, ErrNotCancellable) {
+		t.Fatalf("CancelOrder err = %v, want ErrNotCancellable", err)
+	}
+	if _, err := srv.UpdateOrderStatus(ctx, order.ID, model.OrderStatusCancelled, updated.Version); !errors.Is(err, ErrInvalidTransition) {
+		t.Fatalf("UpdateOrderStatus err = %v, want ErrInvalidTransition", err)
+	}
+	// 库存与优惠券只归还一次
+	assertReserved(t, db, 10, 0)
+}
@@ -4,19 +4,42 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"time"
 
+	"github.com/innovationmech/simple-cli/internal/domain"
 	"github.com/innovationmech/simple-cli/internal/interfaces"
+	"github.com/innovationmech/simple-cli/internal/metrics"
 	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/queryspec"
 	"github.com/innovationmech/simple-cli/internal/repository"
+	"gorm.io/gorm"
 )
 
 // PaymentSrv 是 PaymentService 接口的别名
 type PaymentSrv = interfaces.PaymentService
 
+var (
+	// ErrPaymentNotFound 支付记录不存在
+	ErrPaymentNotFound = domain.Keyed(domain.CodeNotFound, "payment.not_found", "payment not found")
+	// ErrOrderNotFound 支付对应的订单不存在
+	ErrOrderNotFound = domain.Keyed(domain.CodeNotFound, "order.not_found", "order not found")
+	// ErrAmountMismatch 支付金额与订单总额不一致
+	ErrAmountMismatch = domain.Keyed(domain.CodeUnprocessable, "payment.amount_mismatch", "payment amount does not match order total")
+	// ErrOrderNotPending 订单不是待支付状态
+	ErrOrderNotPending = domain.Keyed(domain.CodeInvalidTransition, "payment.order_not_pending", "order is not in pending status")
+	// ErrPaymentNotPending 支付不是待处理状态
+	ErrPaymentNotPending = domain.Keyed(domain.CodeInvalidTransition, "payment.not_pending", "payment is not in pending status")
+	// ErrNotRefundable 只有成功的支付可以退款
+	ErrNotRefundable = domain.Keyed(domain.CodeInvalidTransition, "payment.not_refundable", "only successful payments can be refunded")
+	// ErrRefundExceeded 退款金额超过剩余可退金额
+	ErrRefundExceeded = domain.Keyed(domain.CodeUnprocessable, "payment.refund_exceeded", "refund amount exceeds the refundable amount")
+)
+
 type paymentService struct {
 	paymentRepo repository.PaymentRepository
 	orderRepo   repository.OrderRepository
+	txManager   repository.TxManager
 }
 
 // NewPaymentService 创建支付服务实例
@@ -24,10 +47,12 @@ type paymentService struct {
 func NewPaymentService(
 	paymentRepo repository.PaymentRepository,
 	orderRepo repository.OrderRepository,
+	txManager repository.TxManager,
 ) PaymentSrv {
 	return &paymentService{
 		paymentRepo: paymentRepo,
 		orderRepo:   orderRepo,
+		txManager:   txManager,
 	}
 }
 
@@ -35,17 +60,20 @@ func (s *paymentService) CreatePayment(ctx context.Context, payment *model.Payme
 	// 验证订单存在
 	order, err := s.orderRepo.GetOrder(ctx, payment.OrderID)
 	if err != nil {
-		return "", errors.New("order not found")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", ErrOrderNotFound
+		}
+		return "", err
 	}
 
 	// 验证订单金额
 	if payment.Amount != order.TotalAmount {
-		return "", errors.New("payment amount does not match order total")
+		return "", ErrAmountMismatch
 	}
 
 	// 验证订单状态
 	if order.Status != model.OrderStatusPending {
-		return "", errors.New("order is not in pending status")
+		return "", ErrOrderNotPending
 	}
 
 	payment.Status = model.PaymentStatusPending
@@ -59,62 +87,112 @@ func (s *paymentService) CreatePayment(ctx context.Context, payment *model.Payme
 }
 
 func (s *paymentService) GetPayment(ctx context.Context, id string) (*model.Payment, error) {
-	return s.paymentRepo.GetPayment(ctx, id)
+	payment, err := s.paymentRepo.GetPayment(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPaymentNotFound
+		}
+		return nil, err
+	}
+	return payment, nil
 }
 
 func (s *paymentService) ProcessCallback(ctx context.Context, paymentID, transactionID string, success bool) error {
-	payment, err := s.paymentRepo.GetPayment(ctx, paymentID)
+	payment, err := s.GetPayment(ctx, paymentID)
 	if err != nil {
 		return err
 	}
 
 	if payment.Status != model.PaymentStatusPending {
-		return errors.New("payment is not in pending status")
+		return ErrPaymentNotPending
 	}
 
 	payment.TransactionID = transactionID
 	now := time.Now()
-
 	if success {
 		payment.Status = model.PaymentStatusSuccess
 		payment.PaidAt = &now
-
-		// 更新订单状态为已支付
-		order, err := s.orderRepo.GetOrder(ctx, payment.OrderID)
-		if err == nil {
-			order.Status = model.OrderStatusPaid
-			_ = s.orderRepo.UpdateOrder(ctx, order)
-		}
 	} else {
 		payment.Status = model.PaymentStatusFailed
 	}
 
-	return s.paymentRepo.UpdatePayment(ctx, payment)
+	// 支付成功时更新支付记录、订单状态与订单历史在同一事务中完成，
+	// 订单已被并发修改或已不是待支付状态（如已取消）时整体回滚，避免支付成功而订单状态不一致
+	err = s.txManager.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.paymentRepo.UpdatePayment(ctx, payment); err != nil {
+			return err
+		}
+		if !success {
+			return nil
+		}
+
+		order, err := s.orderRepo.GetOrder(ctx, payment.OrderID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrOrderNotFound
+			}
+			return err
+		}
+		if order.Status != model.OrderStatusPending {
+			return ErrOrderNotPending
+		}
+		from := order.Status
+		order.Status = model.OrderStatusPaid
+		if err := s.orderRepo.UpdateOrder(ctx, order); err != nil {
+			return err
+		}
+		return s.orderRepo.AddHistory(ctx, &model.OrderHistory{
+			OrderID:    order.ID,
+			Event:      model.OrderEventStatusChanged,
+			FromStatus: from,
+			ToStatus:   order.Status,
+			Note:       "payment " + payment.ID + " succeeded",
+		})
+	})
+	if err != nil {
+		return err
+	}
+	metrics.PaymentCompleted(string(payment.Method), success)
+	return nil
 }
 
-func (s *paymentService) RefundPayment(ctx context.Context, id string, reason string) error {
-	payment, err := s.paymentRepo.GetPayment(ctx, id)
+// RefundPayment 退款，支持多次部分退款；累计退款达到支付金额后支付转为已退款
+func (s *paymentService) RefundPayment(ctx context.Context, id string, amount float64, reason string) error {
+	payment, err := s.GetPayment(ctx, id)
 	if err != nil {
 		return err
 	}
 
 	if payment.Status != model.PaymentStatusSuccess {
-		return errors.New("only successful payments can be refunded")
+		return ErrNotRefundable
 	}
 
-	payment.Status = model.PaymentStatusRefunded
-	return s.paymentRepo.UpdatePayment(ctx, payment)
-}
+	refundable := payment.RefundableAmount()
+	if amount <= 0 {
+		amount = refundable
+	}
+	amount = roundAmount(amount)
+	if amount > refundable {
+		return ErrRefundExceeded
+	}
 
-func (s *paymentService) ListPayments(ctx context.Context, userID, orderID string, page, pageSize int) ([]*model.Payment, int64, error) {
-	offset := (page - 1) * pageSize
-	if offset < 0 {
-		offset = 0
+	payment.RefundedAmount = roundAmount(payment.RefundedAmount + amount)
+	if payment.RefundableAmount() <= 0 {
+		payment.Status = model.PaymentStatusRefunded
 	}
-	if pageSize <= 0 {
-		pageSize = 10
+	if err := s.paymentRepo.UpdatePayment(ctx, payment); err != nil {
+		return err
 	}
-	return s.paymentRepo.ListPayments(ctx, userID, orderID, offset, pageSize)
+	// 退货退款在退货事务中发起，只在事务提交后计入退款金额
+	s.txManager.AfterCommit(ctx, func() {
+		metrics.Refunded(string(payment.Method), amount)
+	})
+	return nil
+}
+
+func (s *paymentService) ListPayments(ctx context.Context, userID, orderID string, spec *queryspec.Spec, page, pageSize int) ([]*model.Payment, *queryspec.PageInfo, error) {
+	spec.SetPage(page, pageSize)
+	return s.paymentRepo.ListPayments(ctx, userID, orderID, spec)
 }
 
 // generatePaymentURL 模拟生成支付链接
@@ -131,3 +209,8 @@ func (s *paymentService) generatePaymentURL(payment *model.Payment) string {
 		return fmt.Sprintf("%s/pay?id=%s&amount=%.2f", baseURL, payment.ID, payment.Amount)
 	}
 }
+
+// roundAmount 金额保留两位小数
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,71 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/repository"
+	"github.com/innovationmech/simple-cli/internal/testutil"
+)
+
+func TestProcessCallbackRequiresPendingOrder(t *testing.T) {
+	tests := []struct {
+		name        string
+		orderStatus model.OrderStatus
+		success     bool
+		wantErr     error
+		wantPayment model.PaymentStatus
+		wantOrder   model.OrderStatus
+	}{
+		{name: "paid", orderStatus: model.OrderStatusPending, success: true,
+			wantPayment: model.PaymentStatusSuccess, wantOrder: model.OrderStatusPaid},
+		{name: "cancelled then paid", orderStatus: model.OrderStatusCancelled, success: true, wantErr: ErrOrderNotPending,
+			wantPayment: model.PaymentStatusPending, wantOrder: model.OrderStatusCancelled},
+		{name: "cancelled then failed", orderStatus: model.OrderStatusCancelled,
+			wantPayment: model.PaymentStatusFailed, wantOrder: model.OrderStatusCancelled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := testutil.NewDB(t)
+			paymentRepo := repository.NewPaymentRepository(db)
+			orderRepo := repository.NewOrderRepository(db)
+			srv := NewPaymentService(paymentRepo, orderRepo, repository.NewTxManager(db))
+			ctx := context.Background()
+
+			order := &model.Order{ID: "o1", UserID: "u1", TotalAmount: 10, Status: model.OrderStatusPending}
+			if err := db.Create(order).Error; err != nil {
+				t.Fatal(err)
+			}
+			payment := &model.Payment{ID: "pay1", OrderID: "o1", UserID: "u1", Amount: 10, Method: model.PaymentMethodAlipay}
+			if _, err := srv.CreatePayment(ctx, payment); err != nil {
+				t.Fatalf("CreatePayment: %v", err)
+			}
+			// 支付链接发出后订单状态发生变化，例如用户取消订单
+			if err := db.Model(order).Update("status", tt.orderStatus).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			err := srv.ProcessCallback(ctx, "pay1", "tx1", tt.success)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ProcessCallback err = %v, want %v", err, tt.wantErr)
+			}
+
+			stored, err := paymentRepo.GetPayment(ctx, "pay1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stored.Status != tt.wantPayment {
+				t.Errorf("payment status %s, want %s", stored.Status, tt.wantPayment)
+			}
+			storedOrder, err := orderRepo.GetOrder(ctx, "o1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if storedOrder.Status != tt.wantOrder {
+				t.Errorf("order status %s, want %s", storedOrder.Status, tt.wantOrder)
+			}
+		})
+	}
+}
@@ -3,18 +3,42 @@ package product
 import (
 	"context"
 	"errors"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/innovationmech/simple-cli/internal/domain"
 	"github.com/innovationmech/simple-cli/internal/interfaces"
 	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/queryspec"
 	"github.com/innovationmech/simple-cli/internal/repository"
+	"gorm.io/gorm"
 )
 
 // ProductSrv 是 ProductService 接口的别名，方便外部引用
 type ProductSrv = interfaces.ProductService
 
+var (
+	// ErrInvalidPriceRange 价格区间下限大于上限
+	ErrInvalidPriceRange = domain.Keyed(domain.CodeValidation, "product.invalid_price_range", "min_price must not be greater than max_price")
+	// ErrProductNotFound 商品不存在
+	ErrProductNotFound = domain.Keyed(domain.CodeNotFound, "product.not_found", "product not found")
+	// ErrSKUNotFound SKU 不存在或不属于该商品
+	ErrSKUNotFound = domain.Keyed(domain.CodeNotFound, "sku.not_found", "sku not found")
+	// ErrInvalidSchedule 促销价缺少结束时间，或结束时间不晚于开始时间
+	ErrInvalidSchedule = domain.Keyed(domain.CodeValidation, "price.invalid_schedule", "sale price requires effective_until after effective_from")
+	// ErrPriceNotFound 价格记录不存在
+	ErrPriceNotFound = domain.Keyed(domain.CodeNotFound, "price.not_found", "price not found")
+	// ErrPriceInEffect 价格记录已生效，只能通过新的价格记录覆盖
+	ErrPriceInEffect = domain.Keyed(domain.CodeConflict, "price.in_effect", "price is already in effect")
+	// ErrVersionConflict 商品在读取后已被修改
+	ErrVersionConflict = domain.Keyed(domain.CodePreconditionFailed, "product.version_conflict", "product has been modified")
+)
+
 // ProductServiceConfig 商品服务配置
 type ProductServiceConfig struct {
-	ProductRepository repository.ProductRepository
+	ProductRepository  repository.ProductRepository
+	CategoryRepository repository.CategoryRepository
 }
 
 // ProductServiceOption 函数式选项模式
@@ -31,6 +55,13 @@ func WithProductRepository(repo repository.ProductRepository) ProductServiceOpti
 	}
 }
 
+// WithCategoryRepository 注入商品分类仓储依赖，用于校验商品分类与按分类过滤
+func WithCategoryRepository(repo repository.CategoryRepository) ProductServiceOption {
+	return func(config *ProductServiceConfig) {
+		config.CategoryRepository = repo
+	}
+}
+
 // NewProductService 创建商品服务实例
 // 使用函数式选项模式注入依赖
 func NewProductService(opts ...ProductServiceOption) (ProductSrv, error) {
@@ -41,33 +72,300 @@ func NewProductService(opts ...ProductServiceOption) (ProductSrv, error) {
 	if config.ProductRepository == nil {
 		return nil, errors.New("product repository is required")
 	}
+	if config.CategoryRepository == nil {
+		return nil, errors.New("category repository is required")
+	}
 	return &productService{config: config}, nil
 }
 
 func (s *productService) CreateProduct(ctx context.Context, product *model.Product) error {
+	if err := s.resolveCategories(ctx, product); err != nil {
+		return err
+	}
 	return s.config.ProductRepository.CreateProduct(ctx, product)
 }
 
 func (s *productService) GetProduct(ctx context.Context, id string) (*model.Product, error) {
-	return s.config.ProductRepository.GetProduct(ctx, id)
+	product, err := s.config.ProductRepository.GetProduct(ctx, id)
+	if err != nil {
+		return nil, notFound(err, ErrProductNotFound)
+	}
+	return product, nil
 }
 
 func (s *productService) UpdateProduct(ctx context.Context, product *model.Product) error {
-	return s.config.ProductRepository.UpdateProduct(ctx, product)
+	if err := s.resolveCategories(ctx, product); err != nil {
+		return err
+	}
+	if err := s.config.ProductRepository.UpdateProduct(ctx, product); err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return ErrVersionConflict
+		}
+		return notFound(err, ErrProductNotFound)
+	}
+	return nil
 }
 
 func (s *productService) DeleteProduct(ctx context.Context, id string) error {
-	return s.config.ProductRepository.DeleteProduct(ctx, id)
+	return notFound(s.config.ProductRepository.DeleteProduct(ctx, id), ErrProductNotFound)
+}
+
+func (s *productService) RestoreProduct(ctx context.Context, id string) error {
+	return notFound(s.config.ProductRepository.RestoreProduct(ctx, id), ErrProductNotFound)
+}
+
+func (s *productService) ListDeletedProductIDs(ctx context.Context, before time.Time) ([]string, error) {
+	return s.config.ProductRepository.ListDeletedProductIDs(ctx, before)
+}
+
+func (s *productService) PurgeProduct(ctx context.Context, id string) error {
+	return s.config.ProductRepository.PurgeProduct(ctx, id)
+}
+
+func (s *productService) ListProducts(ctx context.Context, filter model.ProductFilter, spec *queryspec.Spec, page, pageSize int) ([]*model.Product, *queryspec.PageInfo, error) {
+	spec.SetPage(page, pageSize)
+
+	found, err := s.resolveFilter(ctx, &filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !found {
+		var total int64
+		return []*model.Product{}, &queryspec.PageInfo{Total: &total}, nil
+	}
+	return s.config.ProductRepository.ListProducts(ctx, filter, spec)
 }
 
-func (s *productService) ListProducts(ctx context.Context, page, pageSize int) ([]*model.Product, int64, error) {
-	// 计算偏移量
+func (s *productService) SearchProducts(ctx context.Context, query string, filter model.ProductFilter, page, pageSize int) ([]*model.ProductSearchHit, int64, error) {
+	if filter.MinPrice != nil && filter.MaxPrice != nil && *filter.MinPrice > *filter.MaxPrice {
+		return nil, 0, ErrInvalidPriceRange
+	}
 	offset := (page - 1) * pageSize
 	if offset < 0 {
 		offset = 0
 	}
 	if pageSize <= 0 {
-		pageSize = 10 // 默认每页 10 条
+		pageSize = 10
+	}
+
+	found, err := s.resolveFilter(ctx, &filter)
+	if err != nil || !found {
+		return []*model.ProductSearchHit{}, 0, err
+	}
+	return s.config.ProductRepository.SearchProducts(ctx, query, filter, offset, pageSize)
+}
+
+// resolveFilter 将分类过滤条件展开为分类子树，结果包含所有子分类下的商品
+// 分类不存在时返回 false，调用方应返回空列表
+func (s *productService) resolveFilter(ctx context.Context, filter *model.ProductFilter) (bool, error) {
+	if filter.Category == "" {
+		return true, nil
+	}
+	category, err := s.findCategory(ctx, filter.Category)
+	if err != nil {
+		return false, nil
+	}
+	filter.CategoryIDs, err = s.config.CategoryRepository.ListDescendantIDs(ctx, category.ID)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// findCategory 按 ID 或 slug 查找分类
+func (s *productService) findCategory(ctx context.Context, idOrSlug string) (*model.Category, error) {
+	if category, err := s.config.CategoryRepository.GetCategory(ctx, idOrSlug); err == nil {
+		return category, nil
+	}
+	return s.config.CategoryRepository.GetCategoryBySlug(ctx, idOrSlug)
+}
+
+// resolveCategories 校验商品关联的分类均存在，并去除重复项
+func (s *productService) resolveCategories(ctx context.Context, product *model.Product) error {
+	seen := make(map[string]bool, len(product.Categories))
+	categories := make([]model.Category, 0, len(product.Categories))
+	for _, c := range product.Categories {
+		if seen[c.ID] {
+			continue
+		}
+		seen[c.ID] = true
+		category, err := s.config.CategoryRepository.GetCategory(ctx, c.ID)
+		if err != nil {
+			return notFound(err, domain.Errorf(domain.CodeValidation, "category not found: %s", c.ID))
+		}
+		categories = append(categories, *category)
+	}
+	product.Categories = categories
+	return nil
+}
+
+func (s *productService) CreateSKU(ctx context.Context, sku *model.SKU) error {
+	if err := s.validateSKU(ctx, sku); err != nil {
+		return err
+	}
+	return s.config.ProductRepository.CreateSKU(ctx, sku)
+}
+
+func (s *productService) GetSKU(ctx context.Context, productID, skuID string) (*model.SKU, error) {
+	sku, err := s.config.ProductRepository.GetSKU(ctx, skuID)
+	if err != nil {
+		return nil, notFound(err, ErrSKUNotFound)
+	}
+	if sku.ProductID != productID {
+		return nil, ErrSKUNotFound
+	}
+	return sku, nil
+}
+
+func (s *productService) UpdateSKU(ctx context.Context, sku *model.SKU) error {
+	if err := s.validateSKU(ctx, sku); err != nil {
+		return err
+	}
+	return s.config.ProductRepository.UpdateSKU(ctx, sku)
+}
+
+func (s *productService) DeleteSKU(ctx context.Context, productID, skuID string) error {
+	if _, err := s.GetSKU(ctx, productID, skuID); err != nil {
+		return err
+	}
+	return s.config.ProductRepository.DeleteSKU(ctx, skuID)
+}
+
+func (s *productService) ListPrices(ctx context.Context, productID, skuID string) (*model.ListPricesResponse, error) {
+	product, sku, err := s.priceTarget(ctx, productID, skuID)
+	if err != nil {
+		return nil, err
+	}
+	history, err := s.config.ProductRepository.ListPrices(ctx, productID, skuID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.ListPricesResponse{
+		ProductID: productID,
+		SKUID:     skuID,
+		Current:   product.CurrentPrice(sku, time.Now()),
+		History:   history,
+	}, nil
+}
+
+// SchedulePrice 预约调价或设置促销价
+// 开始时间为空或已过去时按当前时间处理；立即生效的基础价通过更新商品或 SKU 记录，
+// 使商品价格字段（排序、过滤使用）保持一致
+func (s *productService) SchedulePrice(ctx context.Context, price *model.ProductPrice) error {
+	product, sku, err := s.priceTarget(ctx, price.ProductID, price.SKUID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if price.EffectiveFrom.Before(now) {
+		price.EffectiveFrom = now
+	}
+	switch price.Kind {
+	case model.PriceKindSale:
+		if price.EffectiveUntil == nil || !price.EffectiveUntil.After(price.EffectiveFrom) {
+			return ErrInvalidSchedule
+		}
+	case model.PriceKindBase:
+		price.EffectiveUntil = nil
+		if !price.EffectiveFrom.After(now) {
+			if sku != nil {
+				sku.Price = &price.Price
+				sku.ChangedBy = price.Actor
+				return s.config.ProductRepository.UpdateSKU(ctx, sku)
+			}
+			product.Price = price.Price
+			product.ChangedBy = price.Actor
+			return s.config.ProductRepository.UpdateProduct(ctx, product)
+		}
+	}
+	return s.config.ProductRepository.CreatePrice(ctx, price)
+}
+
+func (s *productService) CancelPrice(ctx context.Context, productID string, priceID uint) error {
+	price, err := s.config.ProductRepository.GetPrice(ctx, priceID)
+	if err != nil {
+		return notFound(err, ErrPriceNotFound)
+	}
+	if price.ProductID != productID {
+		return ErrPriceNotFound
+	}
+	if !price.EffectiveFrom.After(time.Now()) {
+		return ErrPriceInEffect
+	}
+	return s.config.ProductRepository.DeletePrice(ctx, priceID)
+}
+
+// priceTarget 读取价格记录作用的商品与 SKU，skuID 为空时 SKU 为 nil
+func (s *productService) priceTarget(ctx context.Context, productID, skuID string) (*model.Product, *model.SKU, error) {
+	product, err := s.config.ProductRepository.GetProduct(ctx, productID)
+	if err != nil {
+		return nil, nil, notFound(err, ErrProductNotFound)
+	}
+	if skuID == "" {
+		return product, nil, nil
+	}
+	for i := range product.SKUs {
+		if product.SKUs[i].ID == skuID {
+			return product, &product.SKUs[i], nil
+		}
+	}
+	return nil, nil, ErrSKUNotFound
+}
+
+// validateSKU 校验 SKU 编码唯一，且属性与同商品其他 SKU 构成完整的规格矩阵
+// 所有 SKU 的属性名必须一致，属性组合不能重复
+func (s *productService) validateSKU(ctx context.Context, sku *model.SKU) error {
+	if sku.Code == "" {
+		return domain.Keyed(domain.CodeValidation, "sku.code_required", "sku code is required")
+	}
+	if len(sku.Attributes) == 0 {
+		return domain.Keyed(domain.CodeValidation, "sku.attributes_required", "sku attributes are required")
+	}
+	if existing, err := s.config.ProductRepository.GetSKUByCode(ctx, sku.Code); err == nil && existing.ID != sku.ID {
+		return domain.Keyed(domain.CodeConflict, "sku.code_exists", "sku code already exists")
+	}
+
+	product, err := s.config.ProductRepository.GetProduct(ctx, sku.ProductID)
+	if err != nil {
+		return notFound(err, ErrProductNotFound)
+	}
+
+	names, combination := attributeKey(sku.Attributes)
+	for _, other := range product.SKUs {
+		if other.ID == sku.ID {
+			continue
+		}
+		otherNames, otherCombination := attributeKey(other.Attributes)
+		if otherNames != names {
+			return domain.Errorf(domain.CodeValidation, "sku attributes must match existing variants: %s", otherNames)
+		}
+		if otherCombination == combination {
+			return domain.Keyed(domain.CodeConflict, "sku.duplicate_attributes", "a sku with the same attributes already exists")
+		}
+	}
+	return nil
+}
+
+// notFound 将记录不存在转换为 target，其余错误原样返回
+func notFound(err, target error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return target
+	}
+	return err
+}
+
+// attributeKey 返回属性名列表与属性组合的规范化表示，用于比较
+func attributeKey(attributes map[string]string) (names, combination string) {
+	keys := make([]string, 0, len(attributes))
+	for k := range attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k+"="+attributes[k])
 	}
-	return s.config.ProductRepository.ListProducts(ctx, offset, pageSize)
+	return strings.Join(keys, ","), strings.Join(pairs, ",")
 }
@@ -0,0 +1,298 @@
+package promotion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/innovationmech/simple-cli/internal/domain"
+	"github.com/innovationmech/simple-cli/internal/interfaces"
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/queryspec"
+	"github.com/innovationmech/simple-cli/internal/repository"
+	"gorm.io/gorm"
+)
+
+// PromotionSrv 是 PromotionService 接口的别名
+type PromotionSrv = interfaces.PromotionService
+
+// ErrCouponNotFound 优惠券不存在
+var ErrCouponNotFound = domain.Keyed(domain.CodeNotFound, "coupon.not_found", "coupon not found")
+
+type promotionService struct {
+	couponRepo   repository.CouponRepository
+	categoryRepo repository.CategoryRepository
+	txManager    repository.TxManager
+}
+
+// NewPromotionService 创建促销服务实例
+// 此函数可作为 Wire Provider 使用，也可在 Container 中手动调用
+func NewPromotionService(
+	couponRepo repository.CouponRepository,
+	categoryRepo repository.CategoryRepository,
+	txManager repository.TxManager,
+) PromotionSrv {
+	return &promotionService{
+		couponRepo:   couponRepo,
+		categoryRepo: categoryRepo,
+		txManager:    txManager,
+	}
+}
+
+func (s *promotionService) CreateCoupon(ctx context.Context, coupon *model.Coupon) error {
+	coupon.Code = NormalizeCode(coupon.Code)
+	if err := validateCoupon(coupon); err != nil {
+		return err
+	}
+	if _, err := s.couponRepo.GetCouponByCode(ctx, coupon.Code); err == nil {
+		return domain.Keyed(domain.CodeConflict, "coupon.code_exists", "coupon code already exists")
+	}
+	return s.couponRepo.CreateCoupon(ctx, coupon)
+}
+
+func (s *promotionService) GetCoupon(ctx context.Context, id string) (*model.Coupon, error) {
+	coupon, err := s.couponRepo.GetCoupon(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCouponNotFound
+		}
+		return nil, err
+	}
+	return coupon, nil
+}
+
+func (s *promotionService) UpdateCoupon(ctx context.Context, coupon *model.Coupon) error {
+	if err := validateCoupon(coupon); err != nil {
+		return err
+	}
+	return s.couponRepo.UpdateCoupon(ctx, coupon)
+}
+
+func (s *promotionService) DeleteCoupon(ctx context.Context, id string) error {
+	return s.couponRepo.DeleteCoupon(ctx, id)
+}
+
+func (s *promotionService) ListCoupons(ctx context.Context, spec *queryspec.Spec, page, pageSize int) ([]*model.Coupon, *queryspec.PageInfo, error) {
+	spec.SetPage(page, pageSize)
+	return s.couponRepo.ListCoupons(ctx, spec)
+}
+
+// CalculateDiscounts 依次计算每张优惠券的优惠金额
+// 每张券只作用于其适用范围内的商品，所有优惠之和不超过商品总额
+func (s *promotionService) CalculateDiscounts(ctx context.Context, userID string, items []model.OrderItem, codes []string) ([]model.OrderDiscount, error) {
+	var discounts []model.OrderDiscount
+	seen := make(map[string]bool)
+	remaining := 0.0
+	for _, item := range items {
+		remaining += item.Amount
+	}
+
+	now := time.Now()
+	for _, raw := range codes {
+		code := NormalizeCode(raw)
+		if code == "" || seen[code] {
+			continue
+		}
+		seen[code] = true
+
+		coupon, err := s.couponRepo.GetCouponByCode(ctx, code)
+		if err != nil {
+			return nil, domain.Errorf(domain.CodeUnprocessable, "coupon %s not found", code)
+		}
+		if err := s.checkAvailability(ctx, coupon, userID, now); err != nil {
+			return nil, err
+		}
+
+		targets, err := s.scopedProducts(ctx, coupon)
+		if err != nil {
+			return nil, err
+		}
+		eligible := eligibleAmount(targets, items)
+		if eligible <= 0 {
+			return nil, domain.Errorf(domain.CodeUnprocessable, "coupon %s is not applicable to the order items", code)
+		}
+		if eligible < coupon.MinSpend {
+			return nil, domain.Errorf(domain.CodeUnprocessable, "coupon %s requires a minimum spend of %.2f", code, coupon.MinSpend)
+		}
+
+		amount := math.Min(discountAmount(coupon, eligible), remaining)
+		remaining -= amount
+		discounts = append(discounts, model.OrderDiscount{
+			CouponID:   coupon.ID,
+			Code:       coupon.Code,
+			Amount:     amount,
+			ProductIDs: productIDs(targets),
+		})
+	}
+	return discounts, nil
+}
+
+// RedeemDiscounts 在事务中逐张核销优惠券，任意一张超出限制则整体回滚
+func (s *promotionService) RedeemDiscounts(ctx context.Context, userID string, discounts []model.OrderDiscount) error {
+	if len(discounts) == 0 {
+		return nil
+	}
+	return s.txManager.Transaction(ctx, func(ctx context.Context) error {
+		for _, d := range discounts {
+			coupon, err := s.couponRepo.GetCoupon(ctx, d.CouponID)
+			if err != nil {
+				return domain.Errorf(domain.CodeUnprocessable, "coupon %s not found", d.Code)
+			}
+			if err := s.couponRepo.Redeem(ctx, coupon, userID); err != nil {
+				if errors.Is(err, repository.ErrCouponExhausted) || errors.Is(err, repository.ErrCouponUserLimit) ||
+					errors.Is(err, repository.ErrCouponUnavailable) {
+					return fmt.Errorf("coupon %s: %w", d.Code, err)
+				}
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *promotionService) ReleaseDiscounts(ctx context.Context, userID string, discounts []model.OrderDiscount) error {
+	if len(discounts) == 0 {
+		return nil
+	}
+	return s.txManager.Transaction(ctx, func(ctx context.Context) error {
+		for _, d := range discounts {
+			if err := s.couponRepo.Release(ctx, d.CouponID, userID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// checkAvailability 检查优惠券当前是否可用
+// 此处的次数检查仅用于提前给出友好提示，最终以 Redeem 的原子核销为准
+func (s *promotionService) checkAvailability(ctx context.Context, coupon *model.Coupon, userID string, now time.Time) error {
+	if !coupon.Active {
+		return domain.Errorf(domain.CodeUnprocessable, "coupon %s is not active", coupon.Code)
+	}
+	if coupon.StartsAt != nil && now.Before(*coupon.StartsAt) {
+		return domain.Errorf(domain.CodeUnprocessable, "coupon %s is not yet valid", coupon.Code)
+	}
+	if coupon.EndsAt != nil && now.After(*coupon.EndsAt) {
+		return domain.Errorf(domain.CodeUnprocessable, "coupon %s has expired", coupon.Code)
+	}
+	if coupon.UsageLimit > 0 && coupon.UsedCount >= coupon.UsageLimit {
+		return fmt.Errorf("coupon %s: %w", coupon.Code, repository.ErrCouponExhausted)
+	}
+	if coupon.PerUserLimit > 0 {
+		used, err := s.couponRepo.GetUsageCount(ctx, coupon.ID, userID)
+		if err != nil {
+			return err
+		}
+		if used >= coupon.PerUserLimit {
+			return fmt.Errorf("coupon %s: %w", coupon.Code, repository.ErrCouponUserLimit)
+		}
+	}
+	return nil
+}
+
+// NormalizeCode 规范化优惠码（去除空白并转为大写）
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// validateCoupon 校验优惠券规则
+func validateCoupon(coupon *model.Coupon) error {
+	if coupon.Code == "" {
+		return domain.Keyed(domain.CodeValidation, "coupon.code_required", "coupon code is required")
+	}
+	switch coupon.Type {
+	case model.CouponTypePercentage:
+		if coupon.Value <= 0 || coupon.Value > 100 {
+			return domain.Keyed(domain.CodeValidation, "coupon.invalid_percentage", "percentage coupon value must be between 0 and 100")
+		}
+	case model.CouponTypeFixed:
+		if coupon.Value <= 0 {
+			return domain.Keyed(domain.CodeValidation, "coupon.invalid_amount", "fixed coupon value must be positive")
+		}
+	default:
+		return domain.Keyed(domain.CodeValidation, "coupon.invalid_type", "invalid coupon type")
+	}
+	if coupon.StartsAt != nil && coupon.EndsAt != nil && !coupon.EndsAt.After(*coupon.StartsAt) {
+		return domain.Keyed(domain.CodeValidation, "coupon.invalid_period", "coupon ends_at must be after starts_at")
+	}
+	for _, scope := range coupon.Scopes {
+		if scope.Type != model.CouponScopeProduct && scope.Type != model.CouponScopeCategory {
+			return domain.Keyed(domain.CodeValidation, "coupon.invalid_scope", "invalid coupon scope type")
+		}
+	}
+	return nil
+}
+
+// scopedProducts 展开优惠券适用范围内的商品集合，全场通用时返回 nil
+// 分类范围包含该分类及其所有子分类下的商品
+func (s *promotionService) scopedProducts(ctx context.Context, coupon *model.Coupon) (map[string]bool, error) {
+	if len(coupon.Scopes) == 0 {
+		return nil, nil
+	}
+
+	products := make(map[string]bool)
+	for _, scope := range coupon.Scopes {
+		switch scope.Type {
+		case model.CouponScopeProduct:
+			products[scope.TargetID] = true
+		case model.CouponScopeCategory:
+			categoryIDs, err := s.categoryRepo.ListDescendantIDs(ctx, scope.TargetID)
+			if err != nil {
+				return nil, err
+			}
+			if len(categoryIDs) == 0 {
+				continue
+			}
+			ids, err := s.categoryRepo.ListProductIDs(ctx, categoryIDs)
+			if err != nil {
+				return nil, err
+			}
+			for _, id := range ids {
+				products[id] = true
+			}
+		}
+	}
+	return products, nil
+}
+
+// eligibleAmount 计算优惠券适用范围内的商品金额，targets 为 nil 表示全部商品
+func eligibleAmount(targets map[string]bool, items []model.OrderItem) float64 {
+	total := 0.0
+	for _, item := range items {
+		if targets == nil || targets[item.ProductID] {
+			total += item.Amount
+		}
+	}
+	return total
+}
+
+// productIDs 将商品集合转换为 ID 列表，nil 表示全场通用
+func productIDs(targets map[string]bool) []string {
+	if targets == nil {
+		return nil
+	}
+	ids := make([]string, 0, len(targets))
+	for id := range targets {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// discountAmount 按优惠券类型计算优惠金额，结果保留两位小数
+func discountAmount(coupon *model.Coupon, eligible float64) float64 {
+	var amount float64
+	switch coupon.Type {
+	case model.CouponTypePercentage:
+		amount = eligible * coupon.Value / 100
+		if coupon.MaxDiscount > 0 {
+			amount = math.Min(amount, coupon.MaxDiscount)
+		}
+	case model.CouponTypeFixed:
+		amount = math.Min(coupon.Value, eligible)
+	}
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,60 @@
+package promotion
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/repository"
+	"github.com/innovationmech/simple-cli/internal/testutil"
+)
+
+func TestRedeemDiscountsChecksValidity(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	tests := []struct {
+		name   string
+		coupon model.Coupon
+		want   error
+	}{
+		{name: "active", coupon: model.Coupon{Active: true}},
+		{name: "within period", coupon: model.Coupon{Active: true, StartsAt: &past, EndsAt: &future}},
+		{name: "deactivated", coupon: model.Coupon{Active: false}, want: repository.ErrCouponUnavailable},
+		{name: "not started", coupon: model.Coupon{Active: true, StartsAt: &future}, want: repository.ErrCouponUnavailable},
+		{name: "expired", coupon: model.Coupon{Active: true, EndsAt: &past}, want: repository.ErrCouponUnavailable},
+		{name: "exhausted", coupon: model.Coupon{Active: true, UsageLimit: 1, UsedCount: 1}, want: repository.ErrCouponExhausted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := testutil.NewDB(t)
+			couponRepo := repository.NewCouponRepository(db)
+			srv := NewPromotionService(couponRepo, repository.NewCategoryRepository(db), repository.NewTxManager(db))
+
+			// 模拟计价后优惠券被停用或过期：直接写入数据库，绕过创建时的校验
+			coupon := tt.coupon
+			coupon.ID, coupon.Code, coupon.Type, coupon.Value = "c1", "SAVE5", model.CouponTypeFixed, 5
+			if err := db.Create(&coupon).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			err := srv.RedeemDiscounts(context.Background(), "u1", []model.OrderDiscount{{CouponID: "c1", Code: "SAVE5", Amount: 5}})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("RedeemDiscounts err = %v, want %v", err, tt.want)
+			}
+
+			stored, err := couponRepo.GetCoupon(context.Background(), "c1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantUsed := tt.coupon.UsedCount
+			if tt.want == nil {
+				wantUsed++
+			}
+			if stored.UsedCount != wantUsed {
+				t.Errorf("used count %d, want %d", stored.UsedCount, wantUsed)
+			}
+		})
+	}
+}
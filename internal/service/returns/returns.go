@@ -0,0 +1,402 @@
+package returns
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/innovationmech/simple-cli/internal/domain"
+	"github.com/innovationmech/simple-cli/internal/interfaces"
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/queryspec"
+	"github.com/innovationmech/simple-cli/internal/repository"
+	"gorm.io/gorm"
+)
+
+// ReturnSrv 是 ReturnService 接口的别名，方便外部引用
+type ReturnSrv = interfaces.ReturnService
+
+var (
+	// ErrReturnNotFound 退货申请不存在
+	ErrReturnNotFound = domain.Keyed(domain.CodeNotFound, "return.not_found", "return not found")
+	// ErrOrderNotFound 订单不存在或不属于申请用户
+	ErrOrderNotFound = domain.Keyed(domain.CodeNotFound, "order.not_found", "order not found")
+	// ErrOrderNotReturnable 只有已完成的订单可以退货
+	ErrOrderNotReturnable = domain.Keyed(domain.CodeInvalidTransition, "return.order_not_completed", "only completed orders can be returned")
+	// ErrReturnInProgress 订单已有进行中的退货申请
+	ErrReturnInProgress = domain.Keyed(domain.CodeConflict, "return.in_progress", "order already has a return in progress")
+	// ErrQuantityExceeded 退货数量超过购买数量
+	ErrQuantityExceeded = domain.Keyed(domain.CodeUnprocessable, "return.quantity_exceeded", "return quantity exceeds ordered quantity")
+	// ErrNotApproved 只有已批准的退货可以确认收货
+	ErrNotApproved = domain.Keyed(domain.CodeInvalidTransition, "return.not_approved", "only approved returns can be received")
+	// ErrAlreadyReviewed 退货申请已审核
+	ErrAlreadyReviewed = domain.Keyed(domain.CodeInvalidTransition, "return.already_reviewed", "return has already been reviewed")
+	// ErrNoSuccessfulPayment 订单没有可退款的成功支付
+	ErrNoSuccessfulPayment = domain.Keyed(domain.CodeUnprocessable, "return.no_payment", "no successful payment found for order")
+)
+
+// ReturnServiceConfig 退货服务配置
+type ReturnServiceConfig struct {
+	ReturnRepository  repository.ReturnRepository
+	OrderRepository   repository.OrderRepository
+	ProductRepository repository.ProductRepository
+	PaymentRepository repository.PaymentRepository
+	PaymentService    interfaces.PaymentService
+	TxManager         repository.TxManager
+}
+
+// ReturnServiceOption 函数式选项模式
+type ReturnServiceOption func(*ReturnServiceConfig)
+
+type returnService struct {
+	config *ReturnServiceConfig
+}
+
+// WithReturnRepository 注入退货申请仓储依赖
+func WithReturnRepository(repo repository.ReturnRepository) ReturnServiceOption {
+	return func(config *ReturnServiceConfig) {
+		config.ReturnRepository = repo
+	}
+}
+
+// WithOrderRepository 注入订单仓储依赖
+func WithOrderRepository(repo repository.OrderRepository) ReturnServiceOption {
+	return func(config *ReturnServiceConfig) {
+		config.OrderRepository = repo
+	}
+}
+
+// WithProductRepository 注入商品仓储依赖，用于退货入库
+func WithProductRepository(repo repository.ProductRepository) ReturnServiceOption {
+	return func(config *ReturnServiceConfig) {
+		config.ProductRepository = repo
+	}
+}
+
+// WithPaymentRepository 注入支付仓储依赖，用于查找订单的支付记录
+func WithPaymentRepository(repo repository.PaymentRepository) ReturnServiceOption {
+	return func(config *ReturnServiceConfig) {
+		config.PaymentRepository = repo
+	}
+}
+
+// WithPaymentService 注入支付服务依赖，用于发起退款
+func WithPaymentService(srv interfaces.PaymentService) ReturnServiceOption {
+	return func(config *ReturnServiceConfig) {
+		config.PaymentService = srv
+	}
+}
+
+// WithTxManager 注入事务管理器
+func WithTxManager(txManager repository.TxManager) ReturnServiceOption {
+	return func(config *ReturnServiceConfig) {
+		config.TxManager = txManager
+	}
+}
+
+// NewReturnService 创建退货服务实例
+// 使用函数式选项模式注入依赖
+func NewReturnService(opts ...ReturnServiceOption) (ReturnSrv, error) {
+	config := &ReturnServiceConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	if config.ReturnRepository == nil {
+		return nil, errors.New("return repository is required")
+	}
+	if config.OrderRepository == nil {
+		return nil, errors.New("order repository is required")
+	}
+	if config.ProductRepository == nil {
+		return nil, errors.New("product repository is required")
+	}
+	if config.PaymentRepository == nil {
+		return nil, errors.New("payment repository is required")
+	}
+	if config.PaymentService == nil {
+		return nil, errors.New("payment service is required")
+	}
+	if config.TxManager == nil {
+		return nil, errors.New("tx manager is required")
+	}
+	return &returnService{config: config}, nil
+}
+
+func (s *returnService) CreateReturn(ctx context.Context, ret *model.ReturnRequest) error {
+	order, err := s.getOrder(ctx, ret.OrderID)
+	if err != nil {
+		return err
+	}
+	if order.UserID != ret.UserID {
+		return ErrOrderNotFound
+	}
+
+	// 只有已完成的订单可以申请退货
+	if order.Status != model.OrderStatusCompleted {
+		return ErrOrderNotReturnable
+	}
+
+	// 每个订单同时只能存在一个待处理的退货申请；已退回的数量不能再次退货
+	existing, err := s.config.ReturnRepository.ListReturnsByOrder(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+	for _, r := range existing {
+		if r.Status == model.ReturnStatusRequested || r.Status == model.ReturnStatusApproved {
+			return ErrReturnInProgress
+		}
+	}
+	returned := returnedQuantities(existing)
+
+	// 校验退货数量不超过剩余可退数量，同一商品的不同 SKU 分别校验；
+	// 同一商品/SKU 出现在多行时合并数量与金额，按合并后的实付金额计算退款
+	ordered := make(map[string]model.OrderItem)
+	for _, item := range order.Items {
+		key := itemKey(item.ProductID, item.SKUID)
+		merged := ordered[key]
+		merged.Quantity += item.Quantity
+		merged.Amount += item.Amount
+		merged.DiscountAmount += item.DiscountAmount
+		merged.TaxAmount += item.TaxAmount
+		ordered[key] = merged
+	}
+	requested := make(map[string]int)
+	for _, item := range ret.Items {
+		requested[itemKey(item.ProductID, item.SKUID)] += item.Quantity
+	}
+
+	// 按商品行实付金额（扣除分摊优惠并含税）计算退款，运费不退；
+	// 以累计退货数量的应退金额之差计算，多次部分退货的退款合计不超过该行实付金额
+	ret.RefundAmount = 0
+	for key, quantity := range requested {
+		item, ok := ordered[key]
+		if !ok || returned[key]+quantity > item.Quantity {
+			return ErrQuantityExceeded
+		}
+		ret.RefundAmount += roundAmount(item.RefundableAmount(returned[key]+quantity)) -
+			roundAmount(item.RefundableAmount(returned[key]))
+	}
+	ret.RefundAmount = roundAmount(ret.RefundAmount)
+	ret.Status = model.ReturnStatusRequested
+
+	return s.config.TxManager.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.config.ReturnRepository.CreateReturn(ctx, ret); err != nil {
+			return err
+		}
+		return s.recordOrderHistory(ctx, order, model.OrderEventReturnRequested, ret.Reason)
+	})
+}
+
+func (s *returnService) GetReturn(ctx context.Context, id string) (*model.ReturnRequest, error) {
+	ret, err := s.config.ReturnRepository.GetReturn(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReturnNotFound
+		}
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (s *returnService) ApproveReturn(ctx context.Context, id, note string) error {
+	return s.review(ctx, id, note, model.ReturnStatusApproved, model.OrderEventReturnApproved)
+}
+
+func (s *returnService) RejectReturn(ctx context.Context, id, note string) error {
+	return s.review(ctx, id, note, model.ReturnStatusRejected, model.OrderEventReturnRejected)
+}
+
+// ReceiveReturn 确认收到退货
+// 在同一事务中完成：标记已收货 → 商品入库 → 通过支付服务退款 → 标记已退款
+func (s *returnService) ReceiveReturn(ctx context.Context, id string) error {
+	ret, err := s.GetReturn(ctx, id)
+	if err != nil {
+		return err
+	}
+	if ret.Status != model.ReturnStatusApproved {
+		return ErrNotApproved
+	}
+
+	order, err := s.getOrder(ctx, ret.OrderID)
+	if err != nil {
+		return err
+	}
+
+	payment, err := s.findSuccessfulPayment(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+
+	existing, err := s.config.ReturnRepository.ListReturnsByOrder(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+	returned := returnedQuantities(existing)
+	for _, item := range ret.Items {
+		returned[itemKey(item.ProductID, item.SKUID)] += item.Quantity
+	}
+
+	return s.config.TxManager.Transaction(ctx, func(ctx context.Context) error {
+		now := time.Now()
+
+		// 收货并入库
+		ret.Status = model.ReturnStatusReceived
+		ret.ReceivedAt = &now
+		if err := s.config.ReturnRepository.UpdateReturn(ctx, ret); err != nil {
+			return err
+		}
+		for _, item := range ret.Items {
+			if err := s.config.ProductRepository.AdjustStock(ctx, &model.InventoryAdjustment{
+				ProductID: item.ProductID,
+				SKUID:     item.SKUID,
+				Type:      model.AdjustmentReceive,
+				Delta:     item.Quantity,
+				Reason:    "return received",
+				Reference: ret.ID,
+			}); err != nil {
+				return err
+			}
+		}
+		if err := s.recordOrderHistory(ctx, order, model.OrderEventReturnReceived, ""); err != nil {
+			return err
+		}
+
+		// 退款
+		if err := s.config.PaymentService.RefundPayment(ctx, payment.ID, ret.RefundAmount, "return "+ret.ID); err != nil {
+			return err
+		}
+		ret.Status = model.ReturnStatusRefunded
+		ret.PaymentID = payment.ID
+		ret.RefundedAt = &now
+		if err := s.config.ReturnRepository.UpdateReturn(ctx, ret); err != nil {
+			return err
+		}
+
+		// 历次退货合计退回全部商品时订单转为已退货
+		from := order.Status
+		if totalQuantity(returned) >= order.TotalQuantity() {
+			order.Status = model.OrderStatusReturned
+			if err := s.config.OrderRepository.UpdateOrder(ctx, order); err != nil {
+				return err
+			}
+		}
+		return s.config.OrderRepository.AddHistory(ctx, &model.OrderHistory{
+			OrderID:    order.ID,
+			Event:      model.OrderEventReturnRefunded,
+			FromStatus: from,
+			ToStatus:   order.Status,
+			Note:       "return " + ret.ID,
+		})
+	})
+}
+
+func (s *returnService) ListReturns(ctx context.Context, orderID, userID string, status model.ReturnStatus, spec *queryspec.Spec, page, pageSize int) ([]*model.ReturnRequest, *queryspec.PageInfo, error) {
+	spec.SetPage(page, pageSize)
+	return s.config.ReturnRepository.ListReturns(ctx, orderID, userID, status, spec)
+}
+
+// review 审核退货申请，只有待审核的申请可以被批准或拒绝
+func (s *returnService) review(ctx context.Context, id, note string, status model.ReturnStatus, event model.OrderEvent) error {
+	ret, err := s.GetReturn(ctx, id)
+	if err != nil {
+		return err
+	}
+	if ret.Status != model.ReturnStatusRequested {
+		return ErrAlreadyReviewed
+	}
+
+	order, err := s.getOrder(ctx, ret.OrderID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	ret.Status = status
+	ret.ReviewNote = note
+	if status == model.ReturnStatusApproved {
+		ret.ApprovedAt = &now
+	} else {
+		ret.RejectedAt = &now
+	}
+
+	return s.config.TxManager.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.config.ReturnRepository.UpdateReturn(ctx, ret); err != nil {
+			return err
+		}
+		return s.recordOrderHistory(ctx, order, event, note)
+	})
+}
+
+// getOrder 读取订单，不存在时返回 ErrOrderNotFound
+func (s *returnService) getOrder(ctx context.Context, id string) (*model.Order, error) {
+	order, err := s.config.OrderRepository.GetOrder(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	return order, nil
+}
+
+// findSuccessfulPayment 查找订单的成功支付记录
+func (s *returnService) findSuccessfulPayment(ctx context.Context, orderID string) (*model.Payment, error) {
+	spec := model.PaymentQuerySchema.Default()
+	spec.Limit = 100
+	spec.SkipTotal = true
+	payments, _, err := s.config.PaymentRepository.ListPayments(ctx, "", orderID, spec)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range payments {
+		if p.Status == model.PaymentStatusSuccess {
+			return p, nil
+		}
+	}
+	return nil, ErrNoSuccessfulPayment
+}
+
+// recordOrderHistory 记录不改变订单状态的售后事件
+func (s *returnService) recordOrderHistory(ctx context.Context, order *model.Order, event model.OrderEvent, note string) error {
+	return s.config.OrderRepository.AddHistory(ctx, &model.OrderHistory{
+		OrderID:    order.ID,
+		Event:      event,
+		FromStatus: order.Status,
+		ToStatus:   order.Status,
+		Note:       note,
+	})
+}
+
+// returnedQuantities 按商品与 SKU 统计已收货或已退款的退货数量
+func returnedQuantities(returns []*model.ReturnRequest) map[string]int {
+	returned := make(map[string]int)
+	for _, r := range returns {
+		if r.Status != model.ReturnStatusReceived && r.Status != model.ReturnStatusRefunded {
+			continue
+		}
+		for _, item := range r.Items {
+			returned[itemKey(item.ProductID, item.SKUID)] += item.Quantity
+		}
+	}
+	return returned
+}
+
+// totalQuantity 统计商品总数
+func totalQuantity(quantities map[string]int) int {
+	total := 0
+	for _, quantity := range quantities {
+		total += quantity
+	}
+	return total
+}
+
+// itemKey 商品明细的键，同一商品的不同 SKU 分别统计
+func itemKey(productID, skuID string) string {
+	return productID + "/" + skuID
+}
+
+// roundAmount 金额保留两位小数
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,144 @@
+package returns
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/innovationmech/simple-cli/internal/model"
+	"github.com/innovationmech/simple-cli/internal/repository"
+	paymentSrv "github.com/innovationmech/simple-cli/internal/service/payment"
+	"github.com/innovationmech/simple-cli/internal/testutil"
+	"gorm.io/gorm"
+)
+
+// newTestService 使用真实仓储与 SQLite 数据库创建退货服务，并准备一笔已完成的订单
+// 订单中同一商品分两行下单：2 件与 3 件，两行实付合计 49.5
+func newTestService(t *testing.T) (ReturnSrv, *gorm.DB) {
+	t.Helper()
+	db := testutil.NewDB(t)
+	txManager := repository.NewTxManager(db)
+	orderRepo := repository.NewOrderRepository(db)
+	productRepo := repository.NewProductRepository(db)
+	paymentRepo := repository.NewPaymentRepository(db)
+	srv, err := NewReturnService(
+		WithReturnRepository(repository.NewReturnRepository(db)),
+		WithOrderRepository(orderRepo),
+		WithProductRepository(productRepo),
+		WithPaymentRepository(paymentRepo),
+		WithPaymentService(paymentSrv.NewPaymentService(paymentRepo, orderRepo, txManager)),
+		WithTxManager(txManager),
+	)
+	if err != nil {
+		t.Fatalf("NewReturnService: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := productRepo.CreateProduct(ctx, &model.Product{ID: "p1", Name: "Widget", Price: 10}); err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+	order := &model.Order{
+		ID:     "o1",
+		UserID: "u1",
+		Status: model.OrderStatusCompleted,
+		Items: []model.OrderItem{
+			{ProductID: "p1", Quantity: 2, UnitPrice: 10, Amount: 20, DiscountAmount: 2, TaxAmount: 1.8},
+			{ProductID: "p1", Quantity: 3, UnitPrice: 9, Amount: 27, TaxAmount: 2.7},
+		},
+		TotalAmount: 49.5,
+	}
+	if err := orderRepo.CreateOrder(ctx, order); err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	if err := paymentRepo.CreatePayment(ctx, &model.Payment{
+		ID: "pay1", OrderID: "o1", UserID: "u1", Amount: 49.5,
+		Method: model.PaymentMethodBalance, Status: model.PaymentStatusSuccess,
+	}); err != nil {
+		t.Fatalf("create payment: %v", err)
+	}
+	return srv, db
+}
+
+// returnAndRefund 申请退货并完成审核、收货与退款
+func returnAndRefund(t *testing.T, srv ReturnSrv, id string, quantity int) *model.ReturnRequest {
+	t.Helper()
+	ctx := context.Background()
+	ret := &model.ReturnRequest{
+		ID:      id,
+		OrderID: "o1",
+		UserID:  "u1",
+		Items:   []model.ReturnItem{{ProductID: "p1", Quantity: quantity}},
+	}
+	if err := srv.CreateReturn(ctx, ret); err != nil {
+		t.Fatalf("CreateReturn(%d): %v", quantity, err)
+	}
+	if err := srv.ApproveReturn(ctx, id, ""); err != nil {
+		t.Fatalf("ApproveReturn: %v", err)
+	}
+	if err := srv.ReceiveReturn(ctx, id); err != nil {
+		t.Fatalf("ReceiveReturn: %v", err)
+	}
+	return ret
+}
+
+func TestCreateReturnMergesDuplicateLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		quantity int
+		refund   float64
+		err      error
+	}{
+		{name: "within first line", quantity: 1, refund: 9.9},
+		{name: "across both lines", quantity: 4, refund: 39.6},
+		{name: "all items", quantity: 5, refund: 49.5},
+		{name: "over ordered", quantity: 6, err: ErrQuantityExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _ := newTestService(t)
+			ret := &model.ReturnRequest{
+				ID:      "r1",
+				OrderID: "o1",
+				UserID:  "u1",
+				Items:   []model.ReturnItem{{ProductID: "p1", Quantity: tt.quantity}},
+			}
+			err := srv.CreateReturn(context.Background(), ret)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("CreateReturn err = %v, want %v", err, tt.err)
+			}
+			if tt.err == nil && ret.RefundAmount != tt.refund {
+				t.Errorf("refund amount %.2f, want %.2f", ret.RefundAmount, tt.refund)
+			}
+		})
+	}
+}
+
+func TestPartialReturnsAcrossDuplicateLines(t *testing.T) {
+	srv, db := newTestService(t)
+
+	first := returnAndRefund(t, srv, "r1", 4)
+	second := returnAndRefund(t, srv, "r2", 1)
+	if first.RefundAmount+second.RefundAmount != 49.5 {
+		t.Errorf("refunds %.2f + %.2f, want total 49.50", first.RefundAmount, second.RefundAmount)
+	}
+
+	var payment model.Payment
+	if err := db.First(&payment, "id = ?", "pay1").Error; err != nil {
+		t.Fatal(err)
+	}
+	if payment.Status != model.PaymentStatusRefunded || payment.RefundedAmount != 49.5 {
+		t.Errorf("payment %s refunded %.2f, want refunded 49.50", payment.Status, payment.RefundedAmount)
+	}
+	var order model.Order
+	if err := db.First(&order, "id = ?", "o1").Error; err != nil {
+		t.Fatal(err)
+	}
+	if order.Status != model.OrderStatusReturned {
+		t.Errorf("order status %s, want returned", order.Status)
+	}
+
+	ret := &model.ReturnRequest{ID: "r3", OrderID: "o1", UserID: "u1", Items: []model.ReturnItem{{ProductID: "p1", Quantity: 1}}}
+	if err := srv.CreateReturn(context.Background(), ret); err == nil {
+		t.Fatalf("CreateReturn after full return succeeded")
+	}
+}
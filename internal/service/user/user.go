@@ -3,14 +3,20 @@ package user
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/innovationmech/simple-cli/internal/domain"
 	"github.com/innovationmech/simple-cli/internal/interfaces"
 	"github.com/innovationmech/simple-cli/internal/model"
 	"github.com/innovationmech/simple-cli/internal/repository"
+	"gorm.io/gorm"
 )
 
 type UserSrv = interfaces.UserService
 
+// ErrUserNotFound 用户不存在（恢复时为不存在或未被删除）
+var ErrUserNotFound = domain.Keyed(domain.CodeNotFound, "user.not_found", "user not found")
+
 type UserServiceConfig struct {
 	UserRepository repository.UserRepository
 }
@@ -43,7 +49,11 @@ func (s *userService) CreateUser(ctx context.Context, user *model.User) error {
 }
 
 func (s *userService) GetUser(ctx context.Context, id string) (*model.User, error) {
-	return s.config.UserRepository.GetUser(ctx, id)
+	user, err := s.config.UserRepository.GetUser(ctx, id)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return user, nil
 }
 
 func (s *userService) UpdateUser(ctx context.Context, user *model.User) error {
@@ -51,5 +61,21 @@ func (s *userService) UpdateUser(ctx context.Context, user *model.User) error {
 }
 
 func (s *userService) DeleteUser(ctx context.Context, id string) error {
-	return s.config.UserRepository.DeleteUser(ctx, id)
+	return notFound(s.config.UserRepository.DeleteUser(ctx, id))
+}
+
+func (s *userService) RestoreUser(ctx context.Context, id string) error {
+	return notFound(s.config.UserRepository.RestoreUser(ctx, id))
+}
+
+func (s *userService) PurgeDeletedUsers(ctx context.Context, before time.Time) (int64, error) {
+	return s.config.UserRepository.PurgeUsers(ctx, before)
+}
+
+// notFound 将记录不存在转换为 ErrUserNotFound，其余错误原样返回
+func notFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrUserNotFound
+	}
+	return err
 }
@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocalStorePutGetDelete(t *testing.T) {
+	root := t.TempDir()
+	store := NewLocalStore(root)
+	ctx := context.Background()
+	data := []byte("GIF89a fake image")
+
+	if err := store.Put(ctx, "products/p1/a.gif", data, "image/gif"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "products", "p1", "a.gif")); err != nil {
+		t.Fatalf("object not written under root: %v", err)
+	}
+
+	body, contentType, err := store.Get(ctx, "products/p1/a.gif")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	got, _ := io.ReadAll(body)
+	body.Close()
+	if !bytes.Equal(got, data) || contentType != "image/gif" {
+		t.Fatalf("Get = %q (%s), want %q (image/gif)", got, contentType, data)
+	}
+
+	if err := store.Delete(ctx, "products/p1/a.gif"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, _, err := store.Get(ctx, "products/p1/a.gif"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get after delete: err = %v, want ErrNotFound", err)
+	}
+	if err := store.Delete(ctx, "products/p1/a.gif"); err != nil {
+		t.Fatalf("Delete missing: %v", err)
+	}
+}
+
+func TestLocalStoreRejectsEscapingKeys(t *testing.T) {
+	root := t.TempDir()
+	store := NewLocalStore(filepath.Join(root, "media"))
+
+	for _, key := range []string{"", "/etc/passwd", "../outside.png", "a/../../outside.png", "a/./b.png", `a\b.png`} {
+		if err := store.Put(context.Background(), key, []byte("x"), ""); err == nil {
+			t.Errorf("Put(%q): expected invalid key error", key)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(root, "outside.png")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("file written outside root")
+	}
+}
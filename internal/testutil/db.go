@@ -0,0 +1,33 @@
+// Package testutil 提供服务层测试共用的辅助函数
+package testutil
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/innovationmech/simple-cli/internal/config"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// NewDB 在测试临时目录中创建 SQLite 数据库并执行与线上相同的迁移
+// 使用文件而非内存数据库，使事务与多连接的行为与真实部署一致
+func NewDB(t testing.TB) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	if err := config.AutoMigrate(db); err != nil {
+		t.Fatalf("migrate test db: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
@@ -0,0 +1,29 @@
+package types
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/innovationmech/simple-cli/internal/domain"
+)
+
+// ErrIfMatchRequired 更新请求未携带 If-Match 请求头
+var ErrIfMatchRequired = domain.Keyed(domain.CodePreconditionRequired, "request.if_match_required", "If-Match header is required")
+
+// ETag 返回资源版本号对应的强 ETag
+func ETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// IfMatch 判断 If-Match 请求头是否与资源版本号匹配
+// 请求头可以是逗号分隔的多个 ETag，"*" 匹配任意版本；按强比较，弱 ETag 不匹配
+func IfMatch(header string, version int) bool {
+	current := ETag(version)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || tag == current {
+			return true
+		}
+	}
+	return false
+}
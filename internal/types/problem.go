@@ -0,0 +1,25 @@
+package types
+
+// ProblemContentType RFC 7807 错误响应的内容类型
+const ProblemContentType = "application/problem+json"
+
+// FieldError 单个字段的校验错误
+type FieldError struct {
+	// Field 请求中的字段路径，如 items[0].quantity
+	Field string `json:"field"`
+	// Rule 未通过的校验规则，如 required、gte
+	Rule    string `json:"rule,omitempty"`
+	Message string `json:"message"`
+}
+
+// Problem RFC 7807 问题详情，请求 Accept 为 application/problem+json 时代替 ApiResponse 返回
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// Code 领域错误码，与 ErrorDetail.Reason 一致
+	Code   string       `json:"code"`
+	Errors []FieldError `json:"errors,omitempty"`
+}
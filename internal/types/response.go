@@ -7,7 +7,11 @@ type ResponseStatus struct {
 }
 
 type ErrorDetail struct {
-	Code    int    `json:"code"`
+	Code int `json:"code"`
+	// Reason 领域错误码，如 not_found、insufficient_stock，供调用方按类型处理
+	Reason string `json:"reason"`
+	// Field 校验失败的字段路径，仅参数校验错误携带
+	Field   string `json:"field,omitempty"`
 	Message string `json:"message"`
 	Details string `json:"details,omitempty"`
 }